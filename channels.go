@@ -3,10 +3,21 @@ package steward
 import "github.com/dyrkin/zigbee-steward/model"
 
 type Channels struct {
-	onDeviceRegistered      chan *model.Device
-	onDeviceUnregistered    chan *model.Device
-	onDeviceBecameAvailable chan *model.Device
-	onDeviceIncomingMessage chan *model.DeviceIncomingMessage
+	onDeviceRegistered           chan *model.Device
+	onDeviceUnregistered         chan *model.Device
+	onDeviceBecameAvailable      chan *model.Device
+	onDeviceIncomingMessage      chan *model.DeviceIncomingMessage
+	onDeviceZoneStatusChange     chan *model.DeviceZoneStatusChange
+	onDeviceLockOperationEvent   chan *model.DeviceLockOperationEvent
+	onDeviceLockProgrammingEvent chan *model.DeviceLockProgrammingEvent
+	onDeviceOTAProgress          chan *model.DeviceOTAProgress
+	onDeviceEnergyMeasurement    chan *model.DeviceEnergyMeasurement
+	onDeviceSensorReading        chan *model.DeviceSensorReading
+	onDeviceStateChange          chan *model.DeviceStateChange
+	onDeviceClick                chan *model.DeviceClick
+	onDeviceTuyaReport           chan *model.DeviceTuyaReport
+	onDeviceInterviewProgress    chan *model.DeviceInterviewProgress
+	onDeviceLinkQualityAlert     chan *model.DeviceLinkQualityAlert
 }
 
 func (c *Channels) OnDeviceRegistered() chan *model.Device {
@@ -24,3 +35,47 @@ func (c *Channels) OnDeviceUnregistered() chan *model.Device {
 func (c *Channels) OnDeviceIncomingMessage() chan *model.DeviceIncomingMessage {
 	return c.onDeviceIncomingMessage
 }
+
+func (c *Channels) OnDeviceZoneStatusChange() chan *model.DeviceZoneStatusChange {
+	return c.onDeviceZoneStatusChange
+}
+
+func (c *Channels) OnDeviceLockOperationEvent() chan *model.DeviceLockOperationEvent {
+	return c.onDeviceLockOperationEvent
+}
+
+func (c *Channels) OnDeviceLockProgrammingEvent() chan *model.DeviceLockProgrammingEvent {
+	return c.onDeviceLockProgrammingEvent
+}
+
+func (c *Channels) OnDeviceOTAProgress() chan *model.DeviceOTAProgress {
+	return c.onDeviceOTAProgress
+}
+
+func (c *Channels) OnDeviceEnergyMeasurement() chan *model.DeviceEnergyMeasurement {
+	return c.onDeviceEnergyMeasurement
+}
+
+func (c *Channels) OnDeviceSensorReading() chan *model.DeviceSensorReading {
+	return c.onDeviceSensorReading
+}
+
+func (c *Channels) OnDeviceStateChange() chan *model.DeviceStateChange {
+	return c.onDeviceStateChange
+}
+
+func (c *Channels) OnDeviceClick() chan *model.DeviceClick {
+	return c.onDeviceClick
+}
+
+func (c *Channels) OnDeviceTuyaReport() chan *model.DeviceTuyaReport {
+	return c.onDeviceTuyaReport
+}
+
+func (c *Channels) OnDeviceInterviewProgress() chan *model.DeviceInterviewProgress {
+	return c.onDeviceInterviewProgress
+}
+
+func (c *Channels) OnDeviceLinkQualityAlert() chan *model.DeviceLinkQualityAlert {
+	return c.onDeviceLinkQualityAlert
+}
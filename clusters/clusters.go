@@ -0,0 +1,72 @@
+package clusters
+
+import (
+	"github.com/dyrkin/zcl-go/cluster"
+	"time"
+)
+
+const (
+	Time                      cluster.ClusterId = 0x000a
+	DoorLock                  cluster.ClusterId = 0x0101
+	WindowCovering            cluster.ClusterId = 0x0102
+	Thermostat                cluster.ClusterId = 0x0201
+	FanControl                cluster.ClusterId = 0x0202
+	ThermostatUIConfiguration cluster.ClusterId = 0x0204
+	ColorControl              cluster.ClusterId = 0x0300
+	IlluminanceMeasurement    cluster.ClusterId = 0x0400
+	TemperatureMeasurement    cluster.ClusterId = 0x0402
+	PressureMeasurement       cluster.ClusterId = 0x0403
+	RelativeHumidity          cluster.ClusterId = 0x0405
+	OccupancySensing          cluster.ClusterId = 0x0406
+	IASZone                   cluster.ClusterId = 0x0500
+	IASACE                    cluster.ClusterId = 0x0501
+	IASWD                     cluster.ClusterId = 0x0502
+	Metering                  cluster.ClusterId = 0x0702
+	ElectricalMeasurement     cluster.ClusterId = 0x0b04
+	Tuya                      cluster.ClusterId = 0xef00
+)
+
+var definitions = map[cluster.ClusterId]*cluster.Cluster{
+	Time:                      timeCluster,
+	DoorLock:                  doorLock,
+	WindowCovering:            windowCovering,
+	Thermostat:                thermostat,
+	FanControl:                fanControl,
+	ThermostatUIConfiguration: thermostatUIConfiguration,
+	ColorControl:              colorControl,
+	IlluminanceMeasurement:    illuminanceMeasurement,
+	TemperatureMeasurement:    temperatureMeasurement,
+	PressureMeasurement:       pressureMeasurement,
+	RelativeHumidity:          relativeHumidityMeasurement,
+	OccupancySensing:          occupancySensing,
+	IASZone:                   iasZone,
+	IASACE:                    iasAce,
+	IASWD:                     iasWd,
+	cluster.OTA:               ota,
+	Metering:                  metering,
+	ElectricalMeasurement:     electricalMeasurement,
+	Tuya:                      tuya,
+}
+
+var epoch = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+func ToTime(seconds uint32) time.Time {
+	return epoch.Add(time.Duration(seconds) * time.Second)
+}
+
+func FromTime(t time.Time) uint32 {
+	return uint32(t.Sub(epoch) / time.Second)
+}
+
+func Register(library *cluster.ClusterLibrary) {
+	for clusterId, definition := range definitions {
+		existing, ok := library.Clusters()[clusterId]
+		if !ok {
+			library.Clusters()[clusterId] = definition
+			continue
+		}
+		if existing.CommandDescriptors == nil {
+			existing.CommandDescriptors = definition.CommandDescriptors
+		}
+	}
+}
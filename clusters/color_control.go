@@ -0,0 +1,67 @@
+package clusters
+
+import "github.com/dyrkin/zcl-go/cluster"
+
+const (
+	ColorModeHueSaturation    uint8 = 0x00
+	ColorModeXY               uint8 = 0x01
+	ColorModeColorTemperature uint8 = 0x02
+)
+
+type MoveToHueCommand struct {
+	Hue            uint8
+	Direction      uint8
+	TransitionTime uint16
+}
+
+type MoveToSaturationCommand struct {
+	Saturation     uint8
+	TransitionTime uint16
+}
+
+type MoveToHueAndSaturationCommand struct {
+	Hue            uint8
+	Saturation     uint8
+	TransitionTime uint16
+}
+
+type MoveToColorCommand struct {
+	ColorX         uint16
+	ColorY         uint16
+	TransitionTime uint16
+}
+
+type MoveToColorTemperatureCommand struct {
+	ColorTemperatureMireds uint16
+	TransitionTime         uint16
+}
+
+type StopMoveStepCommand struct{}
+
+var colorControl = &cluster.Cluster{
+	Name: "ColorControl",
+	AttributeDescriptors: map[uint16]*cluster.AttributeDescriptor{
+		0x0000: {Name: "CurrentHue", Type: cluster.ZclDataTypeUint8, Access: cluster.Read | cluster.Reportable},
+		0x0001: {Name: "CurrentSaturation", Type: cluster.ZclDataTypeUint8, Access: cluster.Read | cluster.Reportable},
+		0x0002: {Name: "RemainingTime", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+		0x0003: {Name: "CurrentX", Type: cluster.ZclDataTypeUint16, Access: cluster.Read | cluster.Reportable},
+		0x0004: {Name: "CurrentY", Type: cluster.ZclDataTypeUint16, Access: cluster.Read | cluster.Reportable},
+		0x0007: {Name: "ColorTemperatureMireds", Type: cluster.ZclDataTypeUint16, Access: cluster.Read | cluster.Reportable},
+		0x0008: {Name: "ColorMode", Type: cluster.ZclDataTypeEnum8, Access: cluster.Read},
+		0x000f: {Name: "Options", Type: cluster.ZclDataTypeBitmap8, Access: cluster.Read | cluster.Write},
+		0x400a: {Name: "ColorCapabilities", Type: cluster.ZclDataTypeBitmap16, Access: cluster.Read},
+		0x400b: {Name: "ColorTempPhysicalMinMireds", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+		0x400c: {Name: "ColorTempPhysicalMaxMireds", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+	},
+	CommandDescriptors: &cluster.CommandDescriptors{
+		Received: map[uint8]*cluster.CommandDescriptor{
+			0x00: {Name: "MoveToHue", Command: &MoveToHueCommand{}},
+			0x03: {Name: "MoveToSaturation", Command: &MoveToSaturationCommand{}},
+			0x06: {Name: "MoveToHueAndSaturation", Command: &MoveToHueAndSaturationCommand{}},
+			0x07: {Name: "MoveToColor", Command: &MoveToColorCommand{}},
+			0x0a: {Name: "MoveToColorTemperature", Command: &MoveToColorTemperatureCommand{}},
+			0x47: {Name: "StopMoveStep", Command: &StopMoveStepCommand{}},
+		},
+		Generated: map[uint8]*cluster.CommandDescriptor{},
+	},
+}
@@ -0,0 +1,290 @@
+package clusters
+
+import "github.com/dyrkin/zcl-go/cluster"
+
+type LockDoorCommand struct {
+	PINCode string `size:"1"`
+}
+
+type UnlockDoorCommand struct {
+	PINCode string `size:"1"`
+}
+
+type ToggleDoorCommand struct {
+	PINCode string `size:"1"`
+}
+
+type UnlockWithTimeoutCommand struct {
+	Timeout uint16
+	PINCode string `size:"1"`
+}
+
+type GetLogRecordCommand struct {
+	LogIndex uint16
+}
+
+type SetPINCodeCommand struct {
+	UserID     uint16
+	UserStatus uint8
+	UserType   uint8
+	PINCode    string `size:"1"`
+}
+
+type GetPINCodeCommand struct {
+	UserID uint16
+}
+
+type ClearPINCodeCommand struct {
+	UserID uint16
+}
+
+type ClearAllPINCodesCommand struct{}
+
+type SetUserStatusCommand struct {
+	UserID     uint16
+	UserStatus uint8
+}
+
+type GetUserStatusCommand struct {
+	UserID uint16
+}
+
+type SetWeekDayScheduleCommand struct {
+	ScheduleID  uint8
+	UserID      uint16
+	DaysMask    uint8
+	StartHour   uint8
+	StartMinute uint8
+	EndHour     uint8
+	EndMinute   uint8
+}
+
+type GetWeekDayScheduleCommand struct {
+	ScheduleID uint8
+	UserID     uint16
+}
+
+type ClearWeekDayScheduleCommand struct {
+	ScheduleID uint8
+	UserID     uint16
+}
+
+type SetYearDayScheduleCommand struct {
+	ScheduleID     uint8
+	UserID         uint16
+	LocalStartTime uint32
+	LocalEndTime   uint32
+}
+
+type GetYearDayScheduleCommand struct {
+	ScheduleID uint8
+	UserID     uint16
+}
+
+type ClearYearDayScheduleCommand struct {
+	ScheduleID uint8
+	UserID     uint16
+}
+
+type SetHolidayScheduleCommand struct {
+	HolidayScheduleID          uint8
+	LocalStartTime             uint32
+	LocalEndTime               uint32
+	OperatingModeDuringHoliday uint8
+}
+
+type GetHolidayScheduleCommand struct {
+	HolidayScheduleID uint8
+}
+
+type ClearHolidayScheduleCommand struct {
+	HolidayScheduleID uint8
+}
+
+type SetUserTypeCommand struct {
+	UserID   uint16
+	UserType uint8
+}
+
+type GetUserTypeCommand struct {
+	UserID uint16
+}
+
+type DoorLockStatusResponseCommand struct {
+	Status uint8
+}
+
+type GetLogRecordResponseCommand struct {
+	LogEntryID         uint16
+	Timestamp          uint32
+	EventType          uint8
+	Source             uint8
+	EventIDOrAlarmCode uint8
+	UserID             uint16
+	PIN                string `size:"1"`
+}
+
+type GetPINCodeResponseCommand struct {
+	UserID     uint16
+	UserStatus uint8
+	UserType   uint8
+	PINCode    string `size:"1"`
+}
+
+type GetUserStatusResponseCommand struct {
+	UserID     uint16
+	UserStatus uint8
+}
+
+type GetWeekDayScheduleResponseCommand struct {
+	ScheduleID  uint8
+	UserID      uint16
+	Status      uint8
+	DaysMask    uint8 `cond:"uint:Status==0"`
+	StartHour   uint8 `cond:"uint:Status==0"`
+	StartMinute uint8 `cond:"uint:Status==0"`
+	EndHour     uint8 `cond:"uint:Status==0"`
+	EndMinute   uint8 `cond:"uint:Status==0"`
+}
+
+type GetYearDayScheduleResponseCommand struct {
+	ScheduleID     uint8
+	UserID         uint16
+	Status         uint8
+	LocalStartTime uint32 `cond:"uint:Status==0"`
+	LocalEndTime   uint32 `cond:"uint:Status==0"`
+}
+
+type GetHolidayScheduleResponseCommand struct {
+	HolidayScheduleID          uint8
+	Status                     uint8
+	LocalStartTime             uint32 `cond:"uint:Status==0"`
+	LocalEndTime               uint32 `cond:"uint:Status==0"`
+	OperatingModeDuringHoliday uint8  `cond:"uint:Status==0"`
+}
+
+type GetUserTypeResponseCommand struct {
+	UserID   uint16
+	UserType uint8
+}
+
+type OperationEventNotificationCommand struct {
+	OperationEventSource uint8
+	OperationEventCode   uint8
+	UserID               uint16
+	PIN                  string `size:"1"`
+	LocalTime            uint32
+	Data                 string `size:"1"`
+}
+
+type ProgrammingEventNotificationCommand struct {
+	ProgramEventSource uint8
+	ProgramEventCode   uint8
+	UserID             uint16
+	PIN                string `size:"1"`
+	UserType           uint8
+	UserStatus         uint8
+	LocalTime          uint32
+	Data               string `size:"1"`
+}
+
+var doorLock = &cluster.Cluster{
+	Name: "DoorLock",
+	AttributeDescriptors: map[uint16]*cluster.AttributeDescriptor{
+		0x0000: {Name: "LockState", Type: cluster.ZclDataTypeEnum8, Access: cluster.Read | cluster.Reportable},
+		0x0001: {Name: "LockType", Type: cluster.ZclDataTypeEnum8, Access: cluster.Read},
+		0x0002: {Name: "ActuatorEnabled", Type: cluster.ZclDataTypeBoolean, Access: cluster.Read},
+		0x0003: {Name: "DoorState", Type: cluster.ZclDataTypeEnum8, Access: cluster.Read | cluster.Reportable},
+		0x0004: {Name: "DoorOpenEvents", Type: cluster.ZclDataTypeUint32, Access: cluster.Read | cluster.Write},
+		0x0005: {Name: "DoorClosedEvents", Type: cluster.ZclDataTypeUint32, Access: cluster.Read | cluster.Write},
+		0x0006: {Name: "OpenPeriod", Type: cluster.ZclDataTypeUint16, Access: cluster.Read | cluster.Write},
+		0x0010: {Name: "NumberOfLogRecordsSupported", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+		0x0011: {Name: "NumberOfTotalUsersSupported", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+		0x0012: {Name: "NumberOfPINUsersSupported", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+		0x0013: {Name: "NumberOfRFIDUsersSupported", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+		0x0014: {Name: "NumberOfWeekDaySchedulesSupportedPerUser", Type: cluster.ZclDataTypeUint8, Access: cluster.Read},
+		0x0015: {Name: "NumberOfYearDaySchedulesSupportedPerUser", Type: cluster.ZclDataTypeUint8, Access: cluster.Read},
+		0x0016: {Name: "NumberOfHolidaySchedulesSupported", Type: cluster.ZclDataTypeUint8, Access: cluster.Read},
+		0x0017: {Name: "MaxPINCodeLength", Type: cluster.ZclDataTypeUint8, Access: cluster.Read},
+		0x0018: {Name: "MinPINCodeLength", Type: cluster.ZclDataTypeUint8, Access: cluster.Read},
+		0x0019: {Name: "MaxRFIDCodeLength", Type: cluster.ZclDataTypeUint8, Access: cluster.Read},
+		0x001a: {Name: "MinRFIDCodeLength", Type: cluster.ZclDataTypeUint8, Access: cluster.Read},
+		0x0020: {Name: "EnableLogging", Type: cluster.ZclDataTypeBoolean, Access: cluster.Read | cluster.Write | cluster.Reportable},
+		0x0021: {Name: "Language", Type: cluster.ZclDataTypeCharStr, Access: cluster.Read | cluster.Write | cluster.Reportable},
+		0x0022: {Name: "LEDSettings", Type: cluster.ZclDataTypeUint8, Access: cluster.Read | cluster.Write | cluster.Reportable},
+		0x0023: {Name: "AutoRelockTime", Type: cluster.ZclDataTypeUint32, Access: cluster.Read | cluster.Write | cluster.Reportable},
+		0x0024: {Name: "SoundVolume", Type: cluster.ZclDataTypeUint8, Access: cluster.Read | cluster.Write | cluster.Reportable},
+		0x0025: {Name: "OperatingMode", Type: cluster.ZclDataTypeEnum8, Access: cluster.Read | cluster.Write | cluster.Reportable},
+		0x0026: {Name: "SupportedOperatingModes", Type: cluster.ZclDataTypeBitmap16, Access: cluster.Read},
+		0x0027: {Name: "DefaultConfigurationRegister", Type: cluster.ZclDataTypeBitmap16, Access: cluster.Read | cluster.Reportable},
+		0x0028: {Name: "EnableLocalProgramming", Type: cluster.ZclDataTypeBoolean, Access: cluster.Read | cluster.Write | cluster.Reportable},
+		0x0029: {Name: "EnableOneTouchLocking", Type: cluster.ZclDataTypeBoolean, Access: cluster.Read | cluster.Write | cluster.Reportable},
+		0x002a: {Name: "EnableInsideStatusLED", Type: cluster.ZclDataTypeBoolean, Access: cluster.Read | cluster.Write | cluster.Reportable},
+		0x002b: {Name: "EnablePrivacyModeButton", Type: cluster.ZclDataTypeBoolean, Access: cluster.Read | cluster.Write | cluster.Reportable},
+		0x0030: {Name: "WrongCodeEntryLimit", Type: cluster.ZclDataTypeUint8, Access: cluster.Read | cluster.Write | cluster.Reportable},
+		0x0031: {Name: "UserCodeTemporaryDisableTime", Type: cluster.ZclDataTypeUint8, Access: cluster.Read | cluster.Write | cluster.Reportable},
+		0x0032: {Name: "SendPINOverTheAir", Type: cluster.ZclDataTypeBoolean, Access: cluster.Read | cluster.Write | cluster.Reportable},
+		0x0033: {Name: "RequirePINforRFOperation", Type: cluster.ZclDataTypeBoolean, Access: cluster.Read | cluster.Write | cluster.Reportable},
+		0x0034: {Name: "ZigBeeSecurityLevel", Type: cluster.ZclDataTypeEnum8, Access: cluster.Read},
+		0x0040: {Name: "AlarmMask", Type: cluster.ZclDataTypeBitmap16, Access: cluster.Read | cluster.Write | cluster.Reportable},
+		0x0041: {Name: "KeypadOperationEventMask", Type: cluster.ZclDataTypeBitmap16, Access: cluster.Read | cluster.Write | cluster.Reportable},
+		0x0042: {Name: "RFOperationEventMask", Type: cluster.ZclDataTypeBitmap16, Access: cluster.Read | cluster.Write | cluster.Reportable},
+		0x0043: {Name: "ManualOperationEventMask", Type: cluster.ZclDataTypeBitmap16, Access: cluster.Read | cluster.Write | cluster.Reportable},
+		0x0044: {Name: "RFIDOperationEventMask", Type: cluster.ZclDataTypeBitmap16, Access: cluster.Read | cluster.Write | cluster.Reportable},
+		0x0045: {Name: "KeypadProgrammingEventMask", Type: cluster.ZclDataTypeBitmap16, Access: cluster.Read | cluster.Write | cluster.Reportable},
+		0x0046: {Name: "RFProgrammingEventMask", Type: cluster.ZclDataTypeBitmap16, Access: cluster.Read | cluster.Write | cluster.Reportable},
+		0x0047: {Name: "RFIDProgrammingEventMask", Type: cluster.ZclDataTypeBitmap16, Access: cluster.Read | cluster.Write | cluster.Reportable},
+	},
+	CommandDescriptors: &cluster.CommandDescriptors{
+		Received: map[uint8]*cluster.CommandDescriptor{
+			0x00: {Name: "LockDoor", Command: &LockDoorCommand{}},
+			0x01: {Name: "UnlockDoor", Command: &UnlockDoorCommand{}},
+			0x02: {Name: "Toggle", Command: &ToggleDoorCommand{}},
+			0x03: {Name: "UnlockWithTimeout", Command: &UnlockWithTimeoutCommand{}},
+			0x04: {Name: "GetLogRecord", Command: &GetLogRecordCommand{}},
+			0x05: {Name: "SetPINCode", Command: &SetPINCodeCommand{}},
+			0x06: {Name: "GetPINCode", Command: &GetPINCodeCommand{}},
+			0x07: {Name: "ClearPINCode", Command: &ClearPINCodeCommand{}},
+			0x08: {Name: "ClearAllPINCodes", Command: &ClearAllPINCodesCommand{}},
+			0x09: {Name: "SetUserStatus", Command: &SetUserStatusCommand{}},
+			0x0a: {Name: "GetUserStatus", Command: &GetUserStatusCommand{}},
+			0x0b: {Name: "SetWeekDaySchedule", Command: &SetWeekDayScheduleCommand{}},
+			0x0c: {Name: "GetWeekDaySchedule", Command: &GetWeekDayScheduleCommand{}},
+			0x0d: {Name: "ClearWeekDaySchedule", Command: &ClearWeekDayScheduleCommand{}},
+			0x0e: {Name: "SetYearDaySchedule", Command: &SetYearDayScheduleCommand{}},
+			0x0f: {Name: "GetYearDaySchedule", Command: &GetYearDayScheduleCommand{}},
+			0x10: {Name: "ClearYearDaySchedule", Command: &ClearYearDayScheduleCommand{}},
+			0x11: {Name: "SetHolidaySchedule", Command: &SetHolidayScheduleCommand{}},
+			0x12: {Name: "GetHolidaySchedule", Command: &GetHolidayScheduleCommand{}},
+			0x13: {Name: "ClearHolidaySchedule", Command: &ClearHolidayScheduleCommand{}},
+			0x14: {Name: "SetUserType", Command: &SetUserTypeCommand{}},
+			0x15: {Name: "GetUserType", Command: &GetUserTypeCommand{}},
+		},
+		Generated: map[uint8]*cluster.CommandDescriptor{
+			0x00: {Name: "LockDoorResponse", Command: &DoorLockStatusResponseCommand{}},
+			0x01: {Name: "UnlockDoorResponse", Command: &DoorLockStatusResponseCommand{}},
+			0x02: {Name: "ToggleResponse", Command: &DoorLockStatusResponseCommand{}},
+			0x03: {Name: "UnlockWithTimeoutResponse", Command: &DoorLockStatusResponseCommand{}},
+			0x04: {Name: "GetLogRecordResponse", Command: &GetLogRecordResponseCommand{}},
+			0x05: {Name: "SetPINCodeResponse", Command: &DoorLockStatusResponseCommand{}},
+			0x06: {Name: "GetPINCodeResponse", Command: &GetPINCodeResponseCommand{}},
+			0x07: {Name: "ClearPINCodeResponse", Command: &DoorLockStatusResponseCommand{}},
+			0x08: {Name: "ClearAllPINCodesResponse", Command: &DoorLockStatusResponseCommand{}},
+			0x09: {Name: "SetUserStatusResponse", Command: &DoorLockStatusResponseCommand{}},
+			0x0a: {Name: "GetUserStatusResponse", Command: &GetUserStatusResponseCommand{}},
+			0x0b: {Name: "SetWeekDayScheduleResponse", Command: &DoorLockStatusResponseCommand{}},
+			0x0c: {Name: "GetWeekDayScheduleResponse", Command: &GetWeekDayScheduleResponseCommand{}},
+			0x0d: {Name: "ClearWeekDayScheduleResponse", Command: &DoorLockStatusResponseCommand{}},
+			0x0e: {Name: "SetYearDayScheduleResponse", Command: &DoorLockStatusResponseCommand{}},
+			0x0f: {Name: "GetYearDayScheduleResponse", Command: &GetYearDayScheduleResponseCommand{}},
+			0x10: {Name: "ClearYearDayScheduleResponse", Command: &DoorLockStatusResponseCommand{}},
+			0x11: {Name: "SetHolidayScheduleResponse", Command: &DoorLockStatusResponseCommand{}},
+			0x12: {Name: "GetHolidayScheduleResponse", Command: &GetHolidayScheduleResponseCommand{}},
+			0x13: {Name: "ClearHolidayScheduleResponse", Command: &DoorLockStatusResponseCommand{}},
+			0x14: {Name: "SetUserTypeResponse", Command: &DoorLockStatusResponseCommand{}},
+			0x15: {Name: "GetUserTypeResponse", Command: &GetUserTypeResponseCommand{}},
+			0x20: {Name: "OperationEventNotification", Command: &OperationEventNotificationCommand{}},
+			0x21: {Name: "ProgrammingEventNotification", Command: &ProgrammingEventNotificationCommand{}},
+		},
+	},
+}
@@ -0,0 +1,121 @@
+package clusters
+
+import "github.com/dyrkin/zcl-go/cluster"
+
+type ArmCommand struct {
+	ArmMode       uint8
+	ArmDisarmCode string `size:"1"`
+	ZoneID        uint8
+}
+
+type BypassCommand struct {
+	ZoneIDs       []uint8 `size:"1"`
+	ArmDisarmCode string  `size:"1"`
+}
+
+type EmergencyCommand struct{}
+
+type FireCommand struct{}
+
+type PanicCommand struct{}
+
+type GetZoneIDMapCommand struct{}
+
+type GetZoneInformationCommand struct {
+	ZoneID uint8
+}
+
+type GetPanelStatusCommand struct{}
+
+type GetBypassedZoneListCommand struct{}
+
+type GetZoneStatusCommand struct {
+	StartingZoneID     uint8
+	MaxNumberOfZoneIDs uint8
+	ZoneStatusMaskFlag uint8
+	ZoneStatusMask     uint16
+}
+
+type ArmResponseCommand struct {
+	ArmNotification uint8
+}
+
+type GetZoneIDMapResponseCommand struct {
+	ZoneIDMapSections [16]uint16
+}
+
+type GetZoneInformationResponseCommand struct {
+	ZoneID      uint8
+	ZoneType    uint16
+	IEEEAddress string `hex:"8"`
+	ZoneLabel   string `size:"1"`
+}
+
+type ZoneStatusChangedCommand struct {
+	ZoneID              uint8
+	ZoneStatus          uint16
+	AudibleNotification uint8
+	ZoneLabel           string `size:"1"`
+}
+
+type PanelStatusChangedCommand struct {
+	PanelStatus         uint8
+	SecondsRemaining    uint8
+	AudibleNotification uint8
+	AlarmStatus         uint8
+}
+
+type GetPanelStatusResponseCommand struct {
+	PanelStatus         uint8
+	SecondsRemaining    uint8
+	AudibleNotification uint8
+	AlarmStatus         uint8
+}
+
+type SetBypassedZoneListCommand struct {
+	ZoneIDs []uint8 `size:"1"`
+}
+
+type BypassResponseCommand struct {
+	BypassResults []uint8 `size:"1"`
+}
+
+type ZoneStatusRecord struct {
+	ZoneID     uint8
+	ZoneStatus uint16
+}
+
+type GetZoneStatusResponseCommand struct {
+	ZoneStatusComplete uint8
+	ZoneStatusRecords  []*ZoneStatusRecord `size:"1"`
+}
+
+var iasAce = &cluster.Cluster{
+	Name:                 "IASACE",
+	AttributeDescriptors: map[uint16]*cluster.AttributeDescriptor{},
+	CommandDescriptors: &cluster.CommandDescriptors{
+		Received: map[uint8]*cluster.CommandDescriptor{
+			0x00: {Name: "Arm", Command: &ArmCommand{}},
+			0x01: {Name: "Bypass", Command: &BypassCommand{}},
+			0x02: {Name: "Emergency", Command: &EmergencyCommand{}},
+			0x03: {Name: "Fire", Command: &FireCommand{}},
+			0x04: {Name: "Panic", Command: &PanicCommand{}},
+			0x05: {Name: "GetZoneIDMap", Command: &GetZoneIDMapCommand{}},
+			0x06: {Name: "GetZoneInformation", Command: &GetZoneInformationCommand{}},
+			0x07: {Name: "GetPanelStatus", Command: &GetPanelStatusCommand{}},
+			0x08: {Name: "GetBypassedZoneList", Command: &GetBypassedZoneListCommand{}},
+			0x09: {Name: "GetZoneStatus", Command: &GetZoneStatusCommand{}},
+		},
+		Generated: map[uint8]*cluster.CommandDescriptor{
+			0x00: {Name: "ArmResponse", Command: &ArmResponseCommand{}},
+			0x01: {Name: "GetZoneIDMapResponse", Command: &GetZoneIDMapResponseCommand{}},
+			0x02: {Name: "GetZoneInformationResponse", Command: &GetZoneInformationResponseCommand{}},
+			0x03: {Name: "ZoneStatusChanged", Command: &ZoneStatusChangedCommand{}},
+			0x04: {Name: "PanelStatusChanged", Command: &PanelStatusChangedCommand{}},
+			0x05: {Name: "GetPanelStatusResponse", Command: &GetPanelStatusResponseCommand{}},
+			0x06: {Name: "SetBypassedZoneList", Command: &SetBypassedZoneListCommand{}},
+			0x07: {Name: "BypassResponse", Command: &BypassResponseCommand{}},
+			0x08: {Name: "GetZoneStatusResponse", Command: &GetZoneStatusResponseCommand{}},
+		},
+	},
+}
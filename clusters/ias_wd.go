@@ -0,0 +1,39 @@
+package clusters
+
+import "github.com/dyrkin/zcl-go/cluster"
+
+type WarningInfo struct {
+	SirenLevel  uint8 `bits:"0b00000011" bitmask:"start"`
+	Strobe      uint8 `bits:"0b00001100"`
+	WarningMode uint8 `bits:"0b11110000" bitmask:"end"`
+}
+
+type StartWarningCommand struct {
+	WarningInfo     *WarningInfo
+	WarningDuration uint16
+	StrobeDutyCycle uint8
+	StrobeLevel     uint8
+}
+
+type SquawkInfo struct {
+	SquawkLevel uint8 `bits:"0b00000011" bitmask:"start"`
+	Strobe      uint8 `bits:"0b00001000"`
+	SquawkMode  uint8 `bits:"0b11110000" bitmask:"end"`
+}
+
+type SquawkCommand struct {
+	SquawkInfo *SquawkInfo
+}
+
+var iasWd = &cluster.Cluster{
+	Name: "IASWD",
+	AttributeDescriptors: map[uint16]*cluster.AttributeDescriptor{
+		0x0000: {Name: "MaxDuration", Type: cluster.ZclDataTypeUint16, Access: cluster.Read | cluster.Write},
+	},
+	CommandDescriptors: &cluster.CommandDescriptors{
+		Received: map[uint8]*cluster.CommandDescriptor{
+			0x00: {Name: "StartWarning", Command: &StartWarningCommand{}},
+			0x01: {Name: "Squawk", Command: &SquawkCommand{}},
+		},
+	},
+}
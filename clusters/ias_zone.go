@@ -0,0 +1,51 @@
+package clusters
+
+import "github.com/dyrkin/zcl-go/cluster"
+
+type ZoneEnrollResponseCommand struct {
+	EnrollResponseCode uint8
+	ZoneID             uint8
+}
+
+type InitiateNormalOperationModeCommand struct{}
+
+type InitiateTestModeCommand struct {
+	TestModeDuration            uint8
+	CurrentZoneSensitivityLevel uint8
+}
+
+type ZoneStatusChangeNotificationCommand struct {
+	ZoneStatus     uint16
+	ExtendedStatus uint8
+	ZoneID         uint8
+	Delay          uint16
+}
+
+type ZoneEnrollRequestCommand struct {
+	ZoneType         uint16
+	ManufacturerCode uint16
+}
+
+var iasZone = &cluster.Cluster{
+	Name: "IASZone",
+	AttributeDescriptors: map[uint16]*cluster.AttributeDescriptor{
+		0x0000: {Name: "ZoneState", Type: cluster.ZclDataTypeEnum8, Access: cluster.Read},
+		0x0001: {Name: "ZoneType", Type: cluster.ZclDataTypeEnum16, Access: cluster.Read},
+		0x0002: {Name: "ZoneStatus", Type: cluster.ZclDataTypeBitmap16, Access: cluster.Read},
+		0x0010: {Name: "IAS_CIE_Address", Type: cluster.ZclDataTypeIeeeAddr, Access: cluster.Read | cluster.Write},
+		0x0011: {Name: "ZoneID", Type: cluster.ZclDataTypeUint8, Access: cluster.Read},
+		0x0012: {Name: "NumberOfZoneSensitivityLevelsSupported", Type: cluster.ZclDataTypeUint8, Access: cluster.Read},
+		0x0013: {Name: "CurrentZoneSensitivityLevel", Type: cluster.ZclDataTypeUint8, Access: cluster.Read | cluster.Write},
+	},
+	CommandDescriptors: &cluster.CommandDescriptors{
+		Received: map[uint8]*cluster.CommandDescriptor{
+			0x00: {Name: "ZoneEnrollResponse", Command: &ZoneEnrollResponseCommand{}},
+			0x01: {Name: "InitiateNormalOperationMode", Command: &InitiateNormalOperationModeCommand{}},
+			0x02: {Name: "InitiateTestMode", Command: &InitiateTestModeCommand{}},
+		},
+		Generated: map[uint8]*cluster.CommandDescriptor{
+			0x00: {Name: "ZoneStatusChangeNotification", Command: &ZoneStatusChangeNotificationCommand{}},
+			0x01: {Name: "ZoneEnrollRequest", Command: &ZoneEnrollRequestCommand{}},
+		},
+	},
+}
@@ -0,0 +1,80 @@
+package clusters
+
+import "github.com/dyrkin/zcl-go/cluster"
+
+var illuminanceMeasurement = &cluster.Cluster{
+	Name: "IlluminanceMeasurement",
+	AttributeDescriptors: map[uint16]*cluster.AttributeDescriptor{
+		0x0000: {Name: "MeasuredValue", Type: cluster.ZclDataTypeUint16, Access: cluster.Read | cluster.Reportable},
+		0x0001: {Name: "MinMeasuredValue", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+		0x0002: {Name: "MaxMeasuredValue", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+		0x0003: {Name: "Tolerance", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+		0x0004: {Name: "LightSensorType", Type: cluster.ZclDataTypeEnum8, Access: cluster.Read},
+	},
+	CommandDescriptors: &cluster.CommandDescriptors{
+		Received:  map[uint8]*cluster.CommandDescriptor{},
+		Generated: map[uint8]*cluster.CommandDescriptor{},
+	},
+}
+
+var temperatureMeasurement = &cluster.Cluster{
+	Name: "TemperatureMeasurement",
+	AttributeDescriptors: map[uint16]*cluster.AttributeDescriptor{
+		0x0000: {Name: "MeasuredValue", Type: cluster.ZclDataTypeInt16, Access: cluster.Read | cluster.Reportable},
+		0x0001: {Name: "MinMeasuredValue", Type: cluster.ZclDataTypeInt16, Access: cluster.Read},
+		0x0002: {Name: "MaxMeasuredValue", Type: cluster.ZclDataTypeInt16, Access: cluster.Read},
+		0x0003: {Name: "Tolerance", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+	},
+	CommandDescriptors: &cluster.CommandDescriptors{
+		Received:  map[uint8]*cluster.CommandDescriptor{},
+		Generated: map[uint8]*cluster.CommandDescriptor{},
+	},
+}
+
+var pressureMeasurement = &cluster.Cluster{
+	Name: "PressureMeasurement",
+	AttributeDescriptors: map[uint16]*cluster.AttributeDescriptor{
+		0x0000: {Name: "MeasuredValue", Type: cluster.ZclDataTypeInt16, Access: cluster.Read | cluster.Reportable},
+		0x0001: {Name: "MinMeasuredValue", Type: cluster.ZclDataTypeInt16, Access: cluster.Read},
+		0x0002: {Name: "MaxMeasuredValue", Type: cluster.ZclDataTypeInt16, Access: cluster.Read},
+		0x0003: {Name: "Tolerance", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+		0x0010: {Name: "ScaledValue", Type: cluster.ZclDataTypeInt16, Access: cluster.Read},
+		0x0011: {Name: "MinScaledValue", Type: cluster.ZclDataTypeInt16, Access: cluster.Read},
+		0x0012: {Name: "MaxScaledValue", Type: cluster.ZclDataTypeInt16, Access: cluster.Read},
+		0x0013: {Name: "ScaledTolerance", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+		0x0014: {Name: "Scale", Type: cluster.ZclDataTypeInt8, Access: cluster.Read},
+	},
+	CommandDescriptors: &cluster.CommandDescriptors{
+		Received:  map[uint8]*cluster.CommandDescriptor{},
+		Generated: map[uint8]*cluster.CommandDescriptor{},
+	},
+}
+
+var relativeHumidityMeasurement = &cluster.Cluster{
+	Name: "RelativeHumidityMeasurement",
+	AttributeDescriptors: map[uint16]*cluster.AttributeDescriptor{
+		0x0000: {Name: "MeasuredValue", Type: cluster.ZclDataTypeUint16, Access: cluster.Read | cluster.Reportable},
+		0x0001: {Name: "MinMeasuredValue", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+		0x0002: {Name: "MaxMeasuredValue", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+		0x0003: {Name: "Tolerance", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+	},
+	CommandDescriptors: &cluster.CommandDescriptors{
+		Received:  map[uint8]*cluster.CommandDescriptor{},
+		Generated: map[uint8]*cluster.CommandDescriptor{},
+	},
+}
+
+var occupancySensing = &cluster.Cluster{
+	Name: "OccupancySensing",
+	AttributeDescriptors: map[uint16]*cluster.AttributeDescriptor{
+		0x0000: {Name: "Occupancy", Type: cluster.ZclDataTypeBitmap8, Access: cluster.Read | cluster.Reportable},
+		0x0001: {Name: "OccupancySensorType", Type: cluster.ZclDataTypeEnum8, Access: cluster.Read},
+		0x0010: {Name: "PIROccupiedToUnoccupiedDelay", Type: cluster.ZclDataTypeUint16, Access: cluster.Read | cluster.Write},
+		0x0011: {Name: "PIRUnoccupiedToOccupiedDelay", Type: cluster.ZclDataTypeUint16, Access: cluster.Read | cluster.Write},
+		0x0012: {Name: "PIRUnoccupiedToOccupiedThreshold", Type: cluster.ZclDataTypeUint8, Access: cluster.Read | cluster.Write},
+	},
+	CommandDescriptors: &cluster.CommandDescriptors{
+		Received:  map[uint8]*cluster.CommandDescriptor{},
+		Generated: map[uint8]*cluster.CommandDescriptor{},
+	},
+}
@@ -0,0 +1,50 @@
+package clusters
+
+import "github.com/dyrkin/zcl-go/cluster"
+
+const MeteringUnitKilowatts uint8 = 0x00
+
+var metering = &cluster.Cluster{
+	Name: "Metering",
+	AttributeDescriptors: map[uint16]*cluster.AttributeDescriptor{
+		0x0000: {Name: "CurrentSummationDelivered", Type: cluster.ZclDataTypeUint48, Access: cluster.Read | cluster.Reportable},
+		0x0001: {Name: "CurrentSummationReceived", Type: cluster.ZclDataTypeUint48, Access: cluster.Read},
+		0x0200: {Name: "Status", Type: cluster.ZclDataTypeBitmap8, Access: cluster.Read},
+		0x0300: {Name: "UnitOfMeasure", Type: cluster.ZclDataTypeEnum8, Access: cluster.Read},
+		0x0301: {Name: "Multiplier", Type: cluster.ZclDataTypeUint24, Access: cluster.Read},
+		0x0302: {Name: "Divisor", Type: cluster.ZclDataTypeUint24, Access: cluster.Read},
+		0x0303: {Name: "SummationFormatting", Type: cluster.ZclDataTypeBitmap8, Access: cluster.Read},
+		0x0304: {Name: "DemandFormatting", Type: cluster.ZclDataTypeBitmap8, Access: cluster.Read},
+		0x0306: {Name: "MeteringDeviceType", Type: cluster.ZclDataTypeBitmap8, Access: cluster.Read},
+		0x0400: {Name: "InstantaneousDemand", Type: cluster.ZclDataTypeInt24, Access: cluster.Read | cluster.Reportable},
+	},
+	CommandDescriptors: &cluster.CommandDescriptors{
+		Received:  map[uint8]*cluster.CommandDescriptor{},
+		Generated: map[uint8]*cluster.CommandDescriptor{},
+	},
+}
+
+var electricalMeasurement = &cluster.Cluster{
+	Name: "ElectricalMeasurement",
+	AttributeDescriptors: map[uint16]*cluster.AttributeDescriptor{
+		0x0000: {Name: "MeasurementType", Type: cluster.ZclDataTypeBitmap32, Access: cluster.Read},
+		0x0300: {Name: "ACFrequency", Type: cluster.ZclDataTypeUint16, Access: cluster.Read | cluster.Reportable},
+		0x0304: {Name: "TotalActivePower", Type: cluster.ZclDataTypeInt32, Access: cluster.Read | cluster.Reportable},
+		0x0505: {Name: "RMSVoltage", Type: cluster.ZclDataTypeUint16, Access: cluster.Read | cluster.Reportable},
+		0x0508: {Name: "RMSCurrent", Type: cluster.ZclDataTypeUint16, Access: cluster.Read | cluster.Reportable},
+		0x050b: {Name: "ActivePower", Type: cluster.ZclDataTypeInt16, Access: cluster.Read | cluster.Reportable},
+		0x050e: {Name: "ReactivePower", Type: cluster.ZclDataTypeInt16, Access: cluster.Read | cluster.Reportable},
+		0x050f: {Name: "ApparentPower", Type: cluster.ZclDataTypeUint16, Access: cluster.Read | cluster.Reportable},
+		0x0510: {Name: "PowerFactor", Type: cluster.ZclDataTypeInt8, Access: cluster.Read | cluster.Reportable},
+		0x0600: {Name: "ACVoltageMultiplier", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+		0x0601: {Name: "ACVoltageDivisor", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+		0x0602: {Name: "ACCurrentMultiplier", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+		0x0603: {Name: "ACCurrentDivisor", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+		0x0604: {Name: "ACPowerMultiplier", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+		0x0605: {Name: "ACPowerDivisor", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+	},
+	CommandDescriptors: &cluster.CommandDescriptors{
+		Received:  map[uint8]*cluster.CommandDescriptor{},
+		Generated: map[uint8]*cluster.CommandDescriptor{},
+	},
+}
@@ -0,0 +1,116 @@
+package clusters
+
+import "github.com/dyrkin/zcl-go/cluster"
+
+const (
+	OTAStatusSuccess          uint8 = 0x00
+	OTAStatusAbort            uint8 = 0x95
+	OTAStatusNotAuthorized    uint8 = 0x7e
+	OTAStatusInvalidImage     uint8 = 0x96
+	OTAStatusWaitForData      uint8 = 0x97
+	OTAStatusNoImageAvailable uint8 = 0x98
+	OTAStatusMalformedCommand uint8 = 0x80
+	OTAStatusRequireMoreImage uint8 = 0x99
+)
+
+type ImageNotifyCommand struct {
+	PayloadType      uint8
+	QueryJitter      uint8
+	ManufacturerCode uint16
+	ImageType        uint16
+	NewFileVersion   uint32
+}
+
+type QueryNextImageRequestCommand struct {
+	FieldControl       uint8
+	ManufacturerCode   uint16
+	ImageType          uint16
+	CurrentFileVersion uint32
+	HardwareVersion    uint16
+}
+
+type QueryNextImageResponseCommand struct {
+	Status           uint8
+	ManufacturerCode uint16 `cond:"uint:Status==0"`
+	ImageType        uint16 `cond:"uint:Status==0"`
+	FileVersion      uint32 `cond:"uint:Status==0"`
+	ImageSize        uint32 `cond:"uint:Status==0"`
+}
+
+type ImageBlockRequestCommand struct {
+	FieldControl     uint8
+	ManufacturerCode uint16
+	ImageType        uint16
+	FileVersion      uint32
+	FileOffset       uint32
+	MaximumDataSize  uint8
+}
+
+type ImagePageRequestCommand struct {
+	FieldControl     uint8
+	ManufacturerCode uint16
+	ImageType        uint16
+	FileVersion      uint32
+	FileOffset       uint32
+	MaximumDataSize  uint8
+	PageSize         uint16
+	ResponseSpacing  uint16
+}
+
+type ImageBlockResponseCommand struct {
+	Status             uint8
+	ManufacturerCode   uint16  `cond:"uint:Status==0"`
+	ImageType          uint16  `cond:"uint:Status==0"`
+	FileVersion        uint32  `cond:"uint:Status==0"`
+	FileOffset         uint32  `cond:"uint:Status==0"`
+	ImageData          []uint8 `cond:"uint:Status==0" size:"1"`
+	CurrentTime        uint32  `cond:"uint:Status==151"`
+	RequestTime        uint32  `cond:"uint:Status==151"`
+	MinimumBlockPeriod uint16  `cond:"uint:Status==151"`
+}
+
+type UpgradeEndRequestCommand struct {
+	Status           uint8
+	ManufacturerCode uint16
+	ImageType        uint16
+	FileVersion      uint32
+}
+
+type UpgradeEndResponseCommand struct {
+	ManufacturerCode uint16
+	ImageType        uint16
+	FileVersion      uint32
+	CurrentTime      uint32
+	UpgradeTime      uint32
+}
+
+var ota = &cluster.Cluster{
+	Name: "OTA",
+	AttributeDescriptors: map[uint16]*cluster.AttributeDescriptor{
+		0x0000: {Name: "UpgradeServerID", Type: cluster.ZclDataTypeIeeeAddr, Access: cluster.Read},
+		0x0001: {Name: "FileOffset", Type: cluster.ZclDataTypeUint32, Access: cluster.Read},
+		0x0002: {Name: "CurrentFileVersion", Type: cluster.ZclDataTypeUint32, Access: cluster.Read},
+		0x0003: {Name: "CurrentZigBeeStackVersion", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+		0x0004: {Name: "DownloadedFileVersion", Type: cluster.ZclDataTypeUint32, Access: cluster.Read},
+		0x0005: {Name: "DownloadedZigBeeStackVersion", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+		0x0006: {Name: "ImageUpgradeStatus", Type: cluster.ZclDataTypeEnum8, Access: cluster.Read},
+		0x0007: {Name: "ManufacturerID", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+		0x0008: {Name: "ImageTypeID", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+		0x0009: {Name: "MinimumBlockPeriod", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+		0x000a: {Name: "ImageStamp", Type: cluster.ZclDataTypeUint32, Access: cluster.Read},
+	},
+	CommandDescriptors: &cluster.CommandDescriptors{
+		Received: map[uint8]*cluster.CommandDescriptor{
+			0x01: {Name: "QueryNextImageRequest", Command: &QueryNextImageRequestCommand{}},
+			0x03: {Name: "ImageBlockRequest", Command: &ImageBlockRequestCommand{}},
+			0x04: {Name: "ImagePageRequest", Command: &ImagePageRequestCommand{}},
+			0x06: {Name: "UpgradeEndRequest", Command: &UpgradeEndRequestCommand{}},
+		},
+		Generated: map[uint8]*cluster.CommandDescriptor{
+			0x00: {Name: "ImageNotify", Command: &ImageNotifyCommand{}},
+			0x02: {Name: "QueryNextImageResponse", Command: &QueryNextImageResponseCommand{}},
+			0x05: {Name: "ImageBlockResponse", Command: &ImageBlockResponseCommand{}},
+			0x07: {Name: "UpgradeEndResponse", Command: &UpgradeEndResponseCommand{}},
+		},
+	},
+}
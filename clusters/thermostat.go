@@ -0,0 +1,157 @@
+package clusters
+
+import (
+	"encoding/binary"
+	"github.com/dyrkin/zcl-go/cluster"
+	"io"
+)
+
+const (
+	WeeklyScheduleModeHeat uint8 = 0x01
+	WeeklyScheduleModeCool uint8 = 0x02
+)
+
+type SetpointRaiseLowerCommand struct {
+	Mode   uint8
+	Amount uint8
+}
+
+type ThermostatTransition struct {
+	TransitionTime uint16
+	HeatSetpoint   int16
+	CoolSetpoint   int16
+}
+
+type WeeklySchedule struct {
+	DayOfWeekForSequence uint8
+	ModeForSequence      uint8
+	Transitions          []*ThermostatTransition
+}
+
+type SetWeeklyScheduleCommand struct {
+	WeeklySchedule *WeeklySchedule
+}
+
+type GetWeeklyScheduleCommand struct {
+	DaysToReturn uint8
+	ModeToReturn uint8
+}
+
+type ClearWeeklyScheduleCommand struct{}
+
+type GetRelayStatusLogCommand struct{}
+
+type GetWeeklyScheduleResponseCommand struct {
+	WeeklySchedule *WeeklySchedule
+}
+
+type GetRelayStatusLogResponseCommand struct {
+	TimeOfDay         uint16
+	RelayStatus       uint16
+	LocalTemperature  uint16
+	HumidityInPercent uint8
+	SetPoint          uint16
+	UnreadEntries     uint16
+}
+
+func (s *WeeklySchedule) Serialize(w io.Writer) {
+	binary.Write(w, binary.LittleEndian, uint8(len(s.Transitions)))
+	binary.Write(w, binary.LittleEndian, s.DayOfWeekForSequence)
+	binary.Write(w, binary.LittleEndian, s.ModeForSequence)
+	for _, transition := range s.Transitions {
+		binary.Write(w, binary.LittleEndian, transition.TransitionTime)
+		if s.ModeForSequence&WeeklyScheduleModeHeat > 0 {
+			binary.Write(w, binary.LittleEndian, transition.HeatSetpoint)
+		}
+		if s.ModeForSequence&WeeklyScheduleModeCool > 0 {
+			binary.Write(w, binary.LittleEndian, transition.CoolSetpoint)
+		}
+	}
+}
+
+func (s *WeeklySchedule) Deserialize(r io.Reader) {
+	var numberOfTransitions uint8
+	binary.Read(r, binary.LittleEndian, &numberOfTransitions)
+	binary.Read(r, binary.LittleEndian, &s.DayOfWeekForSequence)
+	binary.Read(r, binary.LittleEndian, &s.ModeForSequence)
+	s.Transitions = make([]*ThermostatTransition, numberOfTransitions)
+	for i := range s.Transitions {
+		transition := &ThermostatTransition{}
+		binary.Read(r, binary.LittleEndian, &transition.TransitionTime)
+		if s.ModeForSequence&WeeklyScheduleModeHeat > 0 {
+			binary.Read(r, binary.LittleEndian, &transition.HeatSetpoint)
+		}
+		if s.ModeForSequence&WeeklyScheduleModeCool > 0 {
+			binary.Read(r, binary.LittleEndian, &transition.CoolSetpoint)
+		}
+		s.Transitions[i] = transition
+	}
+}
+
+var thermostat = &cluster.Cluster{
+	Name: "Thermostat",
+	AttributeDescriptors: map[uint16]*cluster.AttributeDescriptor{
+		0x0000: {Name: "LocalTemperature", Type: cluster.ZclDataTypeInt16, Access: cluster.Read | cluster.Reportable},
+		0x0001: {Name: "OutdoorTemperature", Type: cluster.ZclDataTypeInt16, Access: cluster.Read},
+		0x0002: {Name: "Occupancy", Type: cluster.ZclDataTypeBitmap8, Access: cluster.Read},
+		0x0003: {Name: "AbsMinHeatSetpointLimit", Type: cluster.ZclDataTypeInt16, Access: cluster.Read},
+		0x0004: {Name: "AbsMaxHeatSetpointLimit", Type: cluster.ZclDataTypeInt16, Access: cluster.Read},
+		0x0005: {Name: "AbsMinCoolSetpointLimit", Type: cluster.ZclDataTypeInt16, Access: cluster.Read},
+		0x0006: {Name: "AbsMaxCoolSetpointLimit", Type: cluster.ZclDataTypeInt16, Access: cluster.Read},
+		0x0007: {Name: "PICoolingDemand", Type: cluster.ZclDataTypeUint8, Access: cluster.Read | cluster.Reportable},
+		0x0008: {Name: "PIHeatingDemand", Type: cluster.ZclDataTypeUint8, Access: cluster.Read | cluster.Reportable},
+		0x0009: {Name: "HVACSystemTypeConfiguration", Type: cluster.ZclDataTypeBitmap8, Access: cluster.Read | cluster.Write},
+		0x0010: {Name: "LocalTemperatureCalibration", Type: cluster.ZclDataTypeInt8, Access: cluster.Read | cluster.Write},
+		0x0011: {Name: "OccupiedCoolingSetpoint", Type: cluster.ZclDataTypeInt16, Access: cluster.Read | cluster.Write | cluster.Reportable | cluster.Scene},
+		0x0012: {Name: "OccupiedHeatingSetpoint", Type: cluster.ZclDataTypeInt16, Access: cluster.Read | cluster.Write | cluster.Reportable | cluster.Scene},
+		0x0013: {Name: "UnoccupiedCoolingSetpoint", Type: cluster.ZclDataTypeInt16, Access: cluster.Read | cluster.Write},
+		0x0014: {Name: "UnoccupiedHeatingSetpoint", Type: cluster.ZclDataTypeInt16, Access: cluster.Read | cluster.Write},
+		0x0015: {Name: "MinHeatSetpointLimit", Type: cluster.ZclDataTypeInt16, Access: cluster.Read | cluster.Write},
+		0x0016: {Name: "MaxHeatSetpointLimit", Type: cluster.ZclDataTypeInt16, Access: cluster.Read | cluster.Write},
+		0x0017: {Name: "MinCoolSetpointLimit", Type: cluster.ZclDataTypeInt16, Access: cluster.Read | cluster.Write},
+		0x0018: {Name: "MaxCoolSetpointLimit", Type: cluster.ZclDataTypeInt16, Access: cluster.Read | cluster.Write},
+		0x0019: {Name: "MinSetpointDeadBand", Type: cluster.ZclDataTypeInt8, Access: cluster.Read | cluster.Write},
+		0x001a: {Name: "RemoteSensing", Type: cluster.ZclDataTypeBitmap8, Access: cluster.Read | cluster.Write},
+		0x001b: {Name: "ControlSequenceOfOperation", Type: cluster.ZclDataTypeEnum8, Access: cluster.Read | cluster.Write},
+		0x001c: {Name: "SystemMode", Type: cluster.ZclDataTypeEnum8, Access: cluster.Read | cluster.Write | cluster.Reportable | cluster.Scene},
+		0x001d: {Name: "AlarmMask", Type: cluster.ZclDataTypeBitmap8, Access: cluster.Read},
+		0x001e: {Name: "ThermostatRunningMode", Type: cluster.ZclDataTypeEnum8, Access: cluster.Read},
+		0x0020: {Name: "StartOfWeek", Type: cluster.ZclDataTypeEnum8, Access: cluster.Read},
+		0x0021: {Name: "NumberOfWeeklyTransitions", Type: cluster.ZclDataTypeUint8, Access: cluster.Read},
+		0x0022: {Name: "NumberOfDailyTransitions", Type: cluster.ZclDataTypeUint8, Access: cluster.Read},
+		0x0023: {Name: "TemperatureSetpointHold", Type: cluster.ZclDataTypeEnum8, Access: cluster.Read | cluster.Write},
+		0x0024: {Name: "TemperatureSetpointHoldDuration", Type: cluster.ZclDataTypeUint16, Access: cluster.Read | cluster.Write},
+		0x0025: {Name: "ThermostatProgrammingOperationMode", Type: cluster.ZclDataTypeBitmap8, Access: cluster.Read | cluster.Write | cluster.Reportable},
+		0x0029: {Name: "ThermostatRunningState", Type: cluster.ZclDataTypeBitmap16, Access: cluster.Read},
+	},
+	CommandDescriptors: &cluster.CommandDescriptors{
+		Received: map[uint8]*cluster.CommandDescriptor{
+			0x00: {Name: "SetpointRaiseLower", Command: &SetpointRaiseLowerCommand{}},
+			0x01: {Name: "SetWeeklySchedule", Command: &SetWeeklyScheduleCommand{}},
+			0x02: {Name: "GetWeeklySchedule", Command: &GetWeeklyScheduleCommand{}},
+			0x03: {Name: "ClearWeeklySchedule", Command: &ClearWeeklyScheduleCommand{}},
+			0x04: {Name: "GetRelayStatusLog", Command: &GetRelayStatusLogCommand{}},
+		},
+		Generated: map[uint8]*cluster.CommandDescriptor{
+			0x00: {Name: "GetWeeklyScheduleResponse", Command: &GetWeeklyScheduleResponseCommand{}},
+			0x01: {Name: "GetRelayStatusLogResponse", Command: &GetRelayStatusLogResponseCommand{}},
+		},
+	},
+}
+
+var fanControl = &cluster.Cluster{
+	Name: "FanControl",
+	AttributeDescriptors: map[uint16]*cluster.AttributeDescriptor{
+		0x0000: {Name: "FanMode", Type: cluster.ZclDataTypeEnum8, Access: cluster.Read | cluster.Write},
+		0x0001: {Name: "FanModeSequence", Type: cluster.ZclDataTypeEnum8, Access: cluster.Read | cluster.Write},
+	},
+}
+
+var thermostatUIConfiguration = &cluster.Cluster{
+	Name: "ThermostatUIConfiguration",
+	AttributeDescriptors: map[uint16]*cluster.AttributeDescriptor{
+		0x0000: {Name: "TemperatureDisplayMode", Type: cluster.ZclDataTypeEnum8, Access: cluster.Read | cluster.Write},
+		0x0001: {Name: "KeypadLockout", Type: cluster.ZclDataTypeEnum8, Access: cluster.Read | cluster.Write},
+		0x0002: {Name: "ScheduleProgrammingVisibility", Type: cluster.ZclDataTypeEnum8, Access: cluster.Read | cluster.Write},
+	},
+}
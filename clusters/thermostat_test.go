@@ -0,0 +1,87 @@
+package clusters
+
+import (
+	"bytes"
+	"github.com/dyrkin/bin"
+	"reflect"
+	"testing"
+)
+
+func TestWeeklyScheduleSerialize(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule *WeeklySchedule
+		want     []uint8
+	}{
+		{
+			name:     "no transitions",
+			schedule: &WeeklySchedule{DayOfWeekForSequence: 0x01, ModeForSequence: WeeklyScheduleModeHeat},
+			want:     []uint8{0x00, 0x01, 0x01},
+		},
+		{
+			name: "heat only",
+			schedule: &WeeklySchedule{
+				DayOfWeekForSequence: 0x3e,
+				ModeForSequence:      WeeklyScheduleModeHeat,
+				Transitions: []*ThermostatTransition{
+					{TransitionTime: 360, HeatSetpoint: 2100},
+					{TransitionTime: 1320, HeatSetpoint: 1700},
+				},
+			},
+			want: []uint8{0x02, 0x3e, 0x01, 0x68, 0x01, 0x34, 0x08, 0x28, 0x05, 0xa4, 0x06},
+		},
+		{
+			name: "cool only",
+			schedule: &WeeklySchedule{
+				DayOfWeekForSequence: 0x40,
+				ModeForSequence:      WeeklyScheduleModeCool,
+				Transitions:          []*ThermostatTransition{{TransitionTime: 600, CoolSetpoint: 2600}},
+			},
+			want: []uint8{0x01, 0x40, 0x02, 0x58, 0x02, 0x28, 0x0a},
+		},
+		{
+			name: "heat and cool",
+			schedule: &WeeklySchedule{
+				DayOfWeekForSequence: 0x7f,
+				ModeForSequence:      WeeklyScheduleModeHeat | WeeklyScheduleModeCool,
+				Transitions:          []*ThermostatTransition{{TransitionTime: 0, HeatSetpoint: -500, CoolSetpoint: 2600}},
+			},
+			want: []uint8{0x01, 0x7f, 0x03, 0x00, 0x00, 0x0c, 0xfe, 0x28, 0x0a},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			test.schedule.Serialize(buf)
+			if !bytes.Equal(buf.Bytes(), test.want) {
+				t.Fatalf("serialize: got %x, want %x", buf.Bytes(), test.want)
+			}
+			decoded := &WeeklySchedule{}
+			decoded.Deserialize(bytes.NewReader(test.want))
+			if test.schedule.Transitions == nil {
+				test.schedule.Transitions = []*ThermostatTransition{}
+			}
+			if !reflect.DeepEqual(decoded, test.schedule) {
+				t.Errorf("deserialize: got %+v, want %+v", decoded, test.schedule)
+			}
+		})
+	}
+}
+
+func TestWeeklyScheduleCommandEncoding(t *testing.T) {
+	schedule := &WeeklySchedule{
+		DayOfWeekForSequence: 0x01,
+		ModeForSequence:      WeeklyScheduleModeHeat,
+		Transitions:          []*ThermostatTransition{{TransitionTime: 360, HeatSetpoint: 2100}},
+	}
+	encoded := bin.Encode(&SetWeeklyScheduleCommand{WeeklySchedule: schedule})
+	want := []uint8{0x01, 0x01, 0x01, 0x68, 0x01, 0x34, 0x08}
+	if !bytes.Equal(encoded, want) {
+		t.Fatalf("got %x, want %x", encoded, want)
+	}
+	response := &GetWeeklyScheduleResponseCommand{}
+	bin.Decode(encoded, response)
+	if !reflect.DeepEqual(response.WeeklySchedule, schedule) {
+		t.Errorf("got %+v, want %+v", response.WeeklySchedule, schedule)
+	}
+}
@@ -0,0 +1,31 @@
+package clusters
+
+import "github.com/dyrkin/zcl-go/cluster"
+
+const (
+	TimeStatusMaster        uint8  = 0x01
+	TimeStatusSynchronized  uint8  = 0x02
+	TimeStatusMasterZoneDst uint8  = 0x04
+	TimeStatusSuperseding   uint8  = 0x08
+	TimeInvalid             uint32 = 0xffffffff
+)
+
+var timeCluster = &cluster.Cluster{
+	Name: "Time",
+	AttributeDescriptors: map[uint16]*cluster.AttributeDescriptor{
+		0x0000: {Name: "Time", Type: cluster.ZclDataTypeUtc, Access: cluster.Read | cluster.Write},
+		0x0001: {Name: "TimeStatus", Type: cluster.ZclDataTypeBitmap8, Access: cluster.Read | cluster.Write},
+		0x0002: {Name: "TimeZone", Type: cluster.ZclDataTypeInt32, Access: cluster.Read | cluster.Write},
+		0x0003: {Name: "DstStart", Type: cluster.ZclDataTypeUint32, Access: cluster.Read | cluster.Write},
+		0x0004: {Name: "DstEnd", Type: cluster.ZclDataTypeUint32, Access: cluster.Read | cluster.Write},
+		0x0005: {Name: "DstShift", Type: cluster.ZclDataTypeInt32, Access: cluster.Read | cluster.Write},
+		0x0006: {Name: "StandardTime", Type: cluster.ZclDataTypeUint32, Access: cluster.Read},
+		0x0007: {Name: "LocalTime", Type: cluster.ZclDataTypeUint32, Access: cluster.Read},
+		0x0008: {Name: "LastSetTime", Type: cluster.ZclDataTypeUtc, Access: cluster.Read},
+		0x0009: {Name: "ValidUntilTime", Type: cluster.ZclDataTypeUtc, Access: cluster.Read | cluster.Write},
+	},
+	CommandDescriptors: &cluster.CommandDescriptors{
+		Received:  map[uint8]*cluster.CommandDescriptor{},
+		Generated: map[uint8]*cluster.CommandDescriptor{},
+	},
+}
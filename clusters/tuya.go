@@ -0,0 +1,31 @@
+package clusters
+
+import "github.com/dyrkin/zcl-go/cluster"
+
+type TuyaDatapoint struct {
+	Id   uint8
+	Type uint8
+	Data []uint8 `size:"2" endianness:"be"`
+}
+
+type TuyaDataCommand struct {
+	Sequence   uint16 `endianness:"be"`
+	Datapoints []*TuyaDatapoint
+}
+
+type TuyaDataQueryCommand struct{}
+
+var tuya = &cluster.Cluster{
+	Name:                 "Tuya",
+	AttributeDescriptors: map[uint16]*cluster.AttributeDescriptor{},
+	CommandDescriptors: &cluster.CommandDescriptors{
+		Received: map[uint8]*cluster.CommandDescriptor{
+			0x00: {Name: "DataRequest", Command: &TuyaDataCommand{}},
+			0x03: {Name: "DataQuery", Command: &TuyaDataQueryCommand{}},
+		},
+		Generated: map[uint8]*cluster.CommandDescriptor{
+			0x01: {Name: "DataResponse", Command: &TuyaDataCommand{}},
+			0x02: {Name: "DataReport", Command: &TuyaDataCommand{}},
+		},
+	},
+}
@@ -0,0 +1,62 @@
+package clusters
+
+import "github.com/dyrkin/zcl-go/cluster"
+
+type UpOpenCommand struct{}
+
+type DownCloseCommand struct{}
+
+type WindowCoveringStopCommand struct{}
+
+type GoToLiftValueCommand struct {
+	LiftValue uint16
+}
+
+type GoToLiftPercentageCommand struct {
+	PercentageLiftValue uint8
+}
+
+type GoToTiltValueCommand struct {
+	TiltValue uint16
+}
+
+type GoToTiltPercentageCommand struct {
+	PercentageTiltValue uint8
+}
+
+var windowCovering = &cluster.Cluster{
+	Name: "WindowCovering",
+	AttributeDescriptors: map[uint16]*cluster.AttributeDescriptor{
+		0x0000: {Name: "WindowCoveringType", Type: cluster.ZclDataTypeEnum8, Access: cluster.Read},
+		0x0001: {Name: "PhysicalClosedLimitLift", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+		0x0002: {Name: "PhysicalClosedLimitTilt", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+		0x0003: {Name: "CurrentPositionLift", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+		0x0004: {Name: "CurrentPositionTilt", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+		0x0005: {Name: "NumberOfActuationsLift", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+		0x0006: {Name: "NumberOfActuationsTilt", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+		0x0007: {Name: "ConfigStatus", Type: cluster.ZclDataTypeBitmap8, Access: cluster.Read},
+		0x0008: {Name: "CurrentPositionLiftPercentage", Type: cluster.ZclDataTypeUint8, Access: cluster.Read | cluster.Reportable | cluster.Scene},
+		0x0009: {Name: "CurrentPositionTiltPercentage", Type: cluster.ZclDataTypeUint8, Access: cluster.Read | cluster.Reportable | cluster.Scene},
+		0x0010: {Name: "InstalledOpenLimitLift", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+		0x0011: {Name: "InstalledClosedLimitLift", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+		0x0012: {Name: "InstalledOpenLimitTilt", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+		0x0013: {Name: "InstalledClosedLimitTilt", Type: cluster.ZclDataTypeUint16, Access: cluster.Read},
+		0x0014: {Name: "VelocityLift", Type: cluster.ZclDataTypeUint16, Access: cluster.Read | cluster.Write},
+		0x0015: {Name: "AccelerationTimeLift", Type: cluster.ZclDataTypeUint16, Access: cluster.Read | cluster.Write},
+		0x0016: {Name: "DecelerationTimeLift", Type: cluster.ZclDataTypeUint16, Access: cluster.Read | cluster.Write},
+		0x0017: {Name: "Mode", Type: cluster.ZclDataTypeBitmap8, Access: cluster.Read | cluster.Write},
+		0x0018: {Name: "IntermediateSetpointsLift", Type: cluster.ZclDataTypeOctetStr, Access: cluster.Read | cluster.Write},
+		0x0019: {Name: "IntermediateSetpointsTilt", Type: cluster.ZclDataTypeOctetStr, Access: cluster.Read | cluster.Write},
+	},
+	CommandDescriptors: &cluster.CommandDescriptors{
+		Received: map[uint8]*cluster.CommandDescriptor{
+			0x00: {Name: "UpOpen", Command: &UpOpenCommand{}},
+			0x01: {Name: "DownClose", Command: &DownCloseCommand{}},
+			0x02: {Name: "Stop", Command: &WindowCoveringStopCommand{}},
+			0x04: {Name: "GoToLiftValue", Command: &GoToLiftValueCommand{}},
+			0x05: {Name: "GoToLiftPercentage", Command: &GoToLiftPercentageCommand{}},
+			0x07: {Name: "GoToTiltValue", Command: &GoToTiltValueCommand{}},
+			0x08: {Name: "GoToTiltPercentage", Command: &GoToTiltPercentageCommand{}},
+		},
+	},
+}
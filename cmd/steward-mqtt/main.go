@@ -0,0 +1,101 @@
+package main
+
+import (
+	"flag"
+	"github.com/dyrkin/zigbee-steward"
+	"github.com/dyrkin/zigbee-steward/configuration"
+	"github.com/dyrkin/zigbee-steward/logger"
+	"github.com/dyrkin/zigbee-steward/metrics"
+	"github.com/dyrkin/zigbee-steward/mqtt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+var log = logger.MustGetLogger("steward-mqtt")
+
+func main() {
+	flags := configuration.Default()
+	mqttConf := mqtt.Default()
+
+	configFile := flag.String("config", "configuration.yaml", "YAML or JSON configuration file. Created with a generated network key on first run")
+	configuration.BindFlags(flag.CommandLine, flags)
+	flag.BoolVar(&flags.PermitJoin, "permit-join", flags.PermitJoin, "permit new devices to join the network on start")
+	flag.StringVar(&mqttConf.Server, "mqtt-server", mqttConf.Server, "MQTT server URL")
+	flag.StringVar(&mqttConf.ClientId, "mqtt-client-id", mqttConf.ClientId, "MQTT client id")
+	flag.StringVar(&mqttConf.Username, "mqtt-username", mqttConf.Username, "MQTT username")
+	flag.StringVar(&mqttConf.Password, "mqtt-password", mqttConf.Password, "MQTT password")
+	flag.StringVar(&mqttConf.BaseTopic, "mqtt-base-topic", mqttConf.BaseTopic, "MQTT base topic")
+	qos := flag.Uint("mqtt-qos", uint(mqttConf.QoS), "MQTT QoS level of published messages and subscriptions")
+	flag.BoolVar(&mqttConf.Retain, "mqtt-retain", mqttConf.Retain, "retain device state messages")
+	flag.BoolVar(&mqttConf.HomeAssistant, "homeassistant", mqttConf.HomeAssistant, "publish Home Assistant MQTT discovery messages")
+	flag.StringVar(&mqttConf.HomeAssistantPrefix, "homeassistant-prefix", mqttConf.HomeAssistantPrefix, "Home Assistant discovery prefix")
+	flag.StringVar(&mqttConf.FriendlyNamesFile, "friendly-names", "friendly_names.json", "file to store device friendly names")
+	metricsAddress := flag.String("metrics-listen", "", "address to expose prometheus metrics on, e.g. :9100. Disabled when empty")
+	logLevel := flag.String("log-level", "info", "log level and per module overrides, e.g. info,coordinator=debug")
+	logFormat := flag.String("log-format", logger.FormatText, "log format: text or json")
+	logFile := flag.String("log-file", "", "file to append logs to. Logs go to stderr when empty")
+	flag.Parse()
+	if err := logger.ConfigureFromFlags(*logLevel, *logFormat, *logFile); err != nil {
+		log.Fatal(err)
+	}
+	conf, err := configuration.Load(*configFile)
+	if err == nil {
+		err = configuration.ApplyFlags(conf, flag.CommandLine, flags)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	mqttConf.QoS = uint8(*qos)
+
+	stewie, err := steward.New(conf)
+	if err != nil {
+		log.Fatal(err)
+	}
+	bridge, err := mqtt.New(stewie, mqttConf)
+	if err != nil {
+		log.Fatal(err)
+	}
+	go drain(stewie.Channels())
+	stewie.Start()
+	if err := bridge.Start(); err != nil {
+		log.Fatal(err)
+	}
+	if *metricsAddress != "" {
+		go serveMetrics(*metricsAddress)
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	<-signals
+	log.Info("Stopping...")
+	bridge.Stop()
+}
+
+func serveMetrics(address string) {
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", metrics.Handler())
+	log.Infof("Exposing metrics on [%s]", address)
+	if err := http.ListenAndServe(address, mux); err != nil {
+		log.Errorf("Unable to expose metrics: %s", err)
+	}
+}
+
+// consume events the bridge doesn't handle so their channels never overflow
+func drain(channels *steward.Channels) {
+	for {
+		select {
+		case <-channels.OnDeviceIncomingMessage():
+		case <-channels.OnDeviceZoneStatusChange():
+		case <-channels.OnDeviceLockOperationEvent():
+		case <-channels.OnDeviceLockProgrammingEvent():
+		case <-channels.OnDeviceOTAProgress():
+		case <-channels.OnDeviceEnergyMeasurement():
+		case <-channels.OnDeviceSensorReading():
+		case <-channels.OnDeviceClick():
+		case <-channels.OnDeviceTuyaReport():
+		case <-channels.OnDeviceInterviewProgress():
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"flag"
+	"github.com/dyrkin/zigbee-steward"
+	"github.com/dyrkin/zigbee-steward/configuration"
+	"github.com/dyrkin/zigbee-steward/logger"
+	"github.com/dyrkin/zigbee-steward/server"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+)
+
+var log = logger.MustGetLogger("steward-server")
+
+func main() {
+	flags := configuration.Default()
+	serverConf := server.Default()
+
+	configFile := flag.String("config", "configuration.yaml", "YAML or JSON configuration file. Created with a generated network key on first run")
+	configuration.BindFlags(flag.CommandLine, flags)
+	flag.BoolVar(&flags.PermitJoin, "permit-join", flags.PermitJoin, "permit new devices to join the network on start")
+	flag.StringVar(&serverConf.Address, "listen", serverConf.Address, "address of the HTTP server")
+	flag.BoolVar(&serverConf.Metrics, "metrics", serverConf.Metrics, "expose prometheus metrics on /metrics")
+	allowedOrigins := flag.String("allowed-origins", "", "comma separated origins allowed to open the event stream, * for any")
+	logLevel := flag.String("log-level", "info", "log level and per module overrides, e.g. info,coordinator=debug")
+	logFormat := flag.String("log-format", logger.FormatText, "log format: text or json")
+	logFile := flag.String("log-file", "", "file to append logs to. Logs go to stderr when empty")
+	flag.Parse()
+	if err := logger.ConfigureFromFlags(*logLevel, *logFormat, *logFile); err != nil {
+		log.Fatal(err)
+	}
+	conf, err := configuration.Load(*configFile)
+	if err == nil {
+		err = configuration.ApplyFlags(conf, flag.CommandLine, flags)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, origin := range strings.Split(*allowedOrigins, ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			serverConf.AllowedOrigins = append(serverConf.AllowedOrigins, origin)
+		}
+	}
+
+	stewie, err := steward.New(conf)
+	if err != nil {
+		log.Fatal(err)
+	}
+	apiServer := server.New(stewie, serverConf)
+	stewie.Start()
+	go func() {
+		if err := apiServer.Start(); err != nil {
+			log.Fatal(err)
+		}
+	}()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	<-signals
+	log.Info("Stopping...")
+	if err := apiServer.Stop(); err != nil {
+		log.Error(err)
+	}
+}
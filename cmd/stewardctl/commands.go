@@ -0,0 +1,265 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/dyrkin/zcl-go/cluster"
+	"github.com/dyrkin/zigbee-steward/converters"
+	"github.com/dyrkin/zigbee-steward/db"
+	"github.com/dyrkin/zigbee-steward/functions"
+	"github.com/dyrkin/zigbee-steward/model"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const coordinatorTarget = "coordinator"
+
+func listDevices(s *session, args []string) (output, error) {
+	devices := db.Database().Tables().Devices.All()
+	sort.Slice(devices, func(i, j int) bool {
+		return devices[i].IEEEAddress < devices[j].IEEEAddress
+	})
+	if devices == nil {
+		devices = []*model.Device{}
+	}
+	return deviceList(devices), nil
+}
+
+func showDevice(s *session, args []string) (output, error) {
+	device, err := registeredDevice(args[1])
+	if err != nil {
+		return nil, err
+	}
+	return (*deviceDetails)(device), nil
+}
+
+func readAttributes(s *session, args []string) (output, error) {
+	device, err := registeredDevice(args[1])
+	if err != nil {
+		return nil, err
+	}
+	clusterId, err := parseCluster(args[2])
+	if err != nil {
+		return nil, err
+	}
+	var attributeIds []uint16
+	for _, id := range strings.Split(args[3], ",") {
+		attributeId, err := parseUint("attribute", id, 16)
+		if err != nil {
+			return nil, err
+		}
+		attributeIds = append(attributeIds, uint16(attributeId))
+	}
+	response, err := s.device(device).ReadAttributes(clusterId, attributeIds)
+	if err != nil {
+		return nil, err
+	}
+	result := attributeList{}
+	for _, status := range response.ReadAttributeStatuses {
+		a := &attribute{Id: status.AttributeID, Status: status.Status}
+		if status.Attribute != nil {
+			a.Type = status.Attribute.DataType
+			a.Value = status.Attribute.Value
+		}
+		result = append(result, a)
+	}
+	return result, nil
+}
+
+func writeAttribute(s *session, args []string) (output, error) {
+	device, err := registeredDevice(args[1])
+	if err != nil {
+		return nil, err
+	}
+	clusterId, err := parseCluster(args[2])
+	if err != nil {
+		return nil, err
+	}
+	attributeId, err := parseUint("attribute", args[3], 16)
+	if err != nil {
+		return nil, err
+	}
+	var dataType cluster.ZclDataType
+	if s.options.dataType != "" {
+		t, err := parseUint("type", s.options.dataType, 8)
+		if err != nil {
+			return nil, err
+		}
+		dataType = cluster.ZclDataType(t)
+	} else {
+		knownType, ok := s.stewie.Functions().Cluster().Local().Generic(clusterId).AttributeType(uint16(attributeId))
+		if !ok {
+			return nil, fmt.Errorf("type of attribute [%d] is unknown. Use -type", attributeId)
+		}
+		dataType = knownType
+	}
+	value, err := converters.AttributeValue(dataType, args[4])
+	if err != nil {
+		return nil, err
+	}
+	records := []*cluster.WriteAttributeRecord{{
+		AttributeID: uint16(attributeId),
+		Attribute:   &cluster.Attribute{DataType: dataType, Value: value},
+	}}
+	response, err := s.device(device).WriteAttributes(clusterId, records)
+	if err != nil {
+		return nil, err
+	}
+	result := attributeList{}
+	for _, status := range response.WriteAttributeStatuses {
+		result = append(result, &attribute{Id: status.AttributeID, Status: status.Status})
+	}
+	return result, nil
+}
+
+func onOff(s *session, args []string) (output, error) {
+	device, err := registeredDevice(args[1])
+	if err != nil {
+		return nil, err
+	}
+	functions := s.device(device).OnOff()
+	switch args[0] {
+	case "on":
+		err = functions.On()
+	case "off":
+		err = functions.Off()
+	default:
+		err = functions.Toggle()
+	}
+	return nil, err
+}
+
+func level(s *session, args []string) (output, error) {
+	device, err := registeredDevice(args[1])
+	if err != nil {
+		return nil, err
+	}
+	level, err := parseUint("level", args[2], 8)
+	if err != nil {
+		return nil, err
+	}
+	var transition uint64
+	if len(args) > 3 {
+		if transition, err = parseUint("transition", args[3], 16); err != nil {
+			return nil, err
+		}
+	}
+	return nil, s.device(device).LevelControl().MoveToLevelOnOff(uint8(level), uint16(transition))
+}
+
+func bind(s *session, args []string) (output, error) {
+	device, err := registeredDevice(args[1])
+	if err != nil {
+		return nil, err
+	}
+	clusterId, err := parseCluster(args[2])
+	if err != nil {
+		return nil, err
+	}
+	target := coordinatorTarget
+	if len(args) > 3 {
+		target = args[3]
+	}
+	var targetEndpoint uint64
+	if len(args) > 4 {
+		if targetEndpoint, err = parseUint("target endpoint", args[4], 8); err != nil {
+			return nil, err
+		}
+	}
+	if target == coordinatorTarget {
+		target = s.conf.IEEEAddress
+		if targetEndpoint == 0 {
+			targetEndpoint = uint64(s.stewie.HostEndpoint())
+		}
+	} else {
+		if _, err := registeredDevice(target); err != nil {
+			return nil, err
+		}
+		if targetEndpoint == 0 {
+			return nil, fmt.Errorf("target endpoint is required when binding to a device")
+		}
+	}
+	if args[0] == "bind" {
+		_, err = s.device(device).Bind(clusterId, target, uint8(targetEndpoint))
+	} else {
+		_, err = s.device(device).Unbind(clusterId, target, uint8(targetEndpoint))
+	}
+	return nil, err
+}
+
+func permitJoin(s *session, args []string) (output, error) {
+	timeout, err := parseUint("seconds", args[1], 8)
+	if err != nil {
+		return nil, err
+	}
+	//255 permits join forever, which a one-shot command can't wait for
+	if timeout == 0 || timeout == 0xff {
+		return nil, fmt.Errorf("seconds must be between 1 and 254")
+	}
+	if err := s.stewie.PermitJoin(uint8(timeout)); err != nil {
+		return nil, err
+	}
+	log.Infof("Waiting [%d] seconds for new devices", timeout)
+	joined := deviceList{}
+	deadline := time.After(time.Duration(timeout) * time.Second)
+	for {
+		select {
+		case device := <-s.registered:
+			log.Infof("Device [%s] joined. Manufacturer: [%s], Model: [%s]", device.IEEEAddress, device.Manufacturer, device.Model)
+			joined = append(joined, device)
+		case <-deadline:
+			return joined, nil
+		}
+	}
+}
+
+func topology(s *session, args []string) (output, error) {
+	return (*topologyOutput)(s.stewie.Topology()), nil
+}
+
+func backup(s *session, args []string) (output, error) {
+	backup, err := s.stewie.BackupCoordinator()
+	if err != nil {
+		return nil, err
+	}
+	if len(args) < 2 {
+		return (*backupOutput)(backup), nil
+	}
+	data, err := json.MarshalIndent(backup, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(args[1], data, 0600); err != nil {
+		return nil, err
+	}
+	log.Infof("Coordinator backup is written to [%s]", args[1])
+	return nil, nil
+}
+
+func (s *session) device(device *model.Device) *functions.DeviceFunctions {
+	return s.stewie.Device(device.IEEEAddress).Endpoint(uint8(s.options.endpoint))
+}
+
+func registeredDevice(ieeeAddress string) (*model.Device, error) {
+	device, ok := db.Database().Tables().Devices.Get(ieeeAddress)
+	if !ok {
+		return nil, fmt.Errorf("device [%s] is not registered", ieeeAddress)
+	}
+	return device, nil
+}
+
+func parseCluster(value string) (cluster.ClusterId, error) {
+	clusterId, err := parseUint("cluster", value, 16)
+	return cluster.ClusterId(clusterId), err
+}
+
+func parseUint(name string, value string, bitSize int) (uint64, error) {
+	parsed, err := strconv.ParseUint(strings.TrimSpace(value), 0, bitSize)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s [%s]", name, value)
+	}
+	return parsed, nil
+}
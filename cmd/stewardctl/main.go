@@ -0,0 +1,168 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/dyrkin/zigbee-steward"
+	"github.com/dyrkin/zigbee-steward/configuration"
+	"github.com/dyrkin/zigbee-steward/logger"
+	"github.com/dyrkin/zigbee-steward/model"
+	"os"
+	"sort"
+	"strings"
+)
+
+var log = logger.MustGetLogger("stewardctl")
+
+type options struct {
+	output   string
+	endpoint uint
+	dataType string
+	verbose  bool
+}
+
+type session struct {
+	conf       *configuration.Configuration
+	options    *options
+	stewie     *steward.Steward
+	registered chan *model.Device
+}
+
+type command struct {
+	usage       string
+	description string
+	minArgs     int
+	maxArgs     int
+	//commands which talk to the network start the stack first
+	network bool
+	run     func(s *session, args []string) (output, error)
+}
+
+var commands = map[string]*command{
+	"devices":     {"", "list registered devices", 0, 0, false, listDevices},
+	"device":      {"<ieee>", "show endpoints and clusters of a device", 1, 1, false, showDevice},
+	"read":        {"<ieee> <cluster> <attribute>[,<attribute>...]", "read attributes", 3, 3, true, readAttributes},
+	"write":       {"<ieee> <cluster> <attribute> <value>", "write an attribute. Type is looked up unless -type is given", 4, 4, true, writeAttribute},
+	"on":          {"<ieee>", "switch a device on", 1, 1, true, onOff},
+	"off":         {"<ieee>", "switch a device off", 1, 1, true, onOff},
+	"toggle":      {"<ieee>", "toggle a device", 1, 1, true, onOff},
+	"level":       {"<ieee> <level> [transition]", "move to level, transition in 1/10 seconds", 2, 3, true, level},
+	"bind":        {"<ieee> <cluster> [target [target-endpoint]]", "bind a cluster to the coordinator or another device", 2, 4, true, bind},
+	"unbind":      {"<ieee> <cluster> [target [target-endpoint]]", "remove a binding", 2, 4, true, bind},
+	"permit-join": {"<seconds>", "permit devices to join and wait for them", 1, 1, true, permitJoin},
+	"topology":    {"", "dump neighbor tables of the coordinator and routers", 0, 0, true, topology},
+	"backup":      {"[file]", "back up network parameters of the coordinator", 0, 1, true, backup},
+}
+
+func main() {
+	flags := configuration.Default()
+	opts := &options{}
+
+	configFile := flag.String("config", "configuration.yaml", "YAML or JSON configuration file. Created with a generated network key on first run")
+	configuration.BindFlags(flag.CommandLine, flags)
+	flag.StringVar(&opts.output, "output", "table", "output format: table or json")
+	flag.UintVar(&opts.endpoint, "endpoint", 0, "endpoint of the device. The first endpoint hosting the cluster is used by default")
+	flag.StringVar(&opts.dataType, "type", "", "ZCL data type of the written attribute, e.g. 0x21")
+	flag.BoolVar(&opts.verbose, "verbose", false, "log everything the stack does")
+	flag.Usage = usage
+	flag.Parse()
+	//logs go to stderr so the output of commands stays parsable
+	logLevels := "warning,stewardctl=info"
+	if opts.verbose {
+		logLevels = "debug"
+	}
+	if err := logger.ConfigureFromFlags(logLevels, logger.FormatText, ""); err != nil {
+		fail(err)
+	}
+	conf, err := configuration.Load(*configFile)
+	if err == nil {
+		err = configuration.ApplyFlags(conf, flag.CommandLine, flags)
+	}
+	if err != nil {
+		fail(err)
+	}
+
+	args := flag.Args()
+	if len(args) == 0 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fail(fmt.Errorf("unknown command [%s]", args[0]))
+	}
+	if len(args)-1 < cmd.minArgs || len(args)-1 > cmd.maxArgs {
+		fail(fmt.Errorf("usage: stewardctl %s %s", args[0], cmd.usage))
+	}
+	if opts.output != "table" && opts.output != "json" {
+		fail(fmt.Errorf("unknown output format [%s]", opts.output))
+	}
+	if opts.endpoint > 0xff {
+		fail(fmt.Errorf("invalid endpoint [%d]", opts.endpoint))
+	}
+
+	s := &session{conf: conf, options: opts, registered: make(chan *model.Device, 10)}
+	if cmd.network {
+		stewie, err := steward.New(conf)
+		if err != nil {
+			fail(err)
+		}
+		s.stewie = stewie
+		go drain(s)
+		s.stewie.Start()
+	}
+	out, err := cmd.run(s, args)
+	if err != nil {
+		fail(err)
+	}
+	if err := render(out, opts.output); err != nil {
+		fail(err)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), "Usage: stewardctl [flags] <command> [args]\n\nCommands:\n")
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(flag.CommandLine.Output(), "  %s\n    \t%s\n", strings.TrimSpace(name+" "+commands[name].usage), commands[name].description)
+	}
+	fmt.Fprintf(flag.CommandLine.Output(), "\nFlags:\n")
+	flag.PrintDefaults()
+}
+
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "stewardctl: %s\n", err)
+	os.Exit(1)
+}
+
+// consume events so their channels never overflow. Registered devices are passed on to permit-join
+func drain(s *session) {
+	channels := s.stewie.Channels()
+	for {
+		select {
+		case device := <-channels.OnDeviceRegistered():
+			select {
+			case s.registered <- device:
+			default:
+			}
+		case <-channels.OnDeviceUnregistered():
+		case <-channels.OnDeviceBecameAvailable():
+		case <-channels.OnDeviceIncomingMessage():
+		case <-channels.OnDeviceZoneStatusChange():
+		case <-channels.OnDeviceLockOperationEvent():
+		case <-channels.OnDeviceLockProgrammingEvent():
+		case <-channels.OnDeviceOTAProgress():
+		case <-channels.OnDeviceEnergyMeasurement():
+		case <-channels.OnDeviceSensorReading():
+		case <-channels.OnDeviceStateChange():
+		case <-channels.OnDeviceClick():
+		case <-channels.OnDeviceTuyaReport():
+		case <-channels.OnDeviceInterviewProgress():
+		case <-channels.OnDeviceLinkQualityAlert():
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/dyrkin/zcl-go/cluster"
+	"github.com/dyrkin/zigbee-steward/coordinator"
+	"github.com/dyrkin/zigbee-steward/model"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+type output interface {
+	table(w io.Writer)
+}
+
+type deviceList []*model.Device
+
+type deviceDetails model.Device
+
+type attribute struct {
+	Id     uint16              `json:"id"`
+	Status cluster.ZclStatus   `json:"status"`
+	Type   cluster.ZclDataType `json:"type,omitempty"`
+	Value  interface{}         `json:"value,omitempty"`
+}
+
+type attributeList []*attribute
+
+type topologyOutput model.Topology
+
+type backupOutput coordinator.Backup
+
+func render(out output, format string) error {
+	if out == nil {
+		return nil
+	}
+	if format == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "    ")
+		return encoder.Encode(out)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	out.table(w)
+	return w.Flush()
+}
+
+func (devices deviceList) table(w io.Writer) {
+	fmt.Fprintln(w, "IEEE ADDRESS\tNETWORK ADDRESS\tMANUFACTURER\tMODEL\tLOGICAL TYPE\tPOWER SOURCE")
+	for _, device := range devices {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", device.IEEEAddress, device.NetworkAddress,
+			device.Manufacturer, device.Model, device.LogicalType, device.PowerSource)
+	}
+}
+
+func (device *deviceDetails) table(w io.Writer) {
+	fmt.Fprintf(w, "IEEE address:\t%s\n", device.IEEEAddress)
+	fmt.Fprintf(w, "Network address:\t%s\n", device.NetworkAddress)
+	fmt.Fprintf(w, "Manufacturer:\t%s [0x%04x]\n", device.Manufacturer, device.ManufacturerId)
+	fmt.Fprintf(w, "Model:\t%s\n", device.Model)
+	fmt.Fprintf(w, "Logical type:\t%s\n", device.LogicalType)
+	fmt.Fprintf(w, "Power source:\t%s\n", device.PowerSource)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "ENDPOINT\tPROFILE\tDEVICE\tIN CLUSTERS\tOUT CLUSTERS")
+	for _, endpoint := range device.Endpoints {
+		fmt.Fprintf(w, "%d\t0x%04x\t0x%04x\t%s\t%s\n", endpoint.Id, endpoint.ProfileId, endpoint.DeviceId,
+			clusterNames(endpoint.InClusterList), clusterNames(endpoint.OutClusterList))
+	}
+}
+
+func (attributes attributeList) table(w io.Writer) {
+	fmt.Fprintln(w, "ATTRIBUTE\tSTATUS\tTYPE\tVALUE")
+	for _, a := range attributes {
+		value := ""
+		if a.Value != nil {
+			value = fmt.Sprintf("%v", a.Value)
+		}
+		fmt.Fprintf(w, "0x%04x\t0x%02x\t0x%02x\t%s\n", a.Id, uint8(a.Status), uint8(a.Type), value)
+	}
+}
+
+func (topology *topologyOutput) table(w io.Writer) {
+	fmt.Fprintln(w, "SOURCE\tTARGET\tNETWORK ADDRESS\tRELATIONSHIP\tDEPTH\tLQI")
+	for _, link := range topology.Links {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%d\n", link.Source, link.Target, link.NetworkAddress,
+			link.Relationship, link.Depth, link.LinkQuality)
+	}
+	for _, ieeeAddress := range topology.Unreachable {
+		fmt.Fprintf(w, "%s\t<unreachable>\t\t\t\t\n", ieeeAddress)
+	}
+}
+
+func (backup *backupOutput) table(w io.Writer) {
+	fmt.Fprintf(w, "IEEE address:\t%s\n", backup.IEEEAddress)
+	fmt.Fprintf(w, "PAN ID:\t0x%04x\n", backup.PanId)
+	fmt.Fprintf(w, "Extended PAN ID:\t0x%016x\n", backup.ExtendedPanId)
+	fmt.Fprintf(w, "Channel:\t%d\n", backup.Channel)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "NV ITEM\tNAME\tVALUE")
+	for _, item := range backup.NvItems {
+		fmt.Fprintf(w, "0x%04x\t%s\t%s\n", item.Id, item.Name, item.Value)
+	}
+}
+
+func clusterNames(clusters []*model.Cluster) string {
+	var names []string
+	for _, c := range clusters {
+		if c.Supported {
+			names = append(names, c.Name)
+		} else {
+			names = append(names, fmt.Sprintf("0x%04x", c.Id))
+		}
+	}
+	return strings.Join(names, ",")
+}
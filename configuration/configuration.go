@@ -1,31 +1,93 @@
 package configuration
 
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
 type Serial struct {
-	PortName string
-	BaudRate int
+	PortName string `yaml:"port_name" json:"port_name"`
+	BaudRate int    `yaml:"baud_rate" json:"baud_rate"`
+}
+
+type OTA struct {
+	ImagesDirectory    string        `yaml:"images_directory" json:"images_directory"`
+	MinimumBlockPeriod time.Duration `yaml:"minimum_block_period" json:"minimum_block_period"`
 }
 
+// network key is written to files as 32 hex digits
+type NetworkKey [16]uint8
+
+type Channels []uint8
+
 type Configuration struct {
-	PermitJoin  bool
-	IEEEAddress string
-	PanId       uint16
-	NetworkKey  [16]uint8
-	Channels    []uint8
-	Led         bool
-	Serial      *Serial
+	PermitJoin bool `yaml:"permit_join" json:"permit_join"`
+	//IEEE address of the coordinator. The address burnt into the stick is used when empty
+	IEEEAddress          string     `yaml:"ieee_address" json:"ieee_address"`
+	PanId                uint16     `yaml:"pan_id" json:"pan_id"`
+	ExtendedPanId        uint64     `yaml:"extended_pan_id" json:"extended_pan_id"`
+	NetworkKey           NetworkKey `yaml:"network_key" json:"network_key"`
+	Channels             Channels   `yaml:"channels" json:"channels"`
+	Led                  bool       `yaml:"led" json:"led"`
+	Serial               *Serial    `yaml:"serial" json:"serial"`
+	OTA                  *OTA       `yaml:"ota" json:"ota"`
+	DefinitionsDirectory string     `yaml:"definitions_directory" json:"definitions_directory"`
+	//average link quality below which devices are reported as degraded. 0 disables alerts
+	LinkQualityThreshold uint8 `yaml:"link_quality_threshold" json:"link_quality_threshold"`
 }
 
+// network key, PAN ID and extended PAN ID are left empty. Load generates them on first run
 func Default() *Configuration {
 	return &Configuration{
-		PermitJoin:  false,
-		IEEEAddress: "0x7a2d6265656e6574",
-		PanId:       0x1234,
-		NetworkKey:  [16]uint8{4, 3, 2, 1, 9, 8, 7, 6, 255, 254, 253, 252, 50, 49, 48, 47},
-		Channels:    []uint8{11, 12},
-		Led:         false,
+		PermitJoin:    false,
+		IEEEAddress:   "",
+		PanId:         0,
+		ExtendedPanId: 0,
+		NetworkKey:    NetworkKey{},
+		Channels:      Channels{11},
+		Led:           false,
 		Serial: &Serial{
-			PortName: "/dev/tty.usbmodem14101",
+			PortName: "/dev/ttyACM0",
 			BaudRate: 115200,
 		},
+		OTA: &OTA{
+			ImagesDirectory:    "",
+			MinimumBlockPeriod: 100 * time.Millisecond,
+		},
+		DefinitionsDirectory: "",
+		LinkQualityThreshold: 50,
+	}
+}
+
+// written as a list of numbers instead of base64 which encoding/json uses for byte slices
+func (c Channels) MarshalJSON() ([]byte, error) {
+	channels := make([]int, len(c))
+	for i, channel := range c {
+		channels[i] = int(channel)
+	}
+	return json.Marshal(channels)
+}
+
+func (k NetworkKey) IsZero() bool {
+	return k == NetworkKey{}
+}
+
+func (k NetworkKey) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToString(k[:])), nil
+}
+
+func (k *NetworkKey) UnmarshalText(text []byte) error {
+	value := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(string(text))), "0x")
+	key, err := hex.DecodeString(value)
+	if err != nil {
+		return fmt.Errorf("invalid network key: %s", err)
+	}
+	if len(key) != len(k) {
+		return fmt.Errorf("invalid network key length [%d]. Expected [%d] bytes", len(key), len(k))
 	}
+	copy(k[:], key)
+	return nil
 }
@@ -0,0 +1,36 @@
+package configuration
+
+import "flag"
+
+// flags which override the configuration and the fields they set
+var flagFields = map[string]func(conf *Configuration, flags *Configuration){
+	"port": func(conf *Configuration, flags *Configuration) {
+		conf.Serial.PortName = flags.Serial.PortName
+	},
+	"baud-rate": func(conf *Configuration, flags *Configuration) {
+		conf.Serial.BaudRate = flags.Serial.BaudRate
+	},
+	"permit-join": func(conf *Configuration, flags *Configuration) {
+		conf.PermitJoin = flags.PermitJoin
+	},
+	"definitions": func(conf *Configuration, flags *Configuration) {
+		conf.DefinitionsDirectory = flags.DefinitionsDirectory
+	},
+}
+
+// BindFlags registers -port, -baud-rate and -definitions on the flag set. Commands which start the network may add -permit-join bound to flags.PermitJoin
+func BindFlags(flagSet *flag.FlagSet, flags *Configuration) {
+	flagSet.StringVar(&flags.Serial.PortName, "port", flags.Serial.PortName, "serial port of the ZigBee stick")
+	flagSet.IntVar(&flags.Serial.BaudRate, "baud-rate", flags.Serial.BaudRate, "baud rate of the serial port")
+	flagSet.StringVar(&flags.DefinitionsDirectory, "definitions", flags.DefinitionsDirectory, "directory with external device definitions")
+}
+
+// ApplyFlags copies the flags given on the command line over the loaded configuration and validates the result
+func ApplyFlags(conf *Configuration, flagSet *flag.FlagSet, flags *Configuration) error {
+	flagSet.Visit(func(f *flag.Flag) {
+		if apply, ok := flagFields[f.Name]; ok {
+			apply(conf, flags)
+		}
+	})
+	return conf.Validate()
+}
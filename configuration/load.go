@@ -0,0 +1,170 @@
+package configuration
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"github.com/dyrkin/zigbee-steward/logger"
+	"github.com/natefinch/atomic"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var log = logger.MustGetLogger("configuration")
+
+const envPrefix = "STEWARD_"
+
+// Load reads configuration from a YAML or JSON file (chosen by extension), applies STEWARD_* environment
+// overrides and validates the result. Network key, PAN ID and extended PAN ID missing from the file
+// are generated and written back, so the network survives restarts. An empty path skips the file
+func Load(path string) (*Configuration, error) {
+	config := Default()
+	if path != "" {
+		data, err := ioutil.ReadFile(path)
+		switch {
+		case os.IsNotExist(err):
+		case err != nil:
+			return nil, err
+		default:
+			if err := decode(path, data, config); err != nil {
+				return nil, fmt.Errorf("unable to parse configuration file [%s]: %s", path, err)
+			}
+		}
+	}
+	generated, err := generateNetwork(config)
+	if err != nil {
+		return nil, err
+	}
+	//environment overrides are not persisted, so the file is encoded before they are applied
+	data, err := encode(path, config)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyEnv(config); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if generated && path != "" {
+		if err := atomic.WriteFile(path, bytes.NewReader(data)); err != nil {
+			return nil, fmt.Errorf("unable to save configuration file [%s]: %s", path, err)
+		}
+		log.Infof("Generated network parameters are saved to [%s]", path)
+	}
+	return config, nil
+}
+
+func isJSON(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".json")
+}
+
+func decode(path string, data []byte, config *Configuration) error {
+	if isJSON(path) {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		return decoder.Decode(config)
+	}
+	return yaml.UnmarshalStrict(data, config)
+}
+
+func encode(path string, config *Configuration) ([]byte, error) {
+	if isJSON(path) {
+		return json.MarshalIndent(config, "", "  ")
+	}
+	return yaml.Marshal(config)
+}
+
+func generateNetwork(config *Configuration) (bool, error) {
+	generated := false
+	if config.NetworkKey.IsZero() {
+		if _, err := rand.Read(config.NetworkKey[:]); err != nil {
+			return false, err
+		}
+		generated = true
+	}
+	if config.PanId == 0 {
+		for config.PanId == 0 || config.PanId == 0xFFFF {
+			value, err := random(2)
+			if err != nil {
+				return false, err
+			}
+			config.PanId = uint16(value)
+		}
+		generated = true
+	}
+	if config.ExtendedPanId == 0 {
+		for config.ExtendedPanId == 0 || config.ExtendedPanId == 0xFFFFFFFFFFFFFFFF {
+			value, err := random(8)
+			if err != nil {
+				return false, err
+			}
+			config.ExtendedPanId = value
+		}
+		generated = true
+	}
+	return generated, nil
+}
+
+func random(size int) (uint64, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf[:size]); err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(buf), nil
+}
+
+func applyEnv(config *Configuration) error {
+	overrides := []struct {
+		name  string
+		apply func(value string) error
+	}{
+		{"SERIAL_PORT", func(value string) error { config.Serial.PortName = value; return nil }},
+		{"SERIAL_BAUD_RATE", func(value string) (err error) { config.Serial.BaudRate, err = strconv.Atoi(value); return }},
+		{"PERMIT_JOIN", func(value string) (err error) { config.PermitJoin, err = strconv.ParseBool(value); return }},
+		{"LED", func(value string) (err error) { config.Led, err = strconv.ParseBool(value); return }},
+		{"IEEE_ADDRESS", func(value string) error { config.IEEEAddress = value; return nil }},
+		{"PAN_ID", func(value string) error {
+			panId, err := strconv.ParseUint(value, 0, 16)
+			config.PanId = uint16(panId)
+			return err
+		}},
+		{"EXTENDED_PAN_ID", func(value string) (err error) { config.ExtendedPanId, err = strconv.ParseUint(value, 0, 64); return }},
+		{"NETWORK_KEY", func(value string) error { return config.NetworkKey.UnmarshalText([]byte(value)) }},
+		{"CHANNELS", func(value string) error {
+			var channels []uint8
+			for _, v := range strings.Split(value, ",") {
+				channel, err := strconv.ParseUint(strings.TrimSpace(v), 10, 8)
+				if err != nil {
+					return err
+				}
+				channels = append(channels, uint8(channel))
+			}
+			config.Channels = channels
+			return nil
+		}},
+		{"DEFINITIONS_DIRECTORY", func(value string) error { config.DefinitionsDirectory = value; return nil }},
+		{"OTA_IMAGES_DIRECTORY", func(value string) error { config.OTA.ImagesDirectory = value; return nil }},
+		{"LINK_QUALITY_THRESHOLD", func(value string) error {
+			threshold, err := strconv.ParseUint(value, 10, 8)
+			config.LinkQualityThreshold = uint8(threshold)
+			return err
+		}},
+	}
+	for _, override := range overrides {
+		value, ok := os.LookupEnv(envPrefix + override.name)
+		if !ok {
+			continue
+		}
+		if err := override.apply(value); err != nil {
+			return fmt.Errorf("invalid value [%s] of %s%s: %s", value, envPrefix, override.name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,245 @@
+package configuration
+
+import (
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const networkYAML = `
+pan_id: 6754
+extended_pan_id: 15813569212318741322
+network_key: 3f1a9c0d5be277a41c06f8e913d24b70
+`
+
+func valid() *Configuration {
+	config := Default()
+	config.PanId = 0x1a62
+	config.ExtendedPanId = 15813569212318741322
+	config.NetworkKey = NetworkKey{0x3f, 0x1a, 0x9c, 0x0d, 0x5b, 0xe2, 0x77, 0xa4, 0x1c, 0x06, 0xf8, 0xe9, 0x13, 0xd2, 0x4b, 0x70}
+	return config
+}
+
+func writeFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(config *Configuration)
+		wantErr string
+	}{
+		{"valid", func(config *Configuration) {}, ""},
+		{"ieee address", func(config *Configuration) { config.IEEEAddress = "0x00124b0001020304" }, ""},
+		{"no channels", func(config *Configuration) { config.Channels = nil }, "at least one channel"},
+		{"channel below range", func(config *Configuration) { config.Channels = Channels{10} }, "invalid channel [10]"},
+		{"channel above range", func(config *Configuration) { config.Channels = Channels{11, 27} }, "invalid channel [27]"},
+		{"zero PAN ID", func(config *Configuration) { config.PanId = 0 }, "invalid PAN ID"},
+		{"broadcast PAN ID", func(config *Configuration) { config.PanId = 0xFFFF }, "invalid PAN ID"},
+		{"broadcast extended PAN ID", func(config *Configuration) { config.ExtendedPanId = 0xFFFFFFFFFFFFFFFF }, "invalid extended PAN ID"},
+		{"zero network key", func(config *Configuration) { config.NetworkKey = NetworkKey{} }, "network key is not set"},
+		{"invalid ieee address", func(config *Configuration) { config.IEEEAddress = "x" }, "invalid IEEE address"},
+		{"no serial port", func(config *Configuration) { config.Serial.PortName = "" }, "serial port is not set"},
+		{"unsupported baud rate", func(config *Configuration) { config.Serial.BaudRate = 12345 }, "unsupported baud rate"},
+		{"no OTA", func(config *Configuration) { config.OTA = nil }, "OTA configuration is not set"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := valid()
+			test.modify(config)
+			err := config.Validate()
+			switch {
+			case test.wantErr == "" && err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			case test.wantErr != "" && (err == nil || !strings.Contains(err.Error(), test.wantErr)):
+				t.Fatalf("got error %v, want [%s]", err, test.wantErr)
+			}
+		})
+	}
+	if err := Default().Validate(); err == nil {
+		t.Error("default configuration without network parameters must not validate")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    func(config *Configuration)
+		wantErr bool
+	}{
+		{
+			name:    "yaml",
+			file:    "configuration.yaml",
+			content: networkYAML + "channels: [15, 20]\nserial:\n  port_name: /dev/ttyUSB0\n  baud_rate: 57600\n",
+			want: func(config *Configuration) {
+				config.Channels = Channels{15, 20}
+				config.Serial = &Serial{PortName: "/dev/ttyUSB0", BaudRate: 57600}
+			},
+		},
+		{
+			name:    "json",
+			file:    "configuration.json",
+			content: `{"pan_id": 6754, "extended_pan_id": 15813569212318741322, "network_key": "0x3f1a9c0d5be277a41c06f8e913d24b70", "channels": [25], "led": true}`,
+			want: func(config *Configuration) {
+				config.Channels = Channels{25}
+				config.Led = true
+			},
+		},
+		{"unknown yaml field", "configuration.yaml", networkYAML + "unknown: true\n", nil, true},
+		{"unknown json field", "configuration.json", `{"pan_id": 6754, "unknown": true}`, nil, true},
+		{"invalid network key", "configuration.yaml", "network_key: 3f1a\n", nil, true},
+		{"invalid channel", "configuration.yaml", networkYAML + "channels: [30]\n", nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config, err := Load(writeFile(t, test.file, test.content))
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", config)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := valid()
+			test.want(want)
+			if !reflect.DeepEqual(config, want) {
+				t.Errorf("got %+v, want %+v", config, want)
+			}
+		})
+	}
+}
+
+func TestLoadGeneratesNetwork(t *testing.T) {
+	for _, name := range []string{"configuration.yaml", "configuration.json"} {
+		path := filepath.Join(t.TempDir(), name)
+		generated, err := Load(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if generated.NetworkKey.IsZero() || generated.PanId == 0 || generated.ExtendedPanId == 0 {
+			t.Fatalf("%s: network parameters are not generated: %+v", name, generated)
+		}
+		loaded, err := Load(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(loaded, generated) {
+			t.Errorf("%s: got %+v after reload, want %+v", name, loaded, generated)
+		}
+	}
+}
+
+func TestLoadEnvOverrides(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    func(config *Configuration)
+		wantErr bool
+	}{
+		{
+			name: "serial",
+			env:  map[string]string{"STEWARD_SERIAL_PORT": "/dev/ttyUSB1", "STEWARD_SERIAL_BAUD_RATE": "230400"},
+			want: func(config *Configuration) { config.Serial = &Serial{PortName: "/dev/ttyUSB1", BaudRate: 230400} },
+		},
+		{
+			name: "network",
+			env: map[string]string{"STEWARD_PAN_ID": "0x1234", "STEWARD_EXTENDED_PAN_ID": "0x0011223344556677",
+				"STEWARD_NETWORK_KEY": "00112233445566778899aabbccddeeff", "STEWARD_CHANNELS": "11, 25"},
+			want: func(config *Configuration) {
+				config.PanId = 0x1234
+				config.ExtendedPanId = 0x0011223344556677
+				config.NetworkKey = NetworkKey{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}
+				config.Channels = Channels{11, 25}
+			},
+		},
+		{
+			name: "flags and directories",
+			env: map[string]string{"STEWARD_PERMIT_JOIN": "true", "STEWARD_LED": "1", "STEWARD_IEEE_ADDRESS": "0x00124b0001020304",
+				"STEWARD_DEFINITIONS_DIRECTORY": "/etc/steward", "STEWARD_OTA_IMAGES_DIRECTORY": "/var/ota", "STEWARD_LINK_QUALITY_THRESHOLD": "0"},
+			want: func(config *Configuration) {
+				config.PermitJoin = true
+				config.Led = true
+				config.IEEEAddress = "0x00124b0001020304"
+				config.DefinitionsDirectory = "/etc/steward"
+				config.OTA.ImagesDirectory = "/var/ota"
+				config.LinkQualityThreshold = 0
+			},
+		},
+		{name: "invalid baud rate", env: map[string]string{"STEWARD_SERIAL_BAUD_RATE": "fast"}, wantErr: true},
+		{name: "unsupported baud rate", env: map[string]string{"STEWARD_SERIAL_BAUD_RATE": "1000"}, wantErr: true},
+		{name: "PAN ID out of range", env: map[string]string{"STEWARD_PAN_ID": "0x10000"}, wantErr: true},
+		{name: "invalid channels", env: map[string]string{"STEWARD_CHANNELS": "11,x"}, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := writeFile(t, "configuration.yaml", networkYAML)
+			for name, value := range test.env {
+				t.Setenv(name, value)
+			}
+			config, err := Load(path)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", config)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := valid()
+			test.want(want)
+			if !reflect.DeepEqual(config, want) {
+				t.Errorf("got %+v, want %+v", config, want)
+			}
+			//overrides are not written to the file
+			content, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(content) != networkYAML {
+				t.Errorf("file changed to [%s]", content)
+			}
+		})
+	}
+}
+
+func TestApplyFlags(t *testing.T) {
+	flags := Default()
+	flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+	BindFlags(flagSet, flags)
+	flagSet.BoolVar(&flags.PermitJoin, "permit-join", flags.PermitJoin, "")
+	if err := flagSet.Parse([]string{"-port", "/dev/ttyUSB2", "-permit-join"}); err != nil {
+		t.Fatal(err)
+	}
+	config := valid()
+	config.Serial.BaudRate = 57600
+	config.DefinitionsDirectory = "/etc/steward"
+	if err := ApplyFlags(config, flagSet, flags); err != nil {
+		t.Fatal(err)
+	}
+	want := valid()
+	want.Serial = &Serial{PortName: "/dev/ttyUSB2", BaudRate: 57600}
+	want.DefinitionsDirectory = "/etc/steward"
+	want.PermitJoin = true
+	if !reflect.DeepEqual(config, want) {
+		t.Errorf("got %+v, want %+v", config, want)
+	}
+	if err := flagSet.Parse([]string{"-baud-rate", "1000"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ApplyFlags(config, flagSet, flags); err == nil {
+		t.Error("expected error for unsupported baud rate")
+	}
+}
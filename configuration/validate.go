@@ -0,0 +1,43 @@
+package configuration
+
+import (
+	"fmt"
+	"github.com/dyrkin/zigbee-steward/model"
+)
+
+var baudRates = map[int]bool{
+	9600: true, 19200: true, 38400: true, 57600: true, 115200: true, 230400: true, 460800: true, 921600: true,
+}
+
+func (c *Configuration) Validate() error {
+	if len(c.Channels) == 0 {
+		return fmt.Errorf("at least one channel must be configured")
+	}
+	for _, channel := range c.Channels {
+		if channel < 11 || channel > 26 {
+			return fmt.Errorf("invalid channel [%d]. Channels must be within 11-26", channel)
+		}
+	}
+	if c.PanId == 0 || c.PanId == 0xFFFF {
+		return fmt.Errorf("invalid PAN ID [0x%04x]", c.PanId)
+	}
+	if c.ExtendedPanId == 0xFFFFFFFFFFFFFFFF {
+		return fmt.Errorf("invalid extended PAN ID [0x%016x]", c.ExtendedPanId)
+	}
+	if c.NetworkKey.IsZero() {
+		return fmt.Errorf("network key is not set")
+	}
+	if c.IEEEAddress != "" && !model.IsIEEEAddress(c.IEEEAddress) {
+		return fmt.Errorf("invalid IEEE address [%s]. Expected 0x followed by 16 hex digits", c.IEEEAddress)
+	}
+	if c.Serial == nil || c.Serial.PortName == "" {
+		return fmt.Errorf("serial port is not set")
+	}
+	if !baudRates[c.Serial.BaudRate] {
+		return fmt.Errorf("unsupported baud rate [%d]", c.Serial.BaudRate)
+	}
+	if c.OTA == nil {
+		return fmt.Errorf("OTA configuration is not set")
+	}
+	return nil
+}
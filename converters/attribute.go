@@ -0,0 +1,72 @@
+package converters
+
+import (
+	"fmt"
+	"github.com/dyrkin/zcl-go/cluster"
+	"github.com/dyrkin/zigbee-steward/model"
+	"math"
+	"strconv"
+	"strings"
+)
+
+func AttributeValue(dataType cluster.ZclDataType, value interface{}) (interface{}, error) {
+	switch {
+	case dataType == cluster.ZclDataTypeBoolean:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			return strconv.ParseBool(v)
+		}
+	case dataType >= cluster.ZclDataTypeBitmap8 && dataType <= cluster.ZclDataTypeBitmap64,
+		dataType >= cluster.ZclDataTypeUint8 && dataType <= cluster.ZclDataTypeUint64,
+		dataType == cluster.ZclDataTypeEnum8 || dataType == cluster.ZclDataTypeEnum16:
+		return uintValue(value, 64)
+	case dataType >= cluster.ZclDataTypeInt8 && dataType <= cluster.ZclDataTypeInt64:
+		return intValue(value)
+	case dataType == cluster.ZclDataTypeUtc || dataType == cluster.ZclDataTypeBacOid:
+		v, err := uintValue(value, 32)
+		return uint32(v), err
+	case dataType == cluster.ZclDataTypeClusterId || dataType == cluster.ZclDataTypeAttrId:
+		v, err := uintValue(value, 16)
+		return uint16(v), err
+	case dataType == cluster.ZclDataTypeOctetStr || dataType == cluster.ZclDataTypeCharStr,
+		dataType == cluster.ZclDataTypeLongOctetStr || dataType == cluster.ZclDataTypeLongCharStr:
+		if v, ok := value.(string); ok {
+			return v, nil
+		}
+	case dataType == cluster.ZclDataTypeIeeeAddr:
+		if v, ok := value.(string); ok && model.IsIEEEAddress(v) {
+			return v, nil
+		}
+	default:
+		return nil, fmt.Errorf("unsupported data type [0x%02x]", uint8(dataType))
+	}
+	return nil, fmt.Errorf("invalid value [%v] for data type [0x%02x]", value, uint8(dataType))
+}
+
+func uintValue(value interface{}, bitSize int) (uint64, error) {
+	switch v := value.(type) {
+	case float64:
+		if v < 0 || v != math.Trunc(v) || v > math.MaxUint64 {
+			return 0, fmt.Errorf("invalid unsigned value [%v]", v)
+		}
+		return uint64(v), nil
+	case string:
+		return strconv.ParseUint(strings.TrimSpace(v), 0, bitSize)
+	}
+	return 0, fmt.Errorf("invalid unsigned value [%v]", value)
+}
+
+func intValue(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("invalid signed value [%v]", v)
+		}
+		return int64(v), nil
+	case string:
+		return strconv.ParseInt(strings.TrimSpace(v), 0, 64)
+	}
+	return 0, fmt.Errorf("invalid signed value [%v]", value)
+}
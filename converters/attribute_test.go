@@ -0,0 +1,45 @@
+package converters
+
+import (
+	"github.com/dyrkin/zcl-go/cluster"
+	"reflect"
+	"testing"
+)
+
+func TestAttributeValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		dataType cluster.ZclDataType
+		value    interface{}
+		want     interface{}
+		wantErr  bool
+	}{
+		{"boolean", cluster.ZclDataTypeBoolean, true, true, false},
+		{"boolean string", cluster.ZclDataTypeBoolean, "false", false, false},
+		{"char string", cluster.ZclDataTypeCharStr, "kitchen", "kitchen", false},
+		{"char string not a string", cluster.ZclDataTypeCharStr, 1.0, nil, true},
+		{"ieee address", cluster.ZclDataTypeIeeeAddr, "0x00124b0001020304", "0x00124b0001020304", false},
+		{"ieee address upper case", cluster.ZclDataTypeIeeeAddr, "0x00124B00AABBCCDD", "0x00124B00AABBCCDD", false},
+		{"ieee address too short", cluster.ZclDataTypeIeeeAddr, "x", nil, true},
+		{"ieee address without prefix", cluster.ZclDataTypeIeeeAddr, "00124b0001020304", nil, true},
+		{"ieee address not hex", cluster.ZclDataTypeIeeeAddr, "0x00124b000102030z", nil, true},
+		{"ieee address not a string", cluster.ZclDataTypeIeeeAddr, 1.0, nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := AttributeValue(test.dataType, test.value)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %#v, want %#v", got, test.want)
+			}
+		})
+	}
+}
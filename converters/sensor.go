@@ -0,0 +1,146 @@
+package converters
+
+import (
+	"github.com/dyrkin/zcl-go/cluster"
+	"github.com/dyrkin/zigbee-steward/clusters"
+	"github.com/dyrkin/zigbee-steward/model"
+	"math"
+)
+
+const (
+	invalidTemperature int64  = -0x8000
+	invalidHumidity    uint64 = 0xffff
+	invalidPressure    int64  = -0x8000
+	invalidIlluminance uint64 = 0xffff
+	invalidBattery     uint64 = 0xff
+)
+
+type SensorConverter func(reading *model.DeviceSensorReading, report *cluster.AttributeReport)
+
+var sensorConverters = map[cluster.ClusterId]SensorConverter{
+	cluster.PowerConfiguration:      powerConfiguration,
+	clusters.IlluminanceMeasurement: illuminanceMeasurement,
+	clusters.TemperatureMeasurement: temperatureMeasurement,
+	clusters.PressureMeasurement:    pressureMeasurement,
+	clusters.RelativeHumidity:       relativeHumidity,
+	clusters.OccupancySensing:       occupancySensing,
+}
+
+func SensorReading(device *model.Device, endpoint uint8, clusterId cluster.ClusterId, reports []*cluster.AttributeReport) (*model.DeviceSensorReading, bool) {
+	converter, ok := sensorConverters[clusterId]
+	if !ok {
+		return nil, false
+	}
+	reading := &model.DeviceSensorReading{Device: device, Endpoint: endpoint}
+	for _, report := range reports {
+		if report.Attribute != nil {
+			converter(reading, report)
+		}
+	}
+	return reading, !reading.Empty()
+}
+
+func Temperature(measuredValue int64) (float64, bool) {
+	if measuredValue == invalidTemperature {
+		return 0, false
+	}
+	return float64(measuredValue) / 100, true
+}
+
+func Humidity(measuredValue uint64) (float64, bool) {
+	if measuredValue == invalidHumidity {
+		return 0, false
+	}
+	return float64(measuredValue) / 100, true
+}
+
+func Pressure(measuredValue int64) (float64, bool) {
+	if measuredValue == invalidPressure {
+		return 0, false
+	}
+	return float64(measuredValue), true
+}
+
+func Illuminance(measuredValue uint64) (float64, bool) {
+	if measuredValue == invalidIlluminance {
+		return 0, false
+	}
+	if measuredValue == 0 {
+		return 0, true
+	}
+	return math.Pow(10, float64(measuredValue-1)/10000), true
+}
+
+func Occupied(occupancy uint64) bool {
+	return occupancy&0x01 > 0
+}
+
+func BatteryPercentage(percentageRemaining uint64) (float64, bool) {
+	if percentageRemaining == invalidBattery {
+		return 0, false
+	}
+	return float64(percentageRemaining) / 2, true
+}
+
+func BatteryVoltage(voltage uint64) (float64, bool) {
+	if voltage == invalidBattery {
+		return 0, false
+	}
+	return float64(voltage) / 10, true
+}
+
+func powerConfiguration(reading *model.DeviceSensorReading, report *cluster.AttributeReport) {
+	value, ok := report.Attribute.Value.(uint64)
+	if !ok {
+		return
+	}
+	switch report.AttributeID {
+	case 0x0020:
+		if voltage, ok := BatteryVoltage(value); ok {
+			reading.BatteryVoltage = &voltage
+		}
+	case 0x0021:
+		if percentage, ok := BatteryPercentage(value); ok {
+			reading.BatteryPercentage = &percentage
+		}
+	}
+}
+
+func illuminanceMeasurement(reading *model.DeviceSensorReading, report *cluster.AttributeReport) {
+	if value, ok := report.Attribute.Value.(uint64); ok && report.AttributeID == 0x0000 {
+		if illuminance, ok := Illuminance(value); ok {
+			reading.Illuminance = &illuminance
+		}
+	}
+}
+
+func temperatureMeasurement(reading *model.DeviceSensorReading, report *cluster.AttributeReport) {
+	if value, ok := report.Attribute.Value.(int64); ok && report.AttributeID == 0x0000 {
+		if temperature, ok := Temperature(value); ok {
+			reading.Temperature = &temperature
+		}
+	}
+}
+
+func pressureMeasurement(reading *model.DeviceSensorReading, report *cluster.AttributeReport) {
+	if value, ok := report.Attribute.Value.(int64); ok && report.AttributeID == 0x0000 {
+		if pressure, ok := Pressure(value); ok {
+			reading.Pressure = &pressure
+		}
+	}
+}
+
+func relativeHumidity(reading *model.DeviceSensorReading, report *cluster.AttributeReport) {
+	if value, ok := report.Attribute.Value.(uint64); ok && report.AttributeID == 0x0000 {
+		if humidity, ok := Humidity(value); ok {
+			reading.Humidity = &humidity
+		}
+	}
+}
+
+func occupancySensing(reading *model.DeviceSensorReading, report *cluster.AttributeReport) {
+	if value, ok := report.Attribute.Value.(uint64); ok && report.AttributeID == 0x0000 {
+		occupied := Occupied(value)
+		reading.Occupied = &occupied
+	}
+}
@@ -0,0 +1,272 @@
+package converters
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/dyrkin/zcl-go/cluster"
+	"github.com/dyrkin/zcl-go/frame"
+	"github.com/dyrkin/zigbee-steward/model"
+	"io"
+	"math"
+	"strings"
+)
+
+const (
+	XiaomiManufacturer                     = "LUMI"
+	XiaomiManufacturerId            uint16 = 0x115f
+	XiaomiTLVAttributeId            uint16 = 0xff01
+	XiaomiStructAttributeId         uint16 = 0xff02
+	xiaomiMultiClickAttributeId     uint16 = 0x8000
+	xiaomiPresentValueAttributeId   uint16 = 0x0055
+	xiaomiReportAttributesCommandId        = 0x0a
+)
+
+const (
+	xiaomiBatteryVoltage uint8 = 0x01
+	xiaomiTemperature    uint8 = 0x64
+	xiaomiHumidity       uint8 = 0x65
+	xiaomiPressure       uint8 = 0x66
+)
+
+const (
+	xiaomiMinimumBatteryVoltage = 2725
+	xiaomiMaximumBatteryVoltage = 3000
+	xiaomiStructBatteryVoltage  = 1
+)
+
+var xiaomiDefaultClicks = map[uint64]model.ClickType{
+	0:   model.ClickLong,
+	1:   model.ClickSingle,
+	2:   model.ClickDouble,
+	255: model.ClickRelease,
+}
+
+var xiaomiClicks = map[string]map[uint64]model.ClickType{
+	"lumi.sensor_switch.aq3": {
+		1:  model.ClickSingle,
+		2:  model.ClickDouble,
+		16: model.ClickLong,
+		17: model.ClickRelease,
+		18: model.ClickShake,
+	},
+}
+
+var xiaomiMultiClicks = map[uint64]model.ClickType{
+	1: model.ClickSingle,
+	2: model.ClickDouble,
+	3: model.ClickTriple,
+	4: model.ClickQuadruple,
+}
+
+var xiaomiWeatherModels = map[string]bool{
+	"lumi.weather":   true,
+	"lumi.sensor_ht": true,
+	"lumi.sens":      true,
+}
+
+func IsXiaomi(device *model.Device) bool {
+	return device.ManufacturerId == XiaomiManufacturerId || trimModel(device.Manufacturer) == XiaomiManufacturer
+}
+
+func XiaomiBasicReport(data []uint8) (map[uint8]*cluster.Attribute, error) {
+	f := frame.Decode(data)
+	if f.FrameControl.FrameType != frame.FrameTypeGlobal || f.CommandIdentifier != xiaomiReportAttributesCommandId {
+		return nil, fmt.Errorf("not an attribute report")
+	}
+	values := map[uint8]*cluster.Attribute{}
+	r := bytes.NewReader(f.Payload)
+	for r.Len() > 0 {
+		var attributeId uint16
+		if err := binary.Read(r, binary.LittleEndian, &attributeId); err != nil {
+			return nil, err
+		}
+		dataType, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case attributeId == XiaomiStructAttributeId && cluster.ZclDataType(dataType) == cluster.ZclDataTypeStruct:
+			elements, err := readXiaomiStruct(r)
+			if err != nil {
+				return nil, err
+			}
+			if len(elements) > xiaomiStructBatteryVoltage {
+				values[xiaomiBatteryVoltage] = elements[xiaomiStructBatteryVoltage]
+			}
+		default:
+			value, err := readXiaomiValue(r, cluster.ZclDataType(dataType))
+			if err != nil {
+				return nil, err
+			}
+			if tlv, ok := value.(string); ok && attributeId == XiaomiTLVAttributeId {
+				tlvValues, err := XiaomiTLV([]uint8(tlv))
+				if err != nil {
+					return nil, err
+				}
+				for tag, attribute := range tlvValues {
+					values[tag] = attribute
+				}
+			}
+		}
+	}
+	return values, nil
+}
+
+func XiaomiTLV(data []uint8) (map[uint8]*cluster.Attribute, error) {
+	values := map[uint8]*cluster.Attribute{}
+	r := bytes.NewReader(data)
+	for r.Len() > 0 {
+		tag, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		dataType, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		value, err := readXiaomiValue(r, cluster.ZclDataType(dataType))
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode tag [0x%02x]: %s", tag, err)
+		}
+		values[tag] = &cluster.Attribute{DataType: cluster.ZclDataType(dataType), Value: value}
+	}
+	return values, nil
+}
+
+func XiaomiReading(device *model.Device, endpoint uint8, values map[uint8]*cluster.Attribute) (*model.DeviceSensorReading, bool) {
+	reading := &model.DeviceSensorReading{Device: device, Endpoint: endpoint}
+	if attribute, ok := values[xiaomiBatteryVoltage]; ok {
+		if millivolts, ok := attribute.Value.(uint64); ok {
+			voltage := float64(millivolts) / 1000
+			percentage := XiaomiBatteryPercentage(millivolts)
+			reading.BatteryVoltage = &voltage
+			reading.BatteryPercentage = &percentage
+		}
+	}
+	if xiaomiWeatherModels[trimModel(device.Model)] {
+		if attribute, ok := values[xiaomiTemperature]; ok {
+			if value, ok := attribute.Value.(int64); ok {
+				if temperature, ok := Temperature(value); ok {
+					reading.Temperature = &temperature
+				}
+			}
+		}
+		if attribute, ok := values[xiaomiHumidity]; ok {
+			if value, ok := attribute.Value.(uint64); ok {
+				if humidity, ok := Humidity(value); ok {
+					reading.Humidity = &humidity
+				}
+			}
+		}
+		if attribute, ok := values[xiaomiPressure]; ok {
+			if value, ok := attribute.Value.(int64); ok {
+				pressure := float64(value) / 100
+				reading.Pressure = &pressure
+			}
+		}
+	}
+	return reading, !reading.Empty()
+}
+
+func XiaomiBatteryPercentage(millivolts uint64) float64 {
+	percentage := (float64(millivolts) - xiaomiMinimumBatteryVoltage) * 100 /
+		(xiaomiMaximumBatteryVoltage - xiaomiMinimumBatteryVoltage)
+	return math.Max(0, math.Min(100, math.Round(percentage)))
+}
+
+func XiaomiClick(device *model.Device, clusterId cluster.ClusterId, report *cluster.AttributeReport) (model.ClickType, bool) {
+	if report.Attribute == nil {
+		return 0, false
+	}
+	value, ok := report.Attribute.Value.(uint64)
+	if !ok {
+		return 0, false
+	}
+	switch {
+	case clusterId == cluster.MultistateInput && report.AttributeID == xiaomiPresentValueAttributeId:
+		clicks, ok := xiaomiClicks[trimModel(device.Model)]
+		if !ok {
+			clicks = xiaomiDefaultClicks
+		}
+		click, ok := clicks[value]
+		return click, ok
+	case clusterId == cluster.OnOff && report.AttributeID == xiaomiMultiClickAttributeId:
+		click, ok := xiaomiMultiClicks[value]
+		return click, ok
+	}
+	return 0, false
+}
+
+func readXiaomiStruct(r *bytes.Reader) ([]*cluster.Attribute, error) {
+	var count uint16
+	if err := binary.Read(r, binary.LittleEndian, &count); err != nil {
+		return nil, err
+	}
+	var elements []*cluster.Attribute
+	for i := 0; i < int(count); i++ {
+		dataType, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		value, err := readXiaomiValue(r, cluster.ZclDataType(dataType))
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode struct element [%d]: %s", i, err)
+		}
+		elements = append(elements, &cluster.Attribute{DataType: cluster.ZclDataType(dataType), Value: value})
+	}
+	return elements, nil
+}
+
+func readXiaomiValue(r *bytes.Reader, dataType cluster.ZclDataType) (interface{}, error) {
+	switch {
+	case dataType == cluster.ZclDataTypeBoolean:
+		b, err := r.ReadByte()
+		return b > 0, err
+	case dataType >= cluster.ZclDataTypeData8 && dataType <= cluster.ZclDataTypeData64:
+		return readXiaomiUint(r, int(dataType-cluster.ZclDataTypeData8)+1)
+	case dataType >= cluster.ZclDataTypeBitmap8 && dataType <= cluster.ZclDataTypeBitmap64:
+		return readXiaomiUint(r, int(dataType-cluster.ZclDataTypeBitmap8)+1)
+	case dataType >= cluster.ZclDataTypeUint8 && dataType <= cluster.ZclDataTypeUint64:
+		return readXiaomiUint(r, int(dataType-cluster.ZclDataTypeUint8)+1)
+	case dataType >= cluster.ZclDataTypeInt8 && dataType <= cluster.ZclDataTypeInt64:
+		size := int(dataType-cluster.ZclDataTypeInt8) + 1
+		value, err := readXiaomiUint(r, size)
+		if err != nil {
+			return nil, err
+		}
+		shift := uint(64 - size*8)
+		return int64(value<<shift) >> shift, nil
+	case dataType == cluster.ZclDataTypeEnum8 || dataType == cluster.ZclDataTypeEnum16:
+		return readXiaomiUint(r, int(dataType-cluster.ZclDataTypeEnum8)+1)
+	case dataType == cluster.ZclDataTypeSinglePrec:
+		value, err := readXiaomiUint(r, 4)
+		return float64(math.Float32frombits(uint32(value))), err
+	case dataType == cluster.ZclDataTypeDoublePrec:
+		value, err := readXiaomiUint(r, 8)
+		return math.Float64frombits(value), err
+	case dataType == cluster.ZclDataTypeOctetStr || dataType == cluster.ZclDataTypeCharStr:
+		size, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]uint8, size)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		return string(buf), nil
+	}
+	return nil, fmt.Errorf("unsupported data type [0x%02x]", uint8(dataType))
+}
+
+func readXiaomiUint(r *bytes.Reader, size int) (uint64, error) {
+	buf := make([]uint8, 8)
+	if _, err := io.ReadFull(r, buf[:size]); err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(buf), nil
+}
+
+func trimModel(value string) string {
+	return strings.TrimSpace(strings.TrimRight(value, "\x00"))
+}
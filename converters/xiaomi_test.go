@@ -0,0 +1,136 @@
+package converters
+
+import (
+	"github.com/dyrkin/zcl-go/cluster"
+	"github.com/dyrkin/zigbee-steward/model"
+	"reflect"
+	"testing"
+)
+
+func TestXiaomiTLV(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []uint8
+		want    map[uint8]*cluster.Attribute
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			data: []uint8{},
+			want: map[uint8]*cluster.Attribute{},
+		},
+		{
+			name: "weather sensor",
+			data: []uint8{
+				0x01, 0x21, 0xd1, 0x0b, //battery 3025 mV
+				0x64, 0x29, 0x2e, 0x09, //temperature 23.50
+				0x65, 0x21, 0x5c, 0x12, //humidity 47.00
+				0x66, 0x2b, 0x9c, 0x8a, 0x01, 0x00, //pressure 1010.20 hPa
+				0x0a, 0x10, 0x01, //boolean
+			},
+			want: map[uint8]*cluster.Attribute{
+				0x01: {DataType: cluster.ZclDataTypeUint16, Value: uint64(3025)},
+				0x64: {DataType: cluster.ZclDataTypeInt16, Value: int64(2350)},
+				0x65: {DataType: cluster.ZclDataTypeUint16, Value: uint64(4700)},
+				0x66: {DataType: cluster.ZclDataTypeInt32, Value: int64(101020)},
+				0x0a: {DataType: cluster.ZclDataTypeBoolean, Value: true},
+			},
+		},
+		{
+			name: "negative temperature",
+			data: []uint8{0x64, 0x29, 0x0c, 0xfe},
+			want: map[uint8]*cluster.Attribute{
+				0x64: {DataType: cluster.ZclDataTypeInt16, Value: int64(-500)},
+			},
+		},
+		{
+			name: "string",
+			data: []uint8{0x05, 0x42, 0x02, 'o', 'k'},
+			want: map[uint8]*cluster.Attribute{
+				0x05: {DataType: cluster.ZclDataTypeCharStr, Value: "ok"},
+			},
+		},
+		{
+			name:    "truncated value",
+			data:    []uint8{0x01, 0x21, 0xd1},
+			wantErr: true,
+		},
+		{
+			name:    "missing data type",
+			data:    []uint8{0x01},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported data type",
+			data:    []uint8{0x01, 0xe2, 0x00},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := XiaomiTLV(test.data)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				for tag, attribute := range got {
+					t.Errorf("tag [0x%02x]: got %+v, want %+v", tag, attribute, test.want[tag])
+				}
+			}
+		})
+	}
+}
+
+func TestXiaomiBatteryPercentage(t *testing.T) {
+	tests := []struct {
+		millivolts uint64
+		want       float64
+	}{
+		{2500, 0},
+		{2725, 0},
+		{2862, 50},
+		{3000, 100},
+		{3200, 100},
+	}
+	for _, test := range tests {
+		if got := XiaomiBatteryPercentage(test.millivolts); got != test.want {
+			t.Errorf("%d mV: got %v, want %v", test.millivolts, got, test.want)
+		}
+	}
+}
+
+func TestXiaomiClick(t *testing.T) {
+	tests := []struct {
+		name        string
+		model       string
+		clusterId   cluster.ClusterId
+		attributeId uint16
+		value       interface{}
+		want        model.ClickType
+		wantOk      bool
+	}{
+		{"default single", "lumi.sensor_switch", cluster.MultistateInput, 0x0055, uint64(1), model.ClickSingle, true},
+		{"default release", "lumi.sensor_switch", cluster.MultistateInput, 0x0055, uint64(255), model.ClickRelease, true},
+		{"model specific shake", "lumi.sensor_switch.aq3\x00", cluster.MultistateInput, 0x0055, uint64(18), model.ClickShake, true},
+		{"multi click", "lumi.sensor_switch", cluster.OnOff, 0x8000, uint64(3), model.ClickTriple, true},
+		{"unknown value", "lumi.sensor_switch", cluster.OnOff, 0x8000, uint64(9), 0, false},
+		{"other attribute", "lumi.sensor_switch", cluster.OnOff, 0x0000, uint64(1), 0, false},
+		{"non numeric", "lumi.sensor_switch", cluster.MultistateInput, 0x0055, "1", 0, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			device := &model.Device{Model: test.model}
+			report := &cluster.AttributeReport{AttributeID: test.attributeId, Attribute: &cluster.Attribute{Value: test.value}}
+			got, ok := XiaomiClick(device, test.clusterId, report)
+			if got != test.want || ok != test.wantOk {
+				t.Errorf("got %v, %t, want %v, %t", got, ok, test.want, test.wantOk)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package coordinator
+
+import (
+	"encoding/hex"
+	"fmt"
+	"github.com/dyrkin/unp-go"
+	"github.com/dyrkin/znp-go"
+	"sort"
+)
+
+const maxNvReadLength = 240
+
+// the read offset is a single byte, so the tail of longer items can't be addressed
+const maxNvItemLength = 0xff + maxNvReadLength
+
+// non-volatile items which define the network. Restoring them onto a fresh stick brings the network back
+var backupNvItems = map[uint16]string{
+	0x0001: "EXTADDR",
+	0x0021: "NIB",
+	0x002D: "EXTENDED_PAN_ID",
+	0x003A: "NWK_ACTIVE_KEY_INFO",
+	0x003B: "NWK_ALTERN_KEY_INFO",
+	0x0047: "APS_USE_EXT_PANID",
+	0x0062: "PRECFGKEY",
+	0x0063: "PRECFGKEYS_ENABLE",
+	0x0075: "LEGACY_NWK_SEC_MATERIAL_TABLE_START",
+	0x0083: "PANID",
+	0x0084: "CHANLIST",
+	0x0101: "TCLK_TABLE_START",
+}
+
+type Backup struct {
+	IEEEAddress   string
+	PanId         uint16
+	ExtendedPanId uint64
+	Channel       uint16
+	NvItems       []*NvItem
+}
+
+type NvItem struct {
+	Id    uint16
+	Name  string
+	Value string
+}
+
+func (c *Coordinator) Backup() (*Backup, error) {
+	np := c.networkProcessor
+	deviceInfo, err := np.UtilGetDeviceInfo()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read device info: %s", err)
+	}
+	networkInfo, err := np.ZdoExtNwkInfo()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read network info: %s", err)
+	}
+	backup := &Backup{
+		IEEEAddress:   deviceInfo.IEEEAddr,
+		PanId:         networkInfo.PanID,
+		ExtendedPanId: networkInfo.ExtendedPanID,
+		Channel:       networkInfo.Channel,
+	}
+	for _, id := range sortedNvItemIds() {
+		value, err := c.readNvItem(id)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read NV item [%s]: %s", backupNvItems[id], err)
+		}
+		if value == nil {
+			log.Debugf("NV item [%s] doesn't exist. Skipping", backupNvItems[id])
+			continue
+		}
+		backup.NvItems = append(backup.NvItems, &NvItem{Id: id, Name: backupNvItems[id], Value: hex.EncodeToString(value)})
+	}
+	return backup, nil
+}
+
+func (c *Coordinator) readNvItem(id uint16) ([]uint8, error) {
+	np := c.networkProcessor
+	length, err := np.SysOsalNvLength(id)
+	if err != nil {
+		return nil, err
+	}
+	if length.Length == 0 {
+		return nil, nil
+	}
+	if int(length.Length) > maxNvItemLength {
+		return nil, fmt.Errorf("item is too long: [%d] bytes. At most [%d] bytes can be read", length.Length, maxNvItemLength)
+	}
+	value := make([]uint8, 0, length.Length)
+	for len(value) < int(length.Length) {
+		offset := len(value)
+		if offset > 0xff {
+			return nil, fmt.Errorf("unable to read item past offset [%d] of [%d] bytes", offset, length.Length)
+		}
+		//SysOsalNvRead of znp-go drops the value, so the request is sent directly
+		request := &znp.SysOsalNvRead{ID: id, Offset: uint8(offset)}
+		var response *znp.SysOsalNvReadResponse
+		if err := np.ProcessRequest(unp.C_SREQ, unp.S_SYS, 0x08, request, &response); err != nil {
+			return nil, err
+		}
+		if response.Status != znp.StatusSuccess {
+			return nil, fmt.Errorf("invalid status: [%s]", response.Status)
+		}
+		if len(response.Value) == 0 {
+			break
+		}
+		value = append(value, response.Value...)
+	}
+	if len(value) > int(length.Length) {
+		value = value[:length.Length]
+	}
+	return value, nil
+}
+
+func sortedNvItemIds() []uint16 {
+	var ids []uint16
+	for id := range backupNvItems {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+	return ids
+}
@@ -1,6 +1,8 @@
 package coordinator
 
 import (
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"github.com/dyrkin/zcl-go/frame"
 	"github.com/tv42/topic"
@@ -12,6 +14,7 @@ import (
 	"github.com/dyrkin/unp-go"
 	"github.com/dyrkin/zigbee-steward/configuration"
 	"github.com/dyrkin/zigbee-steward/logger"
+	"github.com/dyrkin/zigbee-steward/metrics"
 	"github.com/dyrkin/znp-go"
 	"go.bug.st/serial.v1"
 )
@@ -22,10 +25,41 @@ var nextTransactionId = frame.MakeDefaultTransactionIdProvider()
 
 const defaultTimeout = 10 * time.Second
 
+// DeliveryError is returned when the network processor confirms a data request with a non-success status
+type DeliveryError struct {
+	Status znp.Status
+}
+
+func (e *DeliveryError) Error() string {
+	return fmt.Sprintf("invalid transaction status: [%s]", e.Status)
+}
+
+// IsRouteFailure reports whether the error means the device can't be reached at the network address used
+func IsRouteFailure(err error) bool {
+	var deliveryError *DeliveryError
+	if !errors.As(err, &deliveryError) {
+		return false
+	}
+	switch deliveryError.Status {
+	case znp.StatusNwkNoRoute, znp.StatusNwkUnknownDevice, znp.StatusMacNoACK, znp.StatusApsNoAck:
+		return true
+	}
+	return false
+}
+
 type Network struct {
 	Address string
 }
 
+type Endpoint struct {
+	Id             uint8
+	ProfileId      uint16
+	DeviceId       uint16
+	DeviceVersion  uint8
+	InClusterList  []uint16
+	OutClusterList []uint16
+}
+
 type MessageChannels struct {
 	onError           chan error
 	onDeviceAnnounce  chan *znp.ZdoEndDeviceAnnceInd
@@ -41,6 +75,7 @@ type Coordinator struct {
 	messageChannels  *MessageChannels
 	network          *Network
 	broadcast        *topic.Topic
+	endpoints        []*Endpoint
 }
 
 func (c *Coordinator) OnIncomingMessage() chan *znp.AfIncomingMessage {
@@ -80,9 +115,18 @@ func New(config *configuration.Configuration) *Coordinator {
 		messageChannels: messageChannels,
 		network:         &Network{},
 		broadcast:       topic.New(),
+		endpoints:       defaultEndpoints(),
 	}
 }
 
+func (c *Coordinator) Endpoints() []*Endpoint {
+	return c.endpoints
+}
+
+func (c *Coordinator) SetEndpoints(endpoints []*Endpoint) {
+	c.endpoints = endpoints
+}
+
 func (c *Coordinator) Start() error {
 	log.Info("Starting coordinator...")
 	port, err := openPort(c.config)
@@ -167,6 +211,65 @@ func (c *Coordinator) SimpleDescription(nwkAddress string, endpoint uint8) (*znp
 	return nil, err
 }
 
+func (c *Coordinator) NetworkAddress(ieeeAddress string) (*znp.ZdoNwkAddrRsp, error) {
+	np := c.networkProcessor
+	nwkAddrReq := func() error {
+		status, err := np.ZdoNwkAddrReq(ieeeAddress, znp.ReqTypeSingleDeviceResponse, 0)
+		if err == nil && status.Status != znp.StatusSuccess {
+			return fmt.Errorf("unable to request network address. Status: [%s]", status.Status)
+		}
+		return err
+	}
+
+	response, err := c.syncCallRetryable(nwkAddrReq, ZdoNwkAddrRspType, defaultTimeout, 3)
+	if err == nil {
+		return response.(*znp.ZdoNwkAddrRsp), nil
+	}
+	return nil, err
+}
+
+func (c *Coordinator) Leave(nwkAddress string, ieeeAddress string) (*znp.ZdoMgmtLeaveRsp, error) {
+	np := c.networkProcessor
+	leaveReq := func() error {
+		status, err := np.ZdoMgmtLeaveReq(nwkAddress, ieeeAddress, &znp.RemoveChildrenRejoin{})
+		if err == nil && status.Status != znp.StatusSuccess {
+			return fmt.Errorf("unable to request leave. Status: [%s]", status.Status)
+		}
+		return err
+	}
+
+	response, err := c.syncCallRetryable(leaveReq, ZdoMgmtLeaveRspType, defaultTimeout, 3)
+	if err == nil {
+		return response.(*znp.ZdoMgmtLeaveRsp), nil
+	}
+	return nil, err
+}
+
+func (c *Coordinator) Lqi(nwkAddress string, startIndex uint8) (*znp.ZdoMgmtLqiRsp, error) {
+	np := c.networkProcessor
+	lqiReq := func() error {
+		status, err := np.ZdoMgmtLqiReq(nwkAddress, startIndex)
+		if err == nil && status.Status != znp.StatusSuccess {
+			return fmt.Errorf("unable to request neighbor table. Status: [%s]", status.Status)
+		}
+		return err
+	}
+
+	response, err := c.syncCallRetryable(lqiReq, ZdoMgmtLqiRspType, defaultTimeout, 3)
+	if err == nil {
+		return response.(*znp.ZdoMgmtLqiRsp), nil
+	}
+	return nil, err
+}
+
+func (c *Coordinator) PermitJoin(timeout uint8) error {
+	status, err := c.networkProcessor.SapiZbPermitJoiningRequest(c.network.Address, timeout)
+	if err == nil && status.Status != znp.StatusSuccess {
+		return fmt.Errorf("unable to permit join. Status: [%s]", status.Status)
+	}
+	return err
+}
+
 func (c *Coordinator) Bind(dstAddr string, srcAddress string, srcEndpoint uint8, clusterId uint16,
 	dstAddrMode znp.AddrMode, dstAddress string, dstEndpoint uint8) (*znp.ZdoBindRsp, error) {
 	np := c.networkProcessor
@@ -204,16 +307,30 @@ func (c *Coordinator) Unbind(dstAddr string, srcAddress string, srcEndpoint uint
 }
 
 func (c *Coordinator) DataRequest(dstAddr string, dstEndpoint uint8, srcEndpoint uint8, clusterId uint16, options *znp.AfDataRequestOptions, radius uint8, data []uint8) (*znp.AfIncomingMessage, error) {
+	dataRequest := c.dataRequest(dstEndpoint, srcEndpoint, clusterId, options, radius, data)
+	started := time.Now()
+	response, err := c.syncDataRetryable(dataRequest, dstAddr, clusterId, nextTransactionId(), true, defaultTimeout, 3)
+	metrics.ObserveDataRequest(clusterId, started, err)
+	return response, err
+}
+
+func (c *Coordinator) DataRequestNoResponse(dstAddr string, dstEndpoint uint8, srcEndpoint uint8, clusterId uint16, options *znp.AfDataRequestOptions, radius uint8, data []uint8) error {
+	dataRequest := c.dataRequest(dstEndpoint, srcEndpoint, clusterId, options, radius, data)
+	started := time.Now()
+	_, err := c.syncDataRetryable(dataRequest, dstAddr, clusterId, nextTransactionId(), false, defaultTimeout, 3)
+	metrics.ObserveDataRequest(clusterId, started, err)
+	return err
+}
+
+func (c *Coordinator) dataRequest(dstEndpoint uint8, srcEndpoint uint8, clusterId uint16, options *znp.AfDataRequestOptions, radius uint8, data []uint8) func(string, uint8) error {
 	np := c.networkProcessor
-	dataRequest := func(networkAddress string, transactionId uint8) error {
+	return func(networkAddress string, transactionId uint8) error {
 		status, err := np.AfDataRequest(networkAddress, dstEndpoint, srcEndpoint, clusterId, transactionId, options, radius, data)
 		if err == nil && status.Status != znp.StatusSuccess {
-			return fmt.Errorf("unable to unbind. Status: [%s]", status.Status)
+			return fmt.Errorf("unable to send data. Status: [%s]", status.Status)
 		}
 		return err
 	}
-
-	return c.syncDataRequestRetryable(dataRequest, dstAddr, nextTransactionId(), defaultTimeout, 3)
 }
 
 func (c *Coordinator) syncCall(call func() error, expectedType reflect.Type, timeout time.Duration) (interface{}, error) {
@@ -272,12 +389,14 @@ func (c *Coordinator) syncCallRetryable(call func() error, expectedType reflect.
 	return response, nil
 }
 
-func (c *Coordinator) syncDataRequestRetryable(request func(string, uint8) error, nwkAddress string, transactionId uint8, timeout time.Duration, retries int) (*znp.AfIncomingMessage, error) {
-	incomingMessage, err := c.syncDataRequest(request, nwkAddress, transactionId, timeout)
+func (c *Coordinator) syncDataRetryable(request func(string, uint8) error, nwkAddress string, clusterId uint16, transactionId uint8, awaitResponse bool, timeout time.Duration, retries int) (*znp.AfIncomingMessage, error) {
+	log := log.With(logger.FieldNwk, nwkAddress, logger.FieldCluster, clusterId, logger.FieldTsn, transactionId)
+	incomingMessage, err := c.syncData(request, nwkAddress, clusterId, transactionId, awaitResponse, timeout)
 	switch {
 	case err != nil && retries > 0:
 		log.Errorf("%s. Retries: %d", err, retries)
-		return c.syncDataRequestRetryable(request, nwkAddress, transactionId, timeout, retries-1)
+		metrics.DataRequestRetry(clusterId)
+		return c.syncDataRetryable(request, nwkAddress, clusterId, transactionId, awaitResponse, timeout, retries-1)
 	case err != nil && retries == 0:
 		log.Errorf("failure: %s", err)
 		return nil, err
@@ -285,66 +404,37 @@ func (c *Coordinator) syncDataRequestRetryable(request func(string, uint8) error
 	return incomingMessage, nil
 }
 
-func (c *Coordinator) syncDataRequest(request func(string, uint8) error, nwkAddress string, transactionId uint8, timeout time.Duration) (*znp.AfIncomingMessage, error) {
+// syncData sends the data request and waits for its confirmation. When awaitResponse is set, it also waits for the
+// response to the transaction, otherwise the returned message is nil
+func (c *Coordinator) syncData(request func(string, uint8) error, nwkAddress string, clusterId uint16, transactionId uint8, awaitResponse bool, timeout time.Duration) (*znp.AfIncomingMessage, error) {
 	messageReceiver := make(chan interface{})
 
 	responseChannel := make(chan *znp.AfIncomingMessage, 1)
 	errorChannel := make(chan error, 1)
 
-	incomingMessageListener := func() {
-		deadline := time.NewTimer(timeout)
-		for {
-			select {
-			case response := <-messageReceiver:
-				if incomingMessage, ok := response.(*znp.AfIncomingMessage); ok {
-					frm := frame.Decode(incomingMessage.Data)
-					if (frm.TransactionSequenceNumber == transactionId) &&
-						(incomingMessage.SrcAddr == nwkAddress) {
-						deadline.Stop()
-						responseChannel <- incomingMessage
-						return
-					}
-				}
-			case _ = <-deadline.C:
-				if !deadline.Stop() {
-					errorChannel <- fmt.Errorf("timeout. didn't receive response for transcation: %d", transactionId)
-				}
-				return
-			}
-		}
-	}
-
 	var wg sync.WaitGroup
 	wg.Add(1)
 
-	confirmListener := func() {
+	listener := func() {
 		deadline := time.NewTimer(timeout)
 		c.broadcast.Register(messageReceiver)
 		wg.Done()
-		for {
-			select {
-			case response := <-messageReceiver:
-				if dataConfirm, ok := response.(*znp.AfDataConfirm); ok {
-					if dataConfirm.TransID == transactionId {
-						deadline.Stop()
-						switch dataConfirm.Status {
-						case znp.StatusSuccess:
-							go incomingMessageListener()
-						default:
-							errorChannel <- fmt.Errorf("invalid transcation status: [%s]", dataConfirm.Status)
-						}
-						return
-					}
-				}
-			case _ = <-deadline.C:
-				if !deadline.Stop() {
-					errorChannel <- fmt.Errorf("timeout. didn't receive confiramtion for transcation: %d", transactionId)
-				}
-				return
-			}
+		if err := awaitDataConfirm(messageReceiver, deadline, clusterId, transactionId); err != nil {
+			errorChannel <- err
+			return
 		}
+		if !awaitResponse {
+			responseChannel <- nil
+			return
+		}
+		incomingMessage, err := awaitIncomingMessage(messageReceiver, time.NewTimer(timeout), nwkAddress, clusterId, transactionId)
+		if err != nil {
+			errorChannel <- err
+			return
+		}
+		responseChannel <- incomingMessage
 	}
-	go confirmListener()
+	go listener()
 	wg.Wait()
 	err := request(nwkAddress, transactionId)
 
@@ -357,9 +447,49 @@ func (c *Coordinator) syncDataRequest(request func(string, uint8) error, nwkAddr
 	case err = <-errorChannel:
 		c.broadcast.Unregister(messageReceiver)
 		return nil, err
-	case zclIncomingMessage := <-responseChannel:
+	case incomingMessage := <-responseChannel:
 		c.broadcast.Unregister(messageReceiver)
-		return zclIncomingMessage, nil
+		return incomingMessage, nil
+	}
+}
+
+func awaitDataConfirm(receiver chan interface{}, deadline *time.Timer, clusterId uint16, transactionId uint8) error {
+	for {
+		select {
+		case response := <-receiver:
+			if dataConfirm, ok := response.(*znp.AfDataConfirm); ok {
+				if dataConfirm.TransID == transactionId {
+					deadline.Stop()
+					metrics.DataConfirm(dataConfirm.Status)
+					if dataConfirm.Status != znp.StatusSuccess {
+						return &DeliveryError{Status: dataConfirm.Status}
+					}
+					return nil
+				}
+			}
+		case _ = <-deadline.C:
+			metrics.DataRequestTimeout(clusterId, "confirm")
+			return fmt.Errorf("timeout. didn't receive confirmation for transaction: %d", transactionId)
+		}
+	}
+}
+
+func awaitIncomingMessage(receiver chan interface{}, deadline *time.Timer, nwkAddress string, clusterId uint16, transactionId uint8) (*znp.AfIncomingMessage, error) {
+	for {
+		select {
+		case response := <-receiver:
+			if incomingMessage, ok := response.(*znp.AfIncomingMessage); ok {
+				frm := frame.Decode(incomingMessage.Data)
+				if (frm.TransactionSequenceNumber == transactionId) &&
+					(incomingMessage.SrcAddr == nwkAddress) {
+					deadline.Stop()
+					return incomingMessage, nil
+				}
+			}
+		case _ = <-deadline.C:
+			metrics.DataRequestTimeout(clusterId, "response")
+			return nil, fmt.Errorf("timeout. didn't receive response for transaction: %d", transactionId)
+		}
 	}
 }
 
@@ -371,7 +501,7 @@ func (c *Coordinator) mapMessageChannels() {
 				c.messageChannels.onError <- err
 			case incoming := <-c.networkProcessor.AsyncInbound():
 				debugIncoming := func(format string) {
-					log.Debugf(format, func() string { return spew.Sdump(incoming) })
+					log.Debugf(format, logger.Lazy(func() string { return spew.Sdump(incoming) }))
 				}
 				c.broadcast.Broadcast <- incoming
 				switch message := incoming.(type) {
@@ -393,6 +523,21 @@ func (c *Coordinator) mapMessageChannels() {
 	}()
 }
 
+func defaultEndpoints() []*Endpoint {
+	var endpoints []*Endpoint
+	for i, profileId := range []uint16{0x0104, 0x0101, 0x0105, 0x0107, 0x0108, 0x0109} {
+		endpoints = append(endpoints, &Endpoint{
+			Id:             uint8(i + 1),
+			ProfileId:      profileId,
+			DeviceId:       0x0005,
+			DeviceVersion:  0x1,
+			InClusterList:  []uint16{},
+			OutClusterList: []uint16{},
+		})
+	}
+	return endpoints
+}
+
 func configure(coordinator *Coordinator) {
 	coordinator.Reset()
 	np := coordinator.networkProcessor
@@ -421,6 +566,15 @@ func configure(coordinator *Coordinator) {
 		_, err := np.UtilSetPanId(coordinator.config.PanId)
 		return err
 	})
+	//extended pan id
+	if coordinator.config.ExtendedPanId != 0 {
+		mandatorySetting(func() error {
+			extendedPanId := make([]uint8, 8)
+			binary.LittleEndian.PutUint64(extendedPanId, coordinator.config.ExtendedPanId)
+			_, err := np.SapiZbWriteConfiguration(0x2D, extendedPanId)
+			return err
+		})
+	}
 	//zdo direc cb
 	mandatorySetting(func() error {
 		_, err := np.SapiZbWriteConfiguration(0x8F, []uint8{1})
@@ -431,10 +585,12 @@ func configure(coordinator *Coordinator) {
 		_, err := np.SapiZbWriteConfiguration(0x64, []uint8{1})
 		return err
 	})
-	mandatorySetting(func() error {
-		_, err := np.SysSetExtAddr(coordinator.config.IEEEAddress)
-		return err
-	})
+	if coordinator.config.IEEEAddress != "" {
+		mandatorySetting(func() error {
+			_, err := np.SysSetExtAddr(coordinator.config.IEEEAddress)
+			return err
+		})
+	}
 
 	channels := &znp.Channels{}
 	for _, v := range coordinator.config.Channels {
@@ -492,17 +648,14 @@ func subscribe(coordinator *Coordinator) {
 
 func registerEndpoints(coordinator *Coordinator) {
 	np := coordinator.networkProcessor
-	np.AfRegister(0x01, 0x0104, 0x0005, 0x1, znp.LatencyNoLatency, []uint16{}, []uint16{})
-
-	np.AfRegister(0x02, 0x0101, 0x0005, 0x1, znp.LatencyNoLatency, []uint16{}, []uint16{})
-
-	np.AfRegister(0x03, 0x0105, 0x0005, 0x1, znp.LatencyNoLatency, []uint16{}, []uint16{})
-
-	np.AfRegister(0x04, 0x0107, 0x0005, 0x1, znp.LatencyNoLatency, []uint16{}, []uint16{})
-
-	np.AfRegister(0x05, 0x0108, 0x0005, 0x1, znp.LatencyNoLatency, []uint16{}, []uint16{})
-
-	np.AfRegister(0x06, 0x0109, 0x0005, 0x1, znp.LatencyNoLatency, []uint16{}, []uint16{})
+	for _, endpoint := range coordinator.endpoints {
+		log.Debugf("Registering endpoint [%d]. In clusters: %v, Out clusters: %v", endpoint.Id, endpoint.InClusterList, endpoint.OutClusterList)
+		_, err := np.AfRegister(endpoint.Id, endpoint.ProfileId, endpoint.DeviceId, endpoint.DeviceVersion, znp.LatencyNoLatency,
+			endpoint.InClusterList, endpoint.OutClusterList)
+		if err != nil {
+			log.Errorf("Unable to register endpoint [%d]: %s", endpoint.Id, err)
+		}
+	}
 }
 
 func enrichNetworkDetails(coordinator *Coordinator) {
@@ -511,6 +664,9 @@ func enrichNetworkDetails(coordinator *Coordinator) {
 		log.Fatal(err)
 	}
 	coordinator.network.Address = deviceInfo.ShortAddr
+	if coordinator.config.IEEEAddress == "" {
+		coordinator.config.IEEEAddress = deviceInfo.IEEEAddr
+	}
 }
 
 func permitJoin(coordinator *Coordinator) {
@@ -518,7 +674,9 @@ func permitJoin(coordinator *Coordinator) {
 	if coordinator.config.PermitJoin {
 		timeout = 0xFF
 	}
-	coordinator.networkProcessor.SapiZbPermitJoiningRequest(coordinator.network.Address, timeout)
+	if err := coordinator.PermitJoin(timeout); err != nil {
+		log.Error(err)
+	}
 }
 
 func switchLed(coordinator *Coordinator) {
@@ -0,0 +1,29 @@
+package coordinator
+
+import (
+	"errors"
+	"fmt"
+	"github.com/dyrkin/znp-go"
+	"testing"
+)
+
+func TestIsRouteFailure(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"no route", &DeliveryError{Status: znp.StatusNwkNoRoute}, true},
+		{"mac no ack", &DeliveryError{Status: znp.StatusMacNoACK}, true},
+		{"aps no ack", &DeliveryError{Status: znp.StatusApsNoAck}, true},
+		{"wrapped", fmt.Errorf("bind: %w", &DeliveryError{Status: znp.StatusNwkNoRoute}), true},
+		{"other status", &DeliveryError{Status: znp.StatusFailure}, false},
+		{"timeout", errors.New("timeout. didn't receive response for transaction: 1"), false},
+	}
+	for _, test := range tests {
+		if got := IsRouteFailure(test.err); got != test.want {
+			t.Errorf("%s: got %t, want %t", test.name, got, test.want)
+		}
+	}
+}
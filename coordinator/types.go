@@ -11,3 +11,6 @@ var ZdoSimpleDescRspType = reflect.TypeOf(&znp.ZdoSimpleDescRsp{})
 var ZdoNodeDescRspType = reflect.TypeOf(&znp.ZdoNodeDescRsp{})
 var ZdoBindRspType = reflect.TypeOf(&znp.ZdoBindRsp{})
 var ZdoUnbindRspType = reflect.TypeOf(&znp.ZdoUnbindRsp{})
+var ZdoMgmtLeaveRspType = reflect.TypeOf(&znp.ZdoMgmtLeaveRsp{})
+var ZdoNwkAddrRspType = reflect.TypeOf(&znp.ZdoNwkAddrRsp{})
+var ZdoMgmtLqiRspType = reflect.TypeOf(&znp.ZdoMgmtLqiRsp{})
@@ -56,6 +56,37 @@ func (devices Devices) GetByNetworkAddress(networkAddress string) (*model.Device
 	return nil, false
 }
 
+func (devices Devices) All() []*model.Device {
+	rw.RLock()
+	defer rw.RUnlock()
+	var all []*model.Device
+	for _, d := range devices {
+		all = append(all, d)
+	}
+	return all
+}
+
+func (devices Devices) Update(ieeeAddress string, updateFn func(device *model.Device)) bool {
+	updated := false
+	update(func() {
+		if device, ok := database.tables.Devices[ieeeAddress]; ok {
+			updateFn(device)
+			updated = true
+		}
+	})
+	return updated
+}
+
+func (devices Devices) View(ieeeAddress string, viewFn func(device *model.Device)) bool {
+	rw.RLock()
+	defer rw.RUnlock()
+	device, ok := database.tables.Devices[ieeeAddress]
+	if ok {
+		viewFn(device)
+	}
+	return ok
+}
+
 func (devices Devices) Remove(ieeeAddress string) {
 	update(func() {
 		delete(database.tables.Devices, ieeeAddress)
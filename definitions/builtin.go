@@ -0,0 +1,145 @@
+package definitions
+
+import (
+	"github.com/dyrkin/zcl-go/cluster"
+)
+
+var Generic = &Definition{
+	Vendor:      "Generic",
+	Description: "Generic ZCL device",
+	FromZigbee: []*FromZigbee{
+		FromZigbeeConverter("on_off"),
+		FromZigbeeConverter("brightness"),
+		FromZigbeeConverter("color"),
+		FromZigbeeConverter("battery"),
+		FromZigbeeConverter("temperature"),
+		FromZigbeeConverter("humidity"),
+		FromZigbeeConverter("pressure"),
+		FromZigbeeConverter("illuminance"),
+		FromZigbeeConverter("occupancy"),
+		FromZigbeeConverter("ias_zone"),
+		FromZigbeeConverter("window_covering"),
+		FromZigbeeConverter("door_lock"),
+		FromZigbeeConverter("thermostat"),
+	},
+	ToZigbee: []*ToZigbee{
+		ToZigbeeConverter("on_off"),
+		ToZigbeeConverter("brightness"),
+		ToZigbeeConverter("color_temp"),
+		ToZigbeeConverter("color"),
+		ToZigbeeConverter("window_covering"),
+		ToZigbeeConverter("door_lock"),
+		ToZigbeeConverter("thermostat"),
+	},
+}
+
+var builtinDefinitions = []*Definition{
+	{
+		Vendor:       "IKEA",
+		Description:  "TRADFRI LED bulb",
+		Manufacturer: "IKEA of Sweden",
+		Models: []string{
+			"TRADFRI bulb E27 W opal 1000lm",
+			"TRADFRI bulb E27 WW 806lm",
+			"TRADFRI bulb E14 W op/ch 400lm",
+			"TRADFRI bulb GU10 W 400lm",
+		},
+		Exposes: []Feature{FeatureLight, FeatureSwitch, FeatureBrightness},
+		FromZigbee: []*FromZigbee{
+			FromZigbeeConverter("on_off"),
+			FromZigbeeConverter("brightness"),
+		},
+		ToZigbee: []*ToZigbee{
+			ToZigbeeConverter("on_off"),
+			ToZigbeeConverter("brightness"),
+		},
+		Configure: []*Configure{
+			Bind(cluster.OnOff, cluster.LevelControl),
+			ConfigureReporting(cluster.OnOff, &Reporting{
+				Attribute:       0x0000,
+				DataType:        cluster.ZclDataTypeBoolean,
+				MinimumInterval: 0,
+				MaximumInterval: 3600,
+			}),
+			ConfigureReporting(cluster.LevelControl, &Reporting{
+				Attribute:        0x0000,
+				DataType:         cluster.ZclDataTypeUint8,
+				MinimumInterval:  1,
+				MaximumInterval:  3600,
+				ReportableChange: uint64(1),
+			}),
+		},
+	},
+	{
+		Vendor:       "IKEA",
+		Description:  "TRADFRI wireless dimmer",
+		Manufacturer: "IKEA of Sweden",
+		Models:       []string{"TRADFRI wireless dimmer"},
+		Exposes:      []Feature{FeatureAction, FeatureBattery},
+		FromZigbee: []*FromZigbee{
+			FromZigbeeConverter("command_level"),
+			FromZigbeeConverter("battery"),
+		},
+		Configure: []*Configure{
+			Bind(cluster.LevelControl, cluster.PowerConfiguration),
+		},
+	},
+	{
+		Vendor:       "Xiaomi",
+		Description:  "Aqara wireless remote switch",
+		Manufacturer: "LUMI",
+		Models:       []string{"lumi.remote.b186acn01", "lumi.remote.b286acn01"},
+		Exposes:      []Feature{FeatureAction, FeatureBattery},
+		FromZigbee: []*FromZigbee{
+			FromZigbeeConverter("xiaomi_action"),
+			FromZigbeeConverter("xiaomi_basic"),
+		},
+	},
+	{
+		Vendor:       "Xiaomi",
+		Description:  "Aqara wireless mini switch",
+		Manufacturer: "LUMI",
+		Models:       []string{"lumi.sensor_switch.aq3"},
+		Exposes:      []Feature{FeatureAction, FeatureBattery},
+		FromZigbee: []*FromZigbee{
+			FromZigbeeConverter("xiaomi_action"),
+			FromZigbeeConverter("xiaomi_basic"),
+		},
+	},
+	{
+		Vendor:       "Xiaomi",
+		Description:  "Wireless mini switch",
+		Manufacturer: "LUMI",
+		Models:       []string{"lumi.sensor_switch", "lumi.sensor_switch.aq2"},
+		Exposes:      []Feature{FeatureAction, FeatureBattery},
+		FromZigbee: []*FromZigbee{
+			FromZigbeeConverter("xiaomi_multi_click"),
+			FromZigbeeConverter("xiaomi_basic"),
+		},
+	},
+	{
+		Vendor:       "Xiaomi",
+		Description:  "Aqara temperature, humidity and pressure sensor",
+		Manufacturer: "LUMI",
+		Models:       []string{"lumi.weather", "lumi.sensor_ht", "lumi.sens"},
+		Exposes:      []Feature{FeatureTemperature, FeatureHumidity, FeaturePressure, FeatureBattery},
+		FromZigbee: []*FromZigbee{
+			FromZigbeeConverter("temperature"),
+			FromZigbeeConverter("humidity"),
+			FromZigbeeConverter("pressure"),
+			FromZigbeeConverter("xiaomi_basic"),
+		},
+	},
+	{
+		Vendor:       "Xiaomi",
+		Description:  "Aqara occupancy and illuminance sensor",
+		Manufacturer: "LUMI",
+		Models:       []string{"lumi.sensor_motion.aq2"},
+		Exposes:      []Feature{FeatureOccupancy, FeatureIlluminance, FeatureBattery},
+		FromZigbee: []*FromZigbee{
+			FromZigbeeConverter("occupancy"),
+			FromZigbeeConverter("illuminance"),
+			FromZigbeeConverter("xiaomi_basic"),
+		},
+	},
+}
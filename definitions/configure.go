@@ -0,0 +1,142 @@
+package definitions
+
+import (
+	"fmt"
+	"github.com/dyrkin/zcl-go/cluster"
+	"github.com/dyrkin/zigbee-steward/model"
+	"math"
+)
+
+type Reporting struct {
+	Attribute        uint16              `json:"attribute" yaml:"attribute"`
+	DataType         cluster.ZclDataType `json:"dataType" yaml:"dataType"`
+	MinimumInterval  uint16              `json:"minimumInterval" yaml:"minimumInterval"`
+	MaximumInterval  uint16              `json:"maximumInterval" yaml:"maximumInterval"`
+	ReportableChange interface{}         `json:"reportableChange" yaml:"reportableChange"`
+}
+
+func Bind(clusterIds ...cluster.ClusterId) *Configure {
+	return &Configure{
+		Name: fmt.Sprintf("bind %v", clusterIds),
+		Run: func(context *Context, device *model.Device) error {
+			for _, clusterId := range clusterIds {
+				for _, endpoint := range device.Endpoints {
+					if !endpoint.HasInCluster(uint16(clusterId)) && !endpoint.HasOutCluster(uint16(clusterId)) {
+						continue
+					}
+					_, err := context.Functions.Generic().Bind(device.NetworkAddress, device.IEEEAddress, endpoint.Id,
+						uint16(clusterId), context.CoordinatorIEEEAddress, context.CoordinatorEndpoint)
+					if err != nil {
+						return fmt.Errorf("unable to bind cluster [%d], ep: [%d]: %s", clusterId, endpoint.Id, err)
+					}
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func ConfigureReporting(clusterId cluster.ClusterId, reportings ...*Reporting) *Configure {
+	return &Configure{
+		Name:       fmt.Sprintf("reporting %d", clusterId),
+		reportings: reportings,
+		Run: func(context *Context, device *model.Device) error {
+			records, err := reportingRecords(reportings)
+			if err != nil {
+				return fmt.Errorf("unable to configure reporting of cluster [%d]: %s", clusterId, err)
+			}
+			for _, endpoint := range device.Endpoints {
+				if !endpoint.HasInCluster(uint16(clusterId)) {
+					continue
+				}
+				response, err := context.Functions.Cluster().Global().Endpoint(endpoint.Id).ConfigureReporting(device.NetworkAddress, clusterId, records)
+				if err != nil {
+					return fmt.Errorf("unable to configure reporting of cluster [%d], ep: [%d]: %s", clusterId, endpoint.Id, err)
+				}
+				for _, status := range response.AttributeStatusRecords {
+					if status.Status != cluster.ZclStatusSuccess {
+						return fmt.Errorf("unable to configure reporting of attribute [%d] of cluster [%d], ep: [%d]. Status: [%d]",
+							status.AttributeID, clusterId, endpoint.Id, status.Status)
+					}
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func reportingRecords(reportings []*Reporting) ([]*cluster.AttributeReportingConfigurationRecord, error) {
+	var records []*cluster.AttributeReportingConfigurationRecord
+	for _, r := range reportings {
+		change, err := reportableChange(r.DataType, r.ReportableChange)
+		if err != nil {
+			return nil, fmt.Errorf("attribute [%d]: %s", r.Attribute, err)
+		}
+		records = append(records, &cluster.AttributeReportingConfigurationRecord{
+			Direction:                cluster.ReportDirectionAttributeReported,
+			AttributeID:              r.Attribute,
+			AttributeDataType:        r.DataType,
+			MinimumReportingInterval: r.MinimumInterval,
+			MaximumReportingInterval: r.MaximumInterval,
+			ReportableChange:         &cluster.Attribute{DataType: r.DataType, Value: change},
+		})
+	}
+	return records, nil
+}
+
+// reportableChange converts the value to the type zcl-go encodes for the data type.
+// Missing values become the zero value of that type
+func reportableChange(dataType cluster.ZclDataType, value interface{}) (interface{}, error) {
+	switch {
+	case dataType == cluster.ZclDataTypeBoolean:
+		switch v := value.(type) {
+		case nil:
+			return false, nil
+		case bool:
+			return v, nil
+		}
+	case dataType >= cluster.ZclDataTypeBitmap8 && dataType <= cluster.ZclDataTypeBitmap64,
+		dataType >= cluster.ZclDataTypeUint8 && dataType <= cluster.ZclDataTypeUint64,
+		dataType == cluster.ZclDataTypeEnum8 || dataType == cluster.ZclDataTypeEnum16:
+		if value == nil {
+			return uint64(0), nil
+		}
+		if v, ok := value.(uint64); ok {
+			return v, nil
+		}
+		if number, ok := reportableNumber(value); ok && number >= 0 && number == math.Trunc(number) {
+			return uint64(number), nil
+		}
+	case dataType >= cluster.ZclDataTypeInt8 && dataType <= cluster.ZclDataTypeInt64:
+		if value == nil {
+			return int64(0), nil
+		}
+		if number, ok := reportableNumber(value); ok && number == math.Trunc(number) {
+			return int64(number), nil
+		}
+	case dataType >= cluster.ZclDataTypeSemiPrec && dataType <= cluster.ZclDataTypeDoublePrec:
+		if value == nil {
+			return float64(0), nil
+		}
+		if number, ok := reportableNumber(value); ok {
+			return number, nil
+		}
+	default:
+		return nil, fmt.Errorf("unsupported reporting data type [0x%02x]", uint8(dataType))
+	}
+	return nil, fmt.Errorf("invalid reportable change [%v] for data type [0x%02x]", value, uint8(dataType))
+}
+
+func reportableNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
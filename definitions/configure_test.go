@@ -0,0 +1,86 @@
+package definitions
+
+import (
+	"github.com/dyrkin/bin"
+	"github.com/dyrkin/zcl-go/cluster"
+	"testing"
+)
+
+func TestRegisteredReportingRecordsEncode(t *testing.T) {
+	for _, definition := range NewRegistry().definitions {
+		for _, configure := range definition.Configure {
+			if len(configure.reportings) == 0 {
+				continue
+			}
+			t.Run(definition.Description+"/"+configure.Name, func(t *testing.T) {
+				records, err := reportingRecords(configure.reportings)
+				if err != nil {
+					t.Fatalf("reportingRecords() error = %v", err)
+				}
+				defer func() {
+					if r := recover(); r != nil {
+						t.Fatalf("unable to encode reporting records: %v", r)
+					}
+				}()
+				bin.Encode(&cluster.ConfigureReportingCommand{AttributeReportingConfigurationRecords: records})
+			})
+		}
+	}
+}
+
+func TestReportableChange(t *testing.T) {
+	tests := []struct {
+		name     string
+		dataType cluster.ZclDataType
+		value    interface{}
+		want     interface{}
+		wantErr  bool
+	}{
+		{name: "missing boolean", dataType: cluster.ZclDataTypeBoolean, want: false},
+		{name: "boolean", dataType: cluster.ZclDataTypeBoolean, value: true, want: true},
+		{name: "numeric boolean", dataType: cluster.ZclDataTypeBoolean, value: float64(1), wantErr: true},
+		{name: "missing uint", dataType: cluster.ZclDataTypeUint8, want: uint64(0)},
+		{name: "json uint", dataType: cluster.ZclDataTypeUint16, value: float64(10), want: uint64(10)},
+		{name: "yaml uint", dataType: cluster.ZclDataTypeUint16, value: 10, want: uint64(10)},
+		{name: "negative uint", dataType: cluster.ZclDataTypeUint16, value: -1, wantErr: true},
+		{name: "fractional uint", dataType: cluster.ZclDataTypeUint16, value: 0.5, wantErr: true},
+		{name: "missing enum", dataType: cluster.ZclDataTypeEnum8, want: uint64(0)},
+		{name: "missing bitmap", dataType: cluster.ZclDataTypeBitmap8, want: uint64(0)},
+		{name: "missing int", dataType: cluster.ZclDataTypeInt16, want: int64(0)},
+		{name: "json int", dataType: cluster.ZclDataTypeInt16, value: float64(-5), want: int64(-5)},
+		{name: "string int", dataType: cluster.ZclDataTypeInt16, value: "5", wantErr: true},
+		{name: "unsupported", dataType: cluster.ZclDataTypeCharStr, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := reportableChange(tt.dataType, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("reportableChange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("reportableChange() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveRejectsInvalidReportableChange(t *testing.T) {
+	specs, err := parseFile("definitions.yaml", []byte(`
+- manufacturer: Vendor
+  models: [switch]
+  configure:
+    - reporting:
+        cluster: 6
+        attributes:
+          - attribute: 0
+            dataType: 16
+            reportableChange: 1
+`))
+	if err != nil {
+		t.Fatalf("parseFile() error = %v", err)
+	}
+	registry := NewRegistry()
+	if _, err := registry.resolve(specs[0]); err == nil {
+		t.Errorf("resolve() accepted a numeric reportable change for a boolean attribute")
+	}
+}
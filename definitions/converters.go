@@ -0,0 +1,509 @@
+package definitions
+
+import (
+	"fmt"
+	"github.com/dyrkin/zcl-go/cluster"
+	"github.com/dyrkin/zigbee-steward/clusters"
+	"github.com/dyrkin/zigbee-steward/converters"
+	"github.com/dyrkin/zigbee-steward/model"
+	"math"
+	"strings"
+)
+
+const colorScale = 65535
+
+var multistateActions = map[uint64]string{
+	0:   "hold",
+	1:   "single",
+	2:   "double",
+	3:   "triple",
+	4:   "quadruple",
+	255: "release",
+}
+
+var fromZigbeeConverters = []*FromZigbee{
+	{Name: "on_off", Cluster: cluster.OnOff, Convert: onOffFromZigbee},
+	{Name: "brightness", Cluster: cluster.LevelControl, Convert: brightnessFromZigbee},
+	{Name: "color", Cluster: clusters.ColorControl, Convert: colorFromZigbee},
+	{Name: "command_on_off", Cluster: cluster.OnOff, Convert: commandOnOffFromZigbee},
+	{Name: "command_level", Cluster: cluster.LevelControl, Convert: commandLevelFromZigbee},
+	{Name: "multistate_action", Cluster: cluster.MultistateInput, Convert: multistateActionFromZigbee},
+	{Name: "battery", Cluster: cluster.PowerConfiguration, Convert: batteryFromZigbee},
+	{Name: "temperature", Cluster: clusters.TemperatureMeasurement, Convert: temperatureFromZigbee},
+	{Name: "humidity", Cluster: clusters.RelativeHumidity, Convert: humidityFromZigbee},
+	{Name: "pressure", Cluster: clusters.PressureMeasurement, Convert: pressureFromZigbee},
+	{Name: "illuminance", Cluster: clusters.IlluminanceMeasurement, Convert: illuminanceFromZigbee},
+	{Name: "occupancy", Cluster: clusters.OccupancySensing, Convert: occupancyFromZigbee},
+	{Name: "ias_zone", Cluster: clusters.IASZone, Convert: iasZoneFromZigbee},
+	{Name: "window_covering", Cluster: clusters.WindowCovering, Convert: windowCoveringFromZigbee},
+	{Name: "door_lock", Cluster: clusters.DoorLock, Convert: doorLockFromZigbee},
+	{Name: "thermostat", Cluster: clusters.Thermostat, Convert: thermostatFromZigbee},
+	{Name: "xiaomi_basic", Cluster: cluster.Basic, Convert: xiaomiBasicFromZigbee},
+	{Name: "xiaomi_action", Cluster: cluster.MultistateInput, Convert: xiaomiClickFromZigbee},
+	{Name: "xiaomi_multi_click", Cluster: cluster.OnOff, Convert: xiaomiClickFromZigbee},
+}
+
+var toZigbeeConverters = []*ToZigbee{
+	{Name: "on_off", Cluster: cluster.OnOff, Keys: []string{"state"}, Convert: onOffToZigbee},
+	{Name: "brightness", Cluster: cluster.LevelControl, Keys: []string{"brightness"}, Convert: brightnessToZigbee},
+	{Name: "color_temp", Cluster: clusters.ColorControl, Keys: []string{"color_temp"}, Convert: colorTemperatureToZigbee},
+	{Name: "color", Cluster: clusters.ColorControl, Keys: []string{"color"}, Convert: colorToZigbee},
+	{Name: "window_covering", Cluster: clusters.WindowCovering, Keys: []string{"cover", "position"}, Convert: windowCoveringToZigbee},
+	{Name: "door_lock", Cluster: clusters.DoorLock, Keys: []string{"lock"}, Convert: doorLockToZigbee},
+	{Name: "thermostat", Cluster: clusters.Thermostat, Keys: []string{"occupied_heating_setpoint", "system_mode"}, Convert: thermostatToZigbee},
+}
+
+var systemModes = []model.SystemMode{
+	model.SystemModeOff,
+	model.SystemModeAuto,
+	model.SystemModeCool,
+	model.SystemModeHeat,
+	model.SystemModeEmergencyHeating,
+	model.SystemModePrecooling,
+	model.SystemModeFanOnly,
+	model.SystemModeDry,
+	model.SystemModeSleep,
+}
+
+func FromZigbeeConverter(name string) *FromZigbee {
+	for _, converter := range fromZigbeeConverters {
+		if converter.Name == name {
+			return converter
+		}
+	}
+	panic(fmt.Sprintf("unknown fromZigbee converter [%s]", name))
+}
+
+func ToZigbeeConverter(name string) *ToZigbee {
+	for _, converter := range toZigbeeConverters {
+		if converter.Name == name {
+			return converter
+		}
+	}
+	panic(fmt.Sprintf("unknown toZigbee converter [%s]", name))
+}
+
+func Attributes(message *model.DeviceIncomingMessage) map[uint16]*cluster.Attribute {
+	attributes := map[uint16]*cluster.Attribute{}
+	switch command := message.IncomingMessage.Data.Command.(type) {
+	case *cluster.ReportAttributesCommand:
+		for _, report := range command.AttributeReports {
+			if report.Attribute != nil {
+				attributes[report.AttributeID] = report.Attribute
+			}
+		}
+	case *cluster.ReadAttributesResponse:
+		for _, status := range command.ReadAttributeStatuses {
+			if status.Status == cluster.ZclStatusSuccess && status.Attribute != nil {
+				attributes[status.AttributeID] = status.Attribute
+			}
+		}
+	}
+	return attributes
+}
+
+func uintAttribute(message *model.DeviceIncomingMessage, attributeId uint16) (uint64, bool) {
+	if attribute, ok := Attributes(message)[attributeId]; ok {
+		value, ok := attribute.Value.(uint64)
+		return value, ok
+	}
+	return 0, false
+}
+
+func intAttribute(message *model.DeviceIncomingMessage, attributeId uint16) (int64, bool) {
+	if attribute, ok := Attributes(message)[attributeId]; ok {
+		value, ok := attribute.Value.(int64)
+		return value, ok
+	}
+	return 0, false
+}
+
+func onOffFromZigbee(message *model.DeviceIncomingMessage, state model.State) {
+	if attribute, ok := Attributes(message)[0x0000]; ok {
+		switch value := attribute.Value.(type) {
+		case bool:
+			state["state"] = onOff(value)
+		case uint64:
+			state["state"] = onOff(value > 0)
+		}
+	}
+}
+
+func brightnessFromZigbee(message *model.DeviceIncomingMessage, state model.State) {
+	if level, ok := uintAttribute(message, 0x0000); ok {
+		state["brightness"] = uint8(level)
+	}
+}
+
+func colorFromZigbee(message *model.DeviceIncomingMessage, state model.State) {
+	attributes := Attributes(message)
+	if attribute, ok := attributes[0x0007]; ok {
+		if mireds, ok := attribute.Value.(uint64); ok {
+			state["color_temp"] = uint16(mireds)
+		}
+	}
+	x, xOk := attributes[0x0003]
+	y, yOk := attributes[0x0004]
+	if xOk && yOk {
+		colorX, xOk := x.Value.(uint64)
+		colorY, yOk := y.Value.(uint64)
+		if xOk && yOk {
+			state["color"] = map[string]interface{}{
+				"x": math.Round(float64(colorX)/colorScale*10000) / 10000,
+				"y": math.Round(float64(colorY)/colorScale*10000) / 10000,
+			}
+		}
+	}
+}
+
+func commandOnOffFromZigbee(message *model.DeviceIncomingMessage, state model.State) {
+	switch message.IncomingMessage.Data.Command.(type) {
+	case *cluster.OnCommand:
+		state["action"] = "on"
+	case *cluster.OffCommand:
+		state["action"] = "off"
+	case *cluster.ToggleCommand:
+		state["action"] = "toggle"
+	}
+}
+
+func commandLevelFromZigbee(message *model.DeviceIncomingMessage, state model.State) {
+	switch command := message.IncomingMessage.Data.Command.(type) {
+	case *cluster.MoveToLevelCommand:
+		state["action"] = "brightness_move_to_level"
+		state["action_level"] = command.Level
+	case *cluster.MoveToLevelOnOffCommand:
+		state["action"] = "brightness_move_to_level"
+		state["action_level"] = command.Level
+	case *cluster.MoveCommand:
+		state["action"] = "brightness_move_" + direction(command.MoveMode)
+		state["action_rate"] = command.Rate
+	case *cluster.MoveOnOffCommand:
+		state["action"] = "brightness_move_" + direction(command.MoveMode)
+		state["action_rate"] = command.Rate
+	case *cluster.StepCommand:
+		state["action"] = "brightness_step_" + direction(command.StepMode)
+		state["action_step_size"] = command.StepSize
+	case *cluster.StepOnOffCommand:
+		state["action"] = "brightness_step_" + direction(command.StepMode)
+		state["action_step_size"] = command.StepSize
+	case *cluster.StopCommand, *cluster.StopOnOffCommand:
+		state["action"] = "brightness_stop"
+	}
+}
+
+func multistateActionFromZigbee(message *model.DeviceIncomingMessage, state model.State) {
+	if value, ok := uintAttribute(message, 0x0055); ok {
+		if action, ok := multistateActions[value]; ok {
+			state["action"] = action
+		}
+	}
+}
+
+func batteryFromZigbee(message *model.DeviceIncomingMessage, state model.State) {
+	if value, ok := uintAttribute(message, 0x0020); ok {
+		if voltage, ok := converters.BatteryVoltage(value); ok {
+			state["voltage"] = voltage
+		}
+	}
+	if value, ok := uintAttribute(message, 0x0021); ok {
+		if percentage, ok := converters.BatteryPercentage(value); ok {
+			state["battery"] = percentage
+		}
+	}
+}
+
+func temperatureFromZigbee(message *model.DeviceIncomingMessage, state model.State) {
+	if value, ok := intAttribute(message, 0x0000); ok {
+		if temperature, ok := converters.Temperature(value); ok {
+			state["temperature"] = temperature
+		}
+	}
+}
+
+func humidityFromZigbee(message *model.DeviceIncomingMessage, state model.State) {
+	if value, ok := uintAttribute(message, 0x0000); ok {
+		if humidity, ok := converters.Humidity(value); ok {
+			state["humidity"] = humidity
+		}
+	}
+}
+
+func pressureFromZigbee(message *model.DeviceIncomingMessage, state model.State) {
+	if value, ok := intAttribute(message, 0x0000); ok {
+		if pressure, ok := converters.Pressure(value); ok {
+			state["pressure"] = pressure
+		}
+	}
+}
+
+func illuminanceFromZigbee(message *model.DeviceIncomingMessage, state model.State) {
+	if value, ok := uintAttribute(message, 0x0000); ok {
+		if illuminance, ok := converters.Illuminance(value); ok {
+			state["illuminance"] = illuminance
+		}
+	}
+}
+
+func occupancyFromZigbee(message *model.DeviceIncomingMessage, state model.State) {
+	if value, ok := uintAttribute(message, 0x0000); ok {
+		state["occupancy"] = converters.Occupied(value)
+	}
+}
+
+func iasZoneFromZigbee(message *model.DeviceIncomingMessage, state model.State) {
+	var zoneStatus *model.ZoneStatus
+	switch command := message.IncomingMessage.Data.Command.(type) {
+	case *clusters.ZoneStatusChangeNotificationCommand:
+		zoneStatus = model.NewZoneStatus(command.ZoneStatus)
+	default:
+		if value, ok := uintAttribute(message, 0x0002); ok {
+			zoneStatus = model.NewZoneStatus(uint16(value))
+		}
+	}
+	if zoneStatus != nil {
+		state["alarm"] = zoneStatus.Alarm1 || zoneStatus.Alarm2
+		state["tamper"] = zoneStatus.Tamper
+		state["battery_low"] = zoneStatus.BatteryLow
+	}
+}
+
+func windowCoveringFromZigbee(message *model.DeviceIncomingMessage, state model.State) {
+	if value, ok := uintAttribute(message, 0x0008); ok && value <= 100 {
+		state["position"] = uint8(100 - value)
+	}
+}
+
+func doorLockFromZigbee(message *model.DeviceIncomingMessage, state model.State) {
+	if value, ok := uintAttribute(message, 0x0000); ok {
+		switch value {
+		case 1:
+			state["lock"] = "LOCKED"
+		case 2:
+			state["lock"] = "UNLOCKED"
+		}
+	}
+}
+
+func thermostatFromZigbee(message *model.DeviceIncomingMessage, state model.State) {
+	if value, ok := intAttribute(message, 0x0000); ok && value != model.TemperatureNotPresent {
+		state["local_temperature"] = model.Celsius(value)
+	}
+	if value, ok := intAttribute(message, 0x0012); ok {
+		state["occupied_heating_setpoint"] = model.Celsius(value)
+	}
+	if value, ok := uintAttribute(message, 0x001c); ok {
+		state["system_mode"] = strings.ToLower(model.SystemMode(value).String())
+	}
+}
+
+func xiaomiBasicFromZigbee(message *model.DeviceIncomingMessage, state model.State) {
+	attribute, ok := Attributes(message)[converters.XiaomiTLVAttributeId]
+	if !ok {
+		return
+	}
+	tlv, ok := attribute.Value.(string)
+	if !ok {
+		return
+	}
+	values, err := converters.XiaomiTLV([]uint8(tlv))
+	if err != nil {
+		return
+	}
+	if reading, ok := converters.XiaomiReading(message.Device, message.IncomingMessage.SrcEndpoint, values); ok {
+		readingState(reading, state)
+	}
+}
+
+func xiaomiClickFromZigbee(message *model.DeviceIncomingMessage, state model.State) {
+	command, ok := message.IncomingMessage.Data.Command.(*cluster.ReportAttributesCommand)
+	if !ok {
+		return
+	}
+	clusterId := cluster.ClusterId(message.IncomingMessage.ClusterID)
+	for _, report := range command.AttributeReports {
+		if click, ok := converters.XiaomiClick(message.Device, clusterId, report); ok {
+			state["action"] = strings.ToLower(click.String())
+		}
+	}
+}
+
+func readingState(reading *model.DeviceSensorReading, state model.State) {
+	if reading.Temperature != nil {
+		state["temperature"] = *reading.Temperature
+	}
+	if reading.Humidity != nil {
+		state["humidity"] = *reading.Humidity
+	}
+	if reading.Pressure != nil {
+		state["pressure"] = *reading.Pressure
+	}
+	if reading.Illuminance != nil {
+		state["illuminance"] = *reading.Illuminance
+	}
+	if reading.Occupied != nil {
+		state["occupancy"] = *reading.Occupied
+	}
+	if reading.BatteryPercentage != nil {
+		state["battery"] = *reading.BatteryPercentage
+	}
+	if reading.BatteryVoltage != nil {
+		state["voltage"] = *reading.BatteryVoltage
+	}
+}
+
+func onOffToZigbee(context *Context, device *model.Device, endpoint uint8, key string, value interface{}, options model.State) error {
+	onOffCluster := context.Functions.Cluster().Local().OnOff()
+	switch v := value.(type) {
+	case bool:
+		if v {
+			return onOffCluster.On(device.NetworkAddress, endpoint)
+		}
+		return onOffCluster.Off(device.NetworkAddress, endpoint)
+	case string:
+		switch strings.ToUpper(v) {
+		case "ON":
+			return onOffCluster.On(device.NetworkAddress, endpoint)
+		case "OFF":
+			return onOffCluster.Off(device.NetworkAddress, endpoint)
+		case "TOGGLE":
+			return onOffCluster.Toggle(device.NetworkAddress, endpoint)
+		}
+	}
+	return model.InvalidRequest("invalid value of [%s]: [%v]", key, value)
+}
+
+func brightnessToZigbee(context *Context, device *model.Device, endpoint uint8, key string, value interface{}, options model.State) error {
+	level, ok := Number(value)
+	if !ok || level < 0 || level > 254 {
+		return model.InvalidRequest("invalid value of [%s]: [%v]", key, value)
+	}
+	return context.Functions.Cluster().Local().LevelControl().MoveToLevelOnOff(device.NetworkAddress, endpoint,
+		uint8(level), TransitionTime(options))
+}
+
+func colorTemperatureToZigbee(context *Context, device *model.Device, endpoint uint8, key string, value interface{}, options model.State) error {
+	mireds, ok := Number(value)
+	if !ok || mireds < 0 || mireds > 0xfeff {
+		return model.InvalidRequest("invalid value of [%s]: [%v]", key, value)
+	}
+	return context.Functions.Cluster().Local().ColorControl().MoveToColorTemperature(device.NetworkAddress, endpoint,
+		uint16(mireds), TransitionTime(options))
+}
+
+func colorToZigbee(context *Context, device *model.Device, endpoint uint8, key string, value interface{}, options model.State) error {
+	var color map[string]interface{}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		color = v
+	case model.State:
+		color = v
+	default:
+		return model.InvalidRequest("invalid value of [%s]: [%v]", key, value)
+	}
+	colorControl := context.Functions.Cluster().Local().ColorControl()
+	x, xOk := Number(color["x"])
+	y, yOk := Number(color["y"])
+	if xOk && yOk && x >= 0 && x <= 1 && y >= 0 && y <= 1 {
+		return colorControl.MoveToColor(device.NetworkAddress, endpoint, uint16(x*colorScale), uint16(y*colorScale),
+			TransitionTime(options))
+	}
+	hue, hueOk := Number(color["hue"])
+	saturation, saturationOk := Number(color["saturation"])
+	if hueOk && saturationOk && hue >= 0 && hue <= 360 && saturation >= 0 && saturation <= 100 {
+		return colorControl.MoveToHueAndSaturation(device.NetworkAddress, endpoint, uint8(hue*254/360),
+			uint8(saturation*254/100), TransitionTime(options))
+	}
+	return model.InvalidRequest("invalid value of [%s]: [%v]", key, value)
+}
+
+func windowCoveringToZigbee(context *Context, device *model.Device, endpoint uint8, key string, value interface{}, options model.State) error {
+	windowCovering := context.Functions.Cluster().Local().WindowCovering()
+	if key == "position" {
+		position, ok := Number(value)
+		if !ok || position < 0 || position > 100 {
+			return model.InvalidRequest("invalid value of [%s]: [%v]", key, value)
+		}
+		return windowCovering.GoToLiftPercentage(device.NetworkAddress, endpoint, uint8(100-position))
+	}
+	command, _ := value.(string)
+	switch strings.ToUpper(command) {
+	case "OPEN":
+		return windowCovering.UpOpen(device.NetworkAddress, endpoint)
+	case "CLOSE":
+		return windowCovering.DownClose(device.NetworkAddress, endpoint)
+	case "STOP":
+		return windowCovering.Stop(device.NetworkAddress, endpoint)
+	}
+	return model.InvalidRequest("invalid value of [%s]: [%v]", key, value)
+}
+
+func doorLockToZigbee(context *Context, device *model.Device, endpoint uint8, key string, value interface{}, options model.State) error {
+	doorLock := context.Functions.Cluster().Local().DoorLock()
+	pinCode, _ := options["pin_code"].(string)
+	command, _ := value.(string)
+	switch strings.ToUpper(command) {
+	case "LOCK":
+		return doorLock.LockDoor(device.NetworkAddress, endpoint, pinCode)
+	case "UNLOCK":
+		return doorLock.UnlockDoor(device.NetworkAddress, endpoint, pinCode)
+	}
+	return model.InvalidRequest("invalid value of [%s]: [%v]", key, value)
+}
+
+func thermostatToZigbee(context *Context, device *model.Device, endpoint uint8, key string, value interface{}, options model.State) error {
+	thermostat := context.Functions.Cluster().Local().Thermostat()
+	if key == "occupied_heating_setpoint" {
+		celsius, ok := Number(value)
+		if !ok {
+			return model.InvalidRequest("invalid value of [%s]: [%v]", key, value)
+		}
+		return thermostat.SetOccupiedHeatingSetpoint(device.NetworkAddress, endpoint, celsius)
+	}
+	mode, _ := value.(string)
+	for _, systemMode := range systemModes {
+		if strings.EqualFold(systemMode.String(), mode) {
+			return thermostat.SetSystemMode(device.NetworkAddress, endpoint, systemMode)
+		}
+	}
+	return model.InvalidRequest("invalid value of [%s]: [%v]", key, value)
+}
+
+func TransitionTime(options model.State) uint16 {
+	if seconds, ok := Number(options["transition"]); ok && seconds > 0 {
+		return uint16(seconds * 10)
+	}
+	return 0
+}
+
+func Number(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+func onOff(on bool) string {
+	if on {
+		return "ON"
+	}
+	return "OFF"
+}
+
+func direction(mode uint8) string {
+	if mode == 0 {
+		return "up"
+	}
+	return "down"
+}
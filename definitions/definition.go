@@ -0,0 +1,118 @@
+package definitions
+
+import (
+	"github.com/dyrkin/zcl-go/cluster"
+	"github.com/dyrkin/zigbee-steward/functions"
+	"github.com/dyrkin/zigbee-steward/model"
+	"strings"
+)
+
+type Feature string
+
+const (
+	FeatureLight       Feature = "light"
+	FeatureSwitch      Feature = "switch"
+	FeatureBrightness  Feature = "brightness"
+	FeatureColorTemp   Feature = "color_temp"
+	FeatureColor       Feature = "color"
+	FeatureAction      Feature = "action"
+	FeatureBattery     Feature = "battery"
+	FeatureTemperature Feature = "temperature"
+	FeatureHumidity    Feature = "humidity"
+	FeaturePressure    Feature = "pressure"
+	FeatureIlluminance Feature = "illuminance"
+	FeatureOccupancy   Feature = "occupancy"
+	FeatureAlarm       Feature = "alarm"
+	FeatureCover       Feature = "cover"
+	FeatureClimate     Feature = "climate"
+	FeatureLock        Feature = "lock"
+)
+
+type Context struct {
+	Functions              *functions.Functions
+	CoordinatorIEEEAddress string
+	CoordinatorEndpoint    uint8
+}
+
+type FromZigbee struct {
+	Name    string
+	Cluster cluster.ClusterId
+	Convert func(message *model.DeviceIncomingMessage, state model.State)
+}
+
+type ToZigbee struct {
+	Name    string
+	Cluster cluster.ClusterId
+	Keys    []string
+	Convert func(context *Context, device *model.Device, endpoint uint8, key string, value interface{}, options model.State) error
+}
+
+type Configure struct {
+	Name string
+	Run  func(context *Context, device *model.Device) error
+
+	reportings []*Reporting
+}
+
+type Definition struct {
+	Vendor         string
+	Description    string
+	Manufacturer   string
+	ManufacturerId uint16
+	Models         []string
+	Exposes        []Feature
+	FromZigbee     []*FromZigbee
+	ToZigbee       []*ToZigbee
+	Configure      []*Configure
+}
+
+func (d *Definition) Matches(device *model.Device) bool {
+	if d.ManufacturerId != 0 && d.ManufacturerId != device.ManufacturerId {
+		return false
+	}
+	if d.Manufacturer != "" && d.Manufacturer != trim(device.Manufacturer) {
+		return false
+	}
+	deviceModel := trim(device.Model)
+	for _, m := range d.Models {
+		if m == deviceModel {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *Definition) HasFeature(feature Feature) bool {
+	for _, f := range d.Exposes {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *Definition) Convert(message *model.DeviceIncomingMessage) model.State {
+	state := model.State{}
+	clusterId := cluster.ClusterId(message.IncomingMessage.ClusterID)
+	for _, converter := range d.FromZigbee {
+		if converter.Cluster == clusterId {
+			converter.Convert(message, state)
+		}
+	}
+	return state
+}
+
+func (d *Definition) ToZigbeeConverter(key string) (*ToZigbee, bool) {
+	for _, converter := range d.ToZigbee {
+		for _, k := range converter.Keys {
+			if k == key {
+				return converter, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func trim(value string) string {
+	return strings.TrimSpace(strings.TrimRight(value, "\x00"))
+}
@@ -0,0 +1,51 @@
+package definitions
+
+import (
+	"github.com/dyrkin/zcl-go/cluster"
+	"github.com/dyrkin/zigbee-steward/clusters"
+	"github.com/dyrkin/zigbee-steward/model"
+)
+
+var clusterFeatures = []struct {
+	cluster  cluster.ClusterId
+	features []Feature
+}{
+	{cluster: cluster.OnOff, features: []Feature{FeatureSwitch}},
+	{cluster: cluster.LevelControl, features: []Feature{FeatureBrightness}},
+	{cluster: clusters.ColorControl, features: []Feature{FeatureColorTemp, FeatureColor}},
+	{cluster: cluster.PowerConfiguration, features: []Feature{FeatureBattery}},
+	{cluster: clusters.TemperatureMeasurement, features: []Feature{FeatureTemperature}},
+	{cluster: clusters.RelativeHumidity, features: []Feature{FeatureHumidity}},
+	{cluster: clusters.PressureMeasurement, features: []Feature{FeaturePressure}},
+	{cluster: clusters.IlluminanceMeasurement, features: []Feature{FeatureIlluminance}},
+	{cluster: clusters.OccupancySensing, features: []Feature{FeatureOccupancy}},
+	{cluster: clusters.IASZone, features: []Feature{FeatureAlarm}},
+	{cluster: clusters.WindowCovering, features: []Feature{FeatureCover}},
+	{cluster: clusters.Thermostat, features: []Feature{FeatureClimate}},
+	{cluster: clusters.DoorLock, features: []Feature{FeatureLock}},
+}
+
+func Features(device *model.Device, definition *Definition) []Feature {
+	var features []Feature
+	add := func(feature Feature) {
+		for _, f := range features {
+			if f == feature {
+				return
+			}
+		}
+		features = append(features, feature)
+	}
+	if definition != nil {
+		for _, feature := range definition.Exposes {
+			add(feature)
+		}
+	}
+	for _, cf := range clusterFeatures {
+		if len(device.InClusterEndpoints(uint16(cf.cluster))) > 0 {
+			for _, feature := range cf.features {
+				add(feature)
+			}
+		}
+	}
+	return features
+}
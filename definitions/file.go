@@ -0,0 +1,69 @@
+package definitions
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/dyrkin/zcl-go/cluster"
+	"gopkg.in/yaml.v2"
+	"path/filepath"
+	"strings"
+)
+
+type definitionSpec struct {
+	Vendor         string           `json:"vendor" yaml:"vendor"`
+	Description    string           `json:"description" yaml:"description"`
+	Manufacturer   string           `json:"manufacturer" yaml:"manufacturer"`
+	ManufacturerId uint16           `json:"manufacturerId" yaml:"manufacturerId"`
+	Models         []string         `json:"models" yaml:"models"`
+	Exposes        []Feature        `json:"exposes" yaml:"exposes"`
+	FromZigbee     []string         `json:"fromZigbee" yaml:"fromZigbee"`
+	ToZigbee       []string         `json:"toZigbee" yaml:"toZigbee"`
+	Configure      []*configureSpec `json:"configure" yaml:"configure"`
+}
+
+type configureSpec struct {
+	Bind      []uint16       `json:"bind" yaml:"bind"`
+	Reporting *reportingSpec `json:"reporting" yaml:"reporting"`
+}
+
+type reportingSpec struct {
+	Cluster    uint16       `json:"cluster" yaml:"cluster"`
+	Attributes []*Reporting `json:"attributes" yaml:"attributes"`
+}
+
+func (s *configureSpec) configure() (*Configure, error) {
+	switch {
+	case len(s.Bind) > 0 && s.Reporting == nil:
+		var clusterIds []cluster.ClusterId
+		for _, clusterId := range s.Bind {
+			clusterIds = append(clusterIds, cluster.ClusterId(clusterId))
+		}
+		return Bind(clusterIds...), nil
+	case len(s.Bind) == 0 && s.Reporting != nil:
+		if len(s.Reporting.Attributes) == 0 {
+			return nil, fmt.Errorf("no reporting attributes specified for cluster [%d]", s.Reporting.Cluster)
+		}
+		if _, err := reportingRecords(s.Reporting.Attributes); err != nil {
+			return nil, fmt.Errorf("invalid reporting of cluster [%d]: %s", s.Reporting.Cluster, err)
+		}
+		return ConfigureReporting(cluster.ClusterId(s.Reporting.Cluster), s.Reporting.Attributes...), nil
+	}
+	return nil, fmt.Errorf("configure step must specify either bind or reporting")
+}
+
+func parseFile(path string, data []byte) ([]*definitionSpec, error) {
+	var specs []*definitionSpec
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		if err := json.Unmarshal(data, &specs); err != nil {
+			return nil, err
+		}
+	case ".yaml", ".yml":
+		if err := yaml.UnmarshalStrict(data, &specs); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported file extension")
+	}
+	return specs, nil
+}
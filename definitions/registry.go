@@ -0,0 +1,154 @@
+package definitions
+
+import (
+	"fmt"
+	"github.com/dyrkin/zigbee-steward/model"
+	"github.com/dyrkin/zigbee-steward/tuya"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+type Registry struct {
+	mutex       sync.RWMutex
+	definitions []*Definition
+	fromZigbee  map[string]*FromZigbee
+	toZigbee    map[string]*ToZigbee
+}
+
+func NewRegistry() *Registry {
+	registry := &Registry{
+		fromZigbee: map[string]*FromZigbee{},
+		toZigbee:   map[string]*ToZigbee{},
+	}
+	registry.RegisterFromZigbee(fromZigbeeConverters...)
+	registry.RegisterToZigbee(toZigbeeConverters...)
+	registry.Register(builtinDefinitions...)
+	for _, device := range tuya.Devices() {
+		registry.Register(TuyaDefinitions(device)...)
+	}
+	return registry
+}
+
+func (r *Registry) Register(definitions ...*Definition) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.definitions = append(r.definitions, definitions...)
+}
+
+func (r *Registry) RegisterFromZigbee(converters ...*FromZigbee) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, converter := range converters {
+		r.fromZigbee[converter.Name] = converter
+	}
+}
+
+func (r *Registry) RegisterToZigbee(converters ...*ToZigbee) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, converter := range converters {
+		r.toZigbee[converter.Name] = converter
+	}
+}
+
+func (r *Registry) Definitions() []*Definition {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return append([]*Definition{}, r.definitions...)
+}
+
+func (r *Registry) Find(device *model.Device) (*Definition, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	for i := len(r.definitions) - 1; i >= 0; i-- {
+		if r.definitions[i].Matches(device) {
+			return r.definitions[i], true
+		}
+	}
+	return nil, false
+}
+
+func (r *Registry) LoadFile(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	specs, err := parseFile(path, data)
+	if err != nil {
+		return fmt.Errorf("unable to parse definitions file [%s]: %s", path, err)
+	}
+	var definitions []*Definition
+	for _, spec := range specs {
+		definition, err := r.resolve(spec)
+		if err != nil {
+			return fmt.Errorf("invalid definition in [%s]: %s", path, err)
+		}
+		definitions = append(definitions, definition)
+	}
+	r.Register(definitions...)
+	return nil
+}
+
+func (r *Registry) LoadDirectory(dir string) error {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if file.IsDir() || !supportedExtension(file.Name()) {
+			continue
+		}
+		if err := r.LoadFile(filepath.Join(dir, file.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Registry) resolve(spec *definitionSpec) (*Definition, error) {
+	if len(spec.Models) == 0 {
+		return nil, fmt.Errorf("no models specified for manufacturer [%s]", spec.Manufacturer)
+	}
+	definition := &Definition{
+		Vendor:         spec.Vendor,
+		Description:    spec.Description,
+		Manufacturer:   spec.Manufacturer,
+		ManufacturerId: spec.ManufacturerId,
+		Models:         spec.Models,
+		Exposes:        spec.Exposes,
+	}
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	for _, name := range spec.FromZigbee {
+		converter, ok := r.fromZigbee[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown fromZigbee converter [%s]", name)
+		}
+		definition.FromZigbee = append(definition.FromZigbee, converter)
+	}
+	for _, name := range spec.ToZigbee {
+		converter, ok := r.toZigbee[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown toZigbee converter [%s]", name)
+		}
+		definition.ToZigbee = append(definition.ToZigbee, converter)
+	}
+	for _, step := range spec.Configure {
+		configure, err := step.configure()
+		if err != nil {
+			return nil, err
+		}
+		definition.Configure = append(definition.Configure, configure)
+	}
+	return definition, nil
+}
+
+func supportedExtension(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json", ".yaml", ".yml":
+		return true
+	}
+	return false
+}
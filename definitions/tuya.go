@@ -0,0 +1,65 @@
+package definitions
+
+import (
+	"github.com/dyrkin/zigbee-steward/clusters"
+	"github.com/dyrkin/zigbee-steward/model"
+	"github.com/dyrkin/zigbee-steward/tuya"
+)
+
+func TuyaDefinitions(device *tuya.Device) []*Definition {
+	fromZigbee := &FromZigbee{
+		Name:    "tuya",
+		Cluster: clusters.Tuya,
+		Convert: func(message *model.DeviceIncomingMessage, state model.State) {
+			command, ok := message.IncomingMessage.Data.Command.(*clusters.TuyaDataCommand)
+			if !ok {
+				return
+			}
+			var datapoints []*model.TuyaDatapoint
+			for _, raw := range command.Datapoints {
+				if datapoint, err := tuya.Decode(raw); err == nil {
+					datapoints = append(datapoints, datapoint)
+				}
+			}
+			for name, value := range device.State(datapoints) {
+				state[name] = value
+			}
+		},
+	}
+	toZigbee := &ToZigbee{
+		Name:    "tuya",
+		Cluster: clusters.Tuya,
+		Convert: func(context *Context, d *model.Device, endpoint uint8, key string, value interface{}, options model.State) error {
+			mapping, ok := device.MappingByName(key)
+			if !ok {
+				return model.InvalidRequest("unsupported state [%s]", key)
+			}
+			datapoint, err := mapping.ToDatapoint(value)
+			if err != nil {
+				return err
+			}
+			_, err = context.Functions.Cluster().Local().Tuya().SetDatapoints(d.NetworkAddress, endpoint, datapoint)
+			return err
+		},
+	}
+	var exposes []Feature
+	for _, mapping := range device.Mappings {
+		exposes = append(exposes, Feature(mapping.Name))
+		if mapping.Writable {
+			toZigbee.Keys = append(toZigbee.Keys, mapping.Name)
+		}
+	}
+	var definitions []*Definition
+	for _, manufacturer := range device.Manufacturers {
+		definitions = append(definitions, &Definition{
+			Vendor:       device.Vendor,
+			Description:  device.Description,
+			Manufacturer: manufacturer,
+			Models:       device.Models,
+			Exposes:      exposes,
+			FromZigbee:   []*FromZigbee{fromZigbee},
+			ToZigbee:     []*ToZigbee{toZigbee},
+		})
+	}
+	return definitions
+}
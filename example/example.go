@@ -3,9 +3,9 @@ package main
 import (
 	"fmt"
 	"github.com/davecgh/go-spew/spew"
-	"github.com/dyrkin/zcl-go/cluster"
 	"github.com/dyrkin/zigbee-steward"
 	"github.com/dyrkin/zigbee-steward/configuration"
+	"github.com/dyrkin/zigbee-steward/definitions"
 	"github.com/dyrkin/zigbee-steward/model"
 	"sync"
 )
@@ -15,10 +15,16 @@ var devices = map[string]*model.Device{}
 
 func main() {
 
-	conf := configuration.Default()
+	conf, err := configuration.Load("configuration.yaml")
+	if err != nil {
+		panic(err)
+	}
 	conf.PermitJoin = true
 
-	stewie := steward.New(conf)
+	stewie, err := steward.New(conf)
+	if err != nil {
+		panic(err)
+	}
 
 	eventListener := func() {
 		for {
@@ -31,7 +37,10 @@ func main() {
 				saveDevice(device)
 			case deviceIncomingMessage := <-stewie.Channels().OnDeviceIncomingMessage():
 				fmt.Printf("Device received incoming message:\n%s", spew.Sdump(deviceIncomingMessage))
-				toggleIkeaBulb(stewie, deviceIncomingMessage)
+			case stateChange := <-stewie.Channels().OnDeviceStateChange():
+				fmt.Printf("Device state changed:\n%s", spew.Sdump(stateChange))
+			case click := <-stewie.Channels().OnDeviceClick():
+				toggleLights(stewie, click)
 			}
 		}
 	}
@@ -41,43 +50,31 @@ func main() {
 	infiniteWait()
 }
 
-func toggleIkeaBulb(stewie *steward.Steward, message *model.DeviceIncomingMessage) {
-	if isXiaomiButtonSingleClick(message) {
-		if ikeaBulb, registered := devices["TRADFRI bulb E27 W opal 1000lm"]; registered {
-			toggleTarget(stewie, ikeaBulb.NetworkAddress)
-		} else {
-			fmt.Println("IKEA bulb is not available")
+func toggleLights(stewie *steward.Steward, click *model.DeviceClick) {
+	if click.Click != model.ClickSingle {
+		return
+	}
+	for _, device := range devices {
+		if definition, ok := stewie.Definitions().Find(device); ok && definition.HasFeature(definitions.FeatureLight) {
+			toggleTarget(stewie, device.IEEEAddress)
 		}
 	}
 }
 
-func toggleTarget(stewie *steward.Steward, networkAddress string) {
+func toggleTarget(stewie *steward.Steward, ieeeAddress string) {
 	go func() {
-		stewie.Functions().Cluster().Local().OnOff().Toggle(networkAddress, 0xFF)
+		stewie.Device(ieeeAddress).OnOff().Toggle()
 	}()
 }
 
-func isXiaomiButtonSingleClick(message *model.DeviceIncomingMessage) bool {
-	command, ok := message.IncomingMessage.Data.Command.(*cluster.ReportAttributesCommand)
-
-	return ok && message.Device.Manufacturer == "LUMI" &&
-		message.Device.Model == "lumi.remote.b186acn01\x00\x00\x00" &&
-		isSingleClick(command)
-}
-
-func isSingleClick(command *cluster.ReportAttributesCommand) bool {
-	click, ok := command.AttributeReports[0].Attribute.Value.(uint64)
-	return ok && click == uint64(1)
-}
-
 func saveDevice(device *model.Device) {
 	fmt.Printf("Registering device:\n%s", spew.Sdump(device))
-	devices[device.Model] = device
+	devices[device.IEEEAddress] = device
 }
 
 func deleteDevice(device *model.Device) {
 	fmt.Printf("Unregistering device:\n%s", spew.Sdump(device))
-	delete(devices, device.Model)
+	delete(devices, device.IEEEAddress)
 }
 
 func infiniteWait() {
@@ -85,14 +82,3 @@ func infiniteWait() {
 	wg.Add(1)
 	wg.Wait()
 }
-
-//TODO Remove this
-func subscribeForLevelControlEvents(stewie *steward.Steward, device *model.Device) {
-	if device.Manufacturer == "IKEA of Sweden" && device.Model == "TRADFRI wireless dimmer" {
-		go func() {
-			rsp, err := stewie.Functions().Generic().Bind(device.NetworkAddress, device.IEEEAddress, 1,
-				uint16(cluster.LevelControl), stewie.Configuration().IEEEAddress, 1)
-			fmt.Printf("Bind result: [%v] [%s]", rsp, err)
-		}()
-	}
-}
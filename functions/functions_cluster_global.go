@@ -1,36 +1,145 @@
 package functions
 
 import (
+	"fmt"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/dyrkin/bin"
 	"github.com/dyrkin/zcl-go"
 	"github.com/dyrkin/zcl-go/cluster"
 	"github.com/dyrkin/zcl-go/frame"
 	"github.com/dyrkin/zigbee-steward/coordinator"
+	"github.com/dyrkin/zigbee-steward/logger"
 	"github.com/dyrkin/znp-go"
 )
 
+type writeAttributesSuccessResponse struct {
+	Status cluster.ZclStatus
+}
+
 type GlobalClusterFunctions struct {
 	coordinator *coordinator.Coordinator
 	zcl         *zcl.Zcl
+	endpoint    uint8
+}
+
+const broadcastEndpoint uint8 = 0xff
+
+func (f *GlobalClusterFunctions) Endpoint(endpoint uint8) *GlobalClusterFunctions {
+	return &GlobalClusterFunctions{
+		coordinator: f.coordinator,
+		zcl:         f.zcl,
+		endpoint:    endpoint,
+	}
 }
 
 func (f *GlobalClusterFunctions) ReadAttributes(nwkAddress string, clusterId cluster.ClusterId, attributeIds []uint16) (*cluster.ReadAttributesResponse, error) {
 	response, err := f.globalCommand(nwkAddress, clusterId, 0x00, &cluster.ReadAttributesCommand{attributeIds})
-
-	if err == nil {
-		return response.(*cluster.ReadAttributesResponse), nil
+	if err != nil {
+		return nil, err
 	}
-	return nil, err
+	if readAttributesResponse, ok := response.(*cluster.ReadAttributesResponse); ok {
+		return readAttributesResponse, nil
+	}
+	return nil, fmt.Errorf("unexpected response to read attributes on cluster [%d]: %T", clusterId, response)
 }
 
 func (f *GlobalClusterFunctions) WriteAttributes(nwkAddress string, clusterId cluster.ClusterId, writeAttributeRecords []*cluster.WriteAttributeRecord) (*cluster.WriteAttributesResponse, error) {
 	response, err := f.globalCommand(nwkAddress, clusterId, 0x02, &cluster.WriteAttributesCommand{writeAttributeRecords})
+	if err != nil {
+		return nil, err
+	}
+	if writeAttributesResponse, ok := response.(*cluster.WriteAttributesResponse); ok {
+		return writeAttributesResponse, nil
+	}
+	return nil, fmt.Errorf("unexpected response to write attributes on cluster [%d]: %T", clusterId, response)
+}
+
+func (f *GlobalClusterFunctions) ConfigureReporting(nwkAddress string, clusterId cluster.ClusterId, attributeReportingConfigurationRecords []*cluster.AttributeReportingConfigurationRecord) (*cluster.ConfigureReportingResponse, error) {
+	response, err := f.globalCommand(nwkAddress, clusterId, 0x06, &cluster.ConfigureReportingCommand{AttributeReportingConfigurationRecords: attributeReportingConfigurationRecords})
+	if err != nil {
+		return nil, err
+	}
+	if configureReportingResponse, ok := response.(*cluster.ConfigureReportingResponse); ok {
+		return configureReportingResponse, nil
+	}
+	return nil, fmt.Errorf("unexpected response to configure reporting on cluster [%d]: %T", clusterId, response)
+}
 
-	if err == nil {
-		return response.(*cluster.WriteAttributesResponse), nil
+func (f *GlobalClusterFunctions) ReadAttributesResponse(nwkAddress string, endpoint uint8, srcEndpoint uint8, clusterId cluster.ClusterId, transactionId uint8, readAttributeStatuses []*cluster.ReadAttributeStatus) error {
+	return f.globalResponse(nwkAddress, endpoint, srcEndpoint, clusterId, transactionId, frame.DirectionServerClient, 0x01,
+		&cluster.ReadAttributesResponse{ReadAttributeStatuses: readAttributeStatuses})
+}
+
+func (f *GlobalClusterFunctions) WriteAttributesResponse(nwkAddress string, endpoint uint8, srcEndpoint uint8, clusterId cluster.ClusterId, transactionId uint8, writeAttributeStatuses []*cluster.WriteAttributeStatus) error {
+	var failedStatuses []*cluster.WriteAttributeStatus
+	for _, status := range writeAttributeStatuses {
+		if status.Status != cluster.ZclStatusSuccess {
+			failedStatuses = append(failedStatuses, status)
+		}
 	}
-	return nil, err
+	if len(failedStatuses) == 0 {
+		return f.globalResponse(nwkAddress, endpoint, srcEndpoint, clusterId, transactionId, frame.DirectionServerClient, 0x04,
+			&writeAttributesSuccessResponse{Status: cluster.ZclStatusSuccess})
+	}
+	return f.globalResponse(nwkAddress, endpoint, srcEndpoint, clusterId, transactionId, frame.DirectionServerClient, 0x04,
+		&cluster.WriteAttributesResponse{WriteAttributeStatuses: failedStatuses})
+}
+
+func (f *GlobalClusterFunctions) DiscoverAttributesResponse(nwkAddress string, endpoint uint8, srcEndpoint uint8, clusterId cluster.ClusterId, transactionId uint8, discoveryComplete bool, attributeInformations []*cluster.AttributeInformation) error {
+	return f.globalResponse(nwkAddress, endpoint, srcEndpoint, clusterId, transactionId, frame.DirectionServerClient, 0x0d,
+		&cluster.DiscoverAttributesResponse{
+			DiscoveryComplete:     discoveryCompleteFlag(discoveryComplete),
+			AttributeInformations: attributeInformations,
+		})
+}
+
+func (f *GlobalClusterFunctions) DiscoverAttributesExtendedResponse(nwkAddress string, endpoint uint8, srcEndpoint uint8, clusterId cluster.ClusterId, transactionId uint8, discoveryComplete bool, extendedAttributeInformations []*cluster.ExtendedAttributeInformation) error {
+	return f.globalResponse(nwkAddress, endpoint, srcEndpoint, clusterId, transactionId, frame.DirectionServerClient, 0x16,
+		&cluster.DiscoverAttributesExtendedResponse{
+			DiscoveryComplete:             discoveryCompleteFlag(discoveryComplete),
+			ExtendedAttributeInformations: extendedAttributeInformations,
+		})
+}
+
+func (f *GlobalClusterFunctions) DefaultResponse(nwkAddress string, endpoint uint8, srcEndpoint uint8, clusterId cluster.ClusterId, transactionId uint8, direction frame.Direction, commandId uint8, status cluster.ZclStatus) error {
+	return f.globalResponse(nwkAddress, endpoint, srcEndpoint, clusterId, transactionId, direction, 0x0b,
+		&cluster.DefaultResponseCommand{CommandID: commandId, Status: status})
+}
+
+func (f *GlobalClusterFunctions) readAttributeValues(nwkAddress string, clusterId cluster.ClusterId, attributeIds []uint16) (map[uint16]interface{}, error) {
+	response, err := f.ReadAttributes(nwkAddress, clusterId, attributeIds)
+	if err != nil {
+		return nil, err
+	}
+	values := map[uint16]interface{}{}
+	for _, status := range response.ReadAttributeStatuses {
+		if status.Status == cluster.ZclStatusSuccess && status.Attribute != nil {
+			values[status.AttributeID] = status.Attribute.Value
+		}
+	}
+	return values, nil
+}
+
+func (f *GlobalClusterFunctions) writeAttribute(nwkAddress string, clusterId cluster.ClusterId, attributeId uint16, dataType cluster.ZclDataType, value interface{}) error {
+	writeAttributeRecords := []*cluster.WriteAttributeRecord{
+		{
+			AttributeID: attributeId,
+			Attribute: &cluster.Attribute{
+				DataType: dataType,
+				Value:    value,
+			},
+		},
+	}
+	response, err := f.WriteAttributes(nwkAddress, clusterId, writeAttributeRecords)
+	if err != nil {
+		return err
+	}
+	for _, status := range response.WriteAttributeStatuses {
+		if status.Status != cluster.ZclStatusSuccess {
+			return fmt.Errorf("unable to write attribute [%d] on cluster [%d]. Status: [%d]", attributeId, clusterId, status.Status)
+		}
+	}
+	return nil
 }
 
 func (f *GlobalClusterFunctions) globalCommand(nwkAddress string, clusterId cluster.ClusterId, commandId uint8, command interface{}) (interface{}, error) {
@@ -47,15 +156,43 @@ func (f *GlobalClusterFunctions) globalCommand(nwkAddress string, clusterId clus
 		return nil, err
 	}
 
-	response, err := f.coordinator.DataRequest(nwkAddress, 255, 1, uint16(clusterId), options, 15, bin.Encode(frm))
-	if err == nil {
-		zclIncomingMessage, err := f.zcl.ToZclIncomingMessage(response)
-		if err == nil {
-			return zclIncomingMessage.Data.Command, nil
-		} else {
-			log.Errorf("Unsupported data response message:\n%s\n", func() string { return spew.Sdump(response) })
-		}
+	endpoint := f.endpoint
+	if endpoint == 0 {
+		endpoint = broadcastEndpoint
+	}
+	response, err := f.coordinator.DataRequest(nwkAddress, endpoint, 1, uint16(clusterId), options, 15, bin.Encode(frm))
+	if err != nil {
+		return nil, err
+	}
+	zclIncomingMessage, err := f.zcl.ToZclIncomingMessage(response)
+	if err != nil {
+		log.Errorf("Unsupported data response message:\n%s\n", logger.Lazy(func() string { return spew.Sdump(response) }))
+		return nil, err
+	}
+	return zclIncomingMessage.Data.Command, nil
+}
+
+func (f *GlobalClusterFunctions) globalResponse(nwkAddress string, endpoint uint8, srcEndpoint uint8, clusterId cluster.ClusterId, transactionId uint8, direction frame.Direction, commandId uint8, command interface{}) error {
+	options := &znp.AfDataRequestOptions{}
+	frm, err := frame.New().
+		DisableDefaultResponse(true).
+		FrameType(frame.FrameTypeGlobal).
+		Direction(direction).
+		CommandId(commandId).
+		Command(command).
+		Build()
+
+	if err != nil {
+		return err
+	}
+	frm.TransactionSequenceNumber = transactionId
+
+	return f.coordinator.DataRequestNoResponse(nwkAddress, endpoint, srcEndpoint, uint16(clusterId), options, 15, bin.Encode(frm))
+}
 
+func discoveryCompleteFlag(discoveryComplete bool) uint8 {
+	if discoveryComplete {
+		return 1
 	}
-	return nil, err
+	return 0
 }
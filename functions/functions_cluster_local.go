@@ -7,13 +7,30 @@ import (
 	"github.com/dyrkin/zcl-go"
 	"github.com/dyrkin/zcl-go/cluster"
 	"github.com/dyrkin/zcl-go/frame"
+	"github.com/dyrkin/zigbee-steward/clusters"
 	"github.com/dyrkin/zigbee-steward/coordinator"
+	"github.com/dyrkin/zigbee-steward/logger"
 	"github.com/dyrkin/znp-go"
 )
 
+var nextNotificationTransactionId = frame.MakeDefaultTransactionIdProvider()
+
 type LocalClusterFunctions struct {
-	onOff        *OnOff
-	levelControl *LevelControl
+	onOff          *OnOff
+	levelControl   *LevelControl
+	colorControl   *ColorControl
+	iasZone        *IASZone
+	iasWd          *IASWD
+	iasAce         *IASACE
+	doorLock       *DoorLock
+	windowCovering *WindowCovering
+	thermostat     *Thermostat
+	fanControl     *FanControl
+	thermostatUI   *ThermostatUIConfiguration
+	ota            *OTA
+	tuya           *Tuya
+	coordinator    *coordinator.Coordinator
+	zcl            *zcl.Zcl
 }
 
 type LocalCluster struct {
@@ -23,6 +40,10 @@ type LocalCluster struct {
 }
 
 func NewLocalClusterFunctions(coordinator *coordinator.Coordinator, zcl *zcl.Zcl) *LocalClusterFunctions {
+	global := &GlobalClusterFunctions{
+		coordinator: coordinator,
+		zcl:         zcl,
+	}
 	return &LocalClusterFunctions{
 		onOff: &OnOff{
 			LocalCluster: &LocalCluster{
@@ -38,6 +59,89 @@ func NewLocalClusterFunctions(coordinator *coordinator.Coordinator, zcl *zcl.Zcl
 				zcl:         zcl,
 			},
 		},
+		colorControl: &ColorControl{
+			LocalCluster: &LocalCluster{
+				clusterId:   clusters.ColorControl,
+				coordinator: coordinator,
+				zcl:         zcl,
+			},
+		},
+		iasZone: &IASZone{
+			LocalCluster: &LocalCluster{
+				clusterId:   clusters.IASZone,
+				coordinator: coordinator,
+				zcl:         zcl,
+			},
+		},
+		iasWd: &IASWD{
+			LocalCluster: &LocalCluster{
+				clusterId:   clusters.IASWD,
+				coordinator: coordinator,
+				zcl:         zcl,
+			},
+		},
+		iasAce: &IASACE{
+			LocalCluster: &LocalCluster{
+				clusterId:   clusters.IASACE,
+				coordinator: coordinator,
+				zcl:         zcl,
+			},
+		},
+		doorLock: &DoorLock{
+			LocalCluster: &LocalCluster{
+				clusterId:   clusters.DoorLock,
+				coordinator: coordinator,
+				zcl:         zcl,
+			},
+		},
+		windowCovering: &WindowCovering{
+			LocalCluster: &LocalCluster{
+				clusterId:   clusters.WindowCovering,
+				coordinator: coordinator,
+				zcl:         zcl,
+			},
+			global: global,
+		},
+		thermostat: &Thermostat{
+			LocalCluster: &LocalCluster{
+				clusterId:   clusters.Thermostat,
+				coordinator: coordinator,
+				zcl:         zcl,
+			},
+			global: global,
+		},
+		fanControl: &FanControl{
+			LocalCluster: &LocalCluster{
+				clusterId:   clusters.FanControl,
+				coordinator: coordinator,
+				zcl:         zcl,
+			},
+			global: global,
+		},
+		thermostatUI: &ThermostatUIConfiguration{
+			LocalCluster: &LocalCluster{
+				clusterId:   clusters.ThermostatUIConfiguration,
+				coordinator: coordinator,
+				zcl:         zcl,
+			},
+			global: global,
+		},
+		ota: &OTA{
+			LocalCluster: &LocalCluster{
+				clusterId:   cluster.OTA,
+				coordinator: coordinator,
+				zcl:         zcl,
+			},
+		},
+		tuya: &Tuya{
+			LocalCluster: &LocalCluster{
+				clusterId:   clusters.Tuya,
+				coordinator: coordinator,
+				zcl:         zcl,
+			},
+		},
+		coordinator: coordinator,
+		zcl:         zcl,
 	}
 }
 
@@ -49,7 +153,66 @@ func (f *LocalClusterFunctions) LevelControl() *LevelControl {
 	return f.levelControl
 }
 
+func (f *LocalClusterFunctions) ColorControl() *ColorControl {
+	return f.colorControl
+}
+
+func (f *LocalClusterFunctions) IASZone() *IASZone {
+	return f.iasZone
+}
+
+func (f *LocalClusterFunctions) IASWD() *IASWD {
+	return f.iasWd
+}
+
+func (f *LocalClusterFunctions) IASACE() *IASACE {
+	return f.iasAce
+}
+
+func (f *LocalClusterFunctions) DoorLock() *DoorLock {
+	return f.doorLock
+}
+
+func (f *LocalClusterFunctions) WindowCovering() *WindowCovering {
+	return f.windowCovering
+}
+
+func (f *LocalClusterFunctions) Thermostat() *Thermostat {
+	return f.thermostat
+}
+
+func (f *LocalClusterFunctions) FanControl() *FanControl {
+	return f.fanControl
+}
+
+func (f *LocalClusterFunctions) ThermostatUIConfiguration() *ThermostatUIConfiguration {
+	return f.thermostatUI
+}
+
+func (f *LocalClusterFunctions) OTA() *OTA {
+	return f.ota
+}
+
+func (f *LocalClusterFunctions) Tuya() *Tuya {
+	return f.tuya
+}
+
+func (f *LocalClusterFunctions) Generic(clusterId cluster.ClusterId) *GenericCluster {
+	return &GenericCluster{
+		LocalCluster: &LocalCluster{
+			clusterId:   clusterId,
+			coordinator: f.coordinator,
+			zcl:         f.zcl,
+		},
+	}
+}
+
 func (f *LocalCluster) localCommand(nwkAddress string, endpoint uint8, commandId uint8, command interface{}) error {
+	_, err := f.localCommandResponse(nwkAddress, endpoint, commandId, command)
+	return err
+}
+
+func (f *LocalCluster) localCommandResponse(nwkAddress string, endpoint uint8, commandId uint8, command interface{}) (interface{}, error) {
 	options := &znp.AfDataRequestOptions{}
 	frm, err := frame.New().
 		DisableDefaultResponse(false).
@@ -60,22 +223,44 @@ func (f *LocalCluster) localCommand(nwkAddress string, endpoint uint8, commandId
 		Build()
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	response, err := f.coordinator.DataRequest(nwkAddress, endpoint, 1, uint16(f.clusterId), options, 15, bin.Encode(frm))
-	if err == nil {
-		zclIncomingMessage, err := f.zcl.ToZclIncomingMessage(response)
-		if err == nil {
-			zclCommand := zclIncomingMessage.Data.Command.(*cluster.DefaultResponseCommand)
-			if zclCommand.Status != cluster.ZclStatusSuccess {
-				return fmt.Errorf("unable to run command [%d] on cluster [%d]. Status: [%d]", commandId, f.clusterId, zclCommand.Status)
-			}
-			return nil
-		} else {
-			log.Errorf("Unsupported data response message:\n%s\n", func() string { return spew.Sdump(response) })
-		}
+	if err != nil {
+		return nil, err
+	}
+	zclIncomingMessage, err := f.zcl.ToZclIncomingMessage(response)
+	if err != nil {
+		log.Errorf("Unsupported data response message:\n%s\n", logger.Lazy(func() string { return spew.Sdump(response) }))
+		return nil, err
+	}
+	zclCommand, ok := zclIncomingMessage.Data.Command.(*cluster.DefaultResponseCommand)
+	if ok && zclCommand.Status != cluster.ZclStatusSuccess {
+		return nil, fmt.Errorf("unable to run command [%d] on cluster [%d]. Status: [%d]", commandId, f.clusterId, zclCommand.Status)
+	}
+	return zclIncomingMessage.Data.Command, nil
+}
 
+func (f *LocalCluster) localResponse(nwkAddress string, endpoint uint8, transactionId uint8, commandId uint8, command interface{}) error {
+	options := &znp.AfDataRequestOptions{}
+	frm, err := frame.New().
+		DisableDefaultResponse(true).
+		FrameType(frame.FrameTypeLocal).
+		Direction(frame.DirectionServerClient).
+		CommandId(commandId).
+		Command(command).
+		Build()
+
+	if err != nil {
+		return err
 	}
-	return err
+	frm.TransactionSequenceNumber = transactionId
+
+	return f.coordinator.DataRequestNoResponse(nwkAddress, endpoint, 1, uint16(f.clusterId), options, 15, bin.Encode(frm))
+}
+
+// localNotification sends an unsolicited server to client command, which has no client transaction to answer
+func (f *LocalCluster) localNotification(nwkAddress string, endpoint uint8, commandId uint8, command interface{}) error {
+	return f.localResponse(nwkAddress, endpoint, nextNotificationTransactionId(), commandId, command)
 }
@@ -0,0 +1,51 @@
+package functions
+
+import (
+	"github.com/dyrkin/zigbee-steward/clusters"
+)
+
+type ColorControl struct {
+	*LocalCluster
+}
+
+func (f *ColorControl) MoveToHue(nwkAddress string, endpoint uint8, hue uint8, direction uint8, transitionTime uint16) error {
+	return f.localCommand(nwkAddress, endpoint, 0x00, &clusters.MoveToHueCommand{
+		Hue:            hue,
+		Direction:      direction,
+		TransitionTime: transitionTime,
+	})
+}
+
+func (f *ColorControl) MoveToSaturation(nwkAddress string, endpoint uint8, saturation uint8, transitionTime uint16) error {
+	return f.localCommand(nwkAddress, endpoint, 0x03, &clusters.MoveToSaturationCommand{
+		Saturation:     saturation,
+		TransitionTime: transitionTime,
+	})
+}
+
+func (f *ColorControl) MoveToHueAndSaturation(nwkAddress string, endpoint uint8, hue uint8, saturation uint8, transitionTime uint16) error {
+	return f.localCommand(nwkAddress, endpoint, 0x06, &clusters.MoveToHueAndSaturationCommand{
+		Hue:            hue,
+		Saturation:     saturation,
+		TransitionTime: transitionTime,
+	})
+}
+
+func (f *ColorControl) MoveToColor(nwkAddress string, endpoint uint8, colorX uint16, colorY uint16, transitionTime uint16) error {
+	return f.localCommand(nwkAddress, endpoint, 0x07, &clusters.MoveToColorCommand{
+		ColorX:         colorX,
+		ColorY:         colorY,
+		TransitionTime: transitionTime,
+	})
+}
+
+func (f *ColorControl) MoveToColorTemperature(nwkAddress string, endpoint uint8, colorTemperatureMireds uint16, transitionTime uint16) error {
+	return f.localCommand(nwkAddress, endpoint, 0x0a, &clusters.MoveToColorTemperatureCommand{
+		ColorTemperatureMireds: colorTemperatureMireds,
+		TransitionTime:         transitionTime,
+	})
+}
+
+func (f *ColorControl) StopMoveStep(nwkAddress string, endpoint uint8) error {
+	return f.localCommand(nwkAddress, endpoint, 0x47, &clusters.StopMoveStepCommand{})
+}
@@ -0,0 +1,191 @@
+package functions
+
+import (
+	"fmt"
+	"github.com/dyrkin/zigbee-steward/clusters"
+	"github.com/dyrkin/zigbee-steward/model"
+	"time"
+)
+
+type DoorLock struct {
+	*LocalCluster
+}
+
+func (f *DoorLock) LockDoor(nwkAddress string, endpoint uint8, pinCode string) error {
+	return f.statusCommand(nwkAddress, endpoint, 0x00, &clusters.LockDoorCommand{PINCode: pinCode})
+}
+
+func (f *DoorLock) UnlockDoor(nwkAddress string, endpoint uint8, pinCode string) error {
+	return f.statusCommand(nwkAddress, endpoint, 0x01, &clusters.UnlockDoorCommand{PINCode: pinCode})
+}
+
+func (f *DoorLock) Toggle(nwkAddress string, endpoint uint8, pinCode string) error {
+	return f.statusCommand(nwkAddress, endpoint, 0x02, &clusters.ToggleDoorCommand{PINCode: pinCode})
+}
+
+func (f *DoorLock) UnlockWithTimeout(nwkAddress string, endpoint uint8, timeout uint16, pinCode string) error {
+	return f.statusCommand(nwkAddress, endpoint, 0x03, &clusters.UnlockWithTimeoutCommand{Timeout: timeout, PINCode: pinCode})
+}
+
+func (f *DoorLock) GetLogRecord(nwkAddress string, endpoint uint8, logIndex uint16) (*clusters.GetLogRecordResponseCommand, error) {
+	response, err := f.localCommandResponse(nwkAddress, endpoint, 0x04, &clusters.GetLogRecordCommand{LogIndex: logIndex})
+	if err != nil {
+		return nil, err
+	}
+	typedResponse, ok := response.(*clusters.GetLogRecordResponseCommand)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response to get log record: %T", response)
+	}
+	return typedResponse, nil
+}
+
+func (f *DoorLock) SetPINCode(nwkAddress string, endpoint uint8, userId uint16, userStatus model.LockUserStatus, userType model.LockUserType, pinCode string) error {
+	return f.statusCommand(nwkAddress, endpoint, 0x05, &clusters.SetPINCodeCommand{
+		UserID:     userId,
+		UserStatus: uint8(userStatus),
+		UserType:   uint8(userType),
+		PINCode:    pinCode,
+	})
+}
+
+func (f *DoorLock) GetPINCode(nwkAddress string, endpoint uint8, userId uint16) (*clusters.GetPINCodeResponseCommand, error) {
+	response, err := f.localCommandResponse(nwkAddress, endpoint, 0x06, &clusters.GetPINCodeCommand{UserID: userId})
+	if err != nil {
+		return nil, err
+	}
+	typedResponse, ok := response.(*clusters.GetPINCodeResponseCommand)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response to get PIN code: %T", response)
+	}
+	return typedResponse, nil
+}
+
+func (f *DoorLock) ClearPINCode(nwkAddress string, endpoint uint8, userId uint16) error {
+	return f.statusCommand(nwkAddress, endpoint, 0x07, &clusters.ClearPINCodeCommand{UserID: userId})
+}
+
+func (f *DoorLock) ClearAllPINCodes(nwkAddress string, endpoint uint8) error {
+	return f.statusCommand(nwkAddress, endpoint, 0x08, &clusters.ClearAllPINCodesCommand{})
+}
+
+func (f *DoorLock) SetUserStatus(nwkAddress string, endpoint uint8, userId uint16, userStatus model.LockUserStatus) error {
+	return f.statusCommand(nwkAddress, endpoint, 0x09, &clusters.SetUserStatusCommand{UserID: userId, UserStatus: uint8(userStatus)})
+}
+
+func (f *DoorLock) GetUserStatus(nwkAddress string, endpoint uint8, userId uint16) (model.LockUserStatus, error) {
+	response, err := f.localCommandResponse(nwkAddress, endpoint, 0x0a, &clusters.GetUserStatusCommand{UserID: userId})
+	if err != nil {
+		return model.LockUserStatusNotSupported, err
+	}
+	statusResponse, ok := response.(*clusters.GetUserStatusResponseCommand)
+	if !ok {
+		return model.LockUserStatusNotSupported, fmt.Errorf("unexpected response to get user status: %T", response)
+	}
+	return model.LockUserStatus(statusResponse.UserStatus), nil
+}
+
+func (f *DoorLock) SetWeekDaySchedule(nwkAddress string, endpoint uint8, scheduleId uint8, userId uint16, daysMask uint8,
+	startHour uint8, startMinute uint8, endHour uint8, endMinute uint8) error {
+	return f.statusCommand(nwkAddress, endpoint, 0x0b, &clusters.SetWeekDayScheduleCommand{
+		ScheduleID:  scheduleId,
+		UserID:      userId,
+		DaysMask:    daysMask,
+		StartHour:   startHour,
+		StartMinute: startMinute,
+		EndHour:     endHour,
+		EndMinute:   endMinute,
+	})
+}
+
+func (f *DoorLock) GetWeekDaySchedule(nwkAddress string, endpoint uint8, scheduleId uint8, userId uint16) (*clusters.GetWeekDayScheduleResponseCommand, error) {
+	response, err := f.localCommandResponse(nwkAddress, endpoint, 0x0c, &clusters.GetWeekDayScheduleCommand{ScheduleID: scheduleId, UserID: userId})
+	if err != nil {
+		return nil, err
+	}
+	typedResponse, ok := response.(*clusters.GetWeekDayScheduleResponseCommand)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response to get week day schedule: %T", response)
+	}
+	return typedResponse, nil
+}
+
+func (f *DoorLock) ClearWeekDaySchedule(nwkAddress string, endpoint uint8, scheduleId uint8, userId uint16) error {
+	return f.statusCommand(nwkAddress, endpoint, 0x0d, &clusters.ClearWeekDayScheduleCommand{ScheduleID: scheduleId, UserID: userId})
+}
+
+func (f *DoorLock) SetYearDaySchedule(nwkAddress string, endpoint uint8, scheduleId uint8, userId uint16, localStartTime time.Time, localEndTime time.Time) error {
+	return f.statusCommand(nwkAddress, endpoint, 0x0e, &clusters.SetYearDayScheduleCommand{
+		ScheduleID:     scheduleId,
+		UserID:         userId,
+		LocalStartTime: clusters.FromTime(localStartTime),
+		LocalEndTime:   clusters.FromTime(localEndTime),
+	})
+}
+
+func (f *DoorLock) GetYearDaySchedule(nwkAddress string, endpoint uint8, scheduleId uint8, userId uint16) (*clusters.GetYearDayScheduleResponseCommand, error) {
+	response, err := f.localCommandResponse(nwkAddress, endpoint, 0x0f, &clusters.GetYearDayScheduleCommand{ScheduleID: scheduleId, UserID: userId})
+	if err != nil {
+		return nil, err
+	}
+	typedResponse, ok := response.(*clusters.GetYearDayScheduleResponseCommand)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response to get year day schedule: %T", response)
+	}
+	return typedResponse, nil
+}
+
+func (f *DoorLock) ClearYearDaySchedule(nwkAddress string, endpoint uint8, scheduleId uint8, userId uint16) error {
+	return f.statusCommand(nwkAddress, endpoint, 0x10, &clusters.ClearYearDayScheduleCommand{ScheduleID: scheduleId, UserID: userId})
+}
+
+func (f *DoorLock) SetHolidaySchedule(nwkAddress string, endpoint uint8, holidayScheduleId uint8, localStartTime time.Time, localEndTime time.Time,
+	operatingMode model.LockOperatingMode) error {
+	return f.statusCommand(nwkAddress, endpoint, 0x11, &clusters.SetHolidayScheduleCommand{
+		HolidayScheduleID:          holidayScheduleId,
+		LocalStartTime:             clusters.FromTime(localStartTime),
+		LocalEndTime:               clusters.FromTime(localEndTime),
+		OperatingModeDuringHoliday: uint8(operatingMode),
+	})
+}
+
+func (f *DoorLock) GetHolidaySchedule(nwkAddress string, endpoint uint8, holidayScheduleId uint8) (*clusters.GetHolidayScheduleResponseCommand, error) {
+	response, err := f.localCommandResponse(nwkAddress, endpoint, 0x12, &clusters.GetHolidayScheduleCommand{HolidayScheduleID: holidayScheduleId})
+	if err != nil {
+		return nil, err
+	}
+	typedResponse, ok := response.(*clusters.GetHolidayScheduleResponseCommand)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response to get holiday schedule: %T", response)
+	}
+	return typedResponse, nil
+}
+
+func (f *DoorLock) ClearHolidaySchedule(nwkAddress string, endpoint uint8, holidayScheduleId uint8) error {
+	return f.statusCommand(nwkAddress, endpoint, 0x13, &clusters.ClearHolidayScheduleCommand{HolidayScheduleID: holidayScheduleId})
+}
+
+func (f *DoorLock) SetUserType(nwkAddress string, endpoint uint8, userId uint16, userType model.LockUserType) error {
+	return f.statusCommand(nwkAddress, endpoint, 0x14, &clusters.SetUserTypeCommand{UserID: userId, UserType: uint8(userType)})
+}
+
+func (f *DoorLock) GetUserType(nwkAddress string, endpoint uint8, userId uint16) (model.LockUserType, error) {
+	response, err := f.localCommandResponse(nwkAddress, endpoint, 0x15, &clusters.GetUserTypeCommand{UserID: userId})
+	if err != nil {
+		return model.LockUserTypeNotSupported, err
+	}
+	typeResponse, ok := response.(*clusters.GetUserTypeResponseCommand)
+	if !ok {
+		return model.LockUserTypeNotSupported, fmt.Errorf("unexpected response to get user type: %T", response)
+	}
+	return model.LockUserType(typeResponse.UserType), nil
+}
+
+func (f *DoorLock) statusCommand(nwkAddress string, endpoint uint8, commandId uint8, command interface{}) error {
+	response, err := f.localCommandResponse(nwkAddress, endpoint, commandId, command)
+	if err == nil {
+		if statusResponse, ok := response.(*clusters.DoorLockStatusResponseCommand); ok && statusResponse.Status != 0 {
+			return fmt.Errorf("unable to run command [%d] on cluster [%d]. Status: [%d]", commandId, f.clusterId, statusResponse.Status)
+		}
+	}
+	return err
+}
@@ -0,0 +1,42 @@
+package functions
+
+import (
+	"fmt"
+	"github.com/dyrkin/zcl-go/cluster"
+	"reflect"
+)
+
+type GenericCluster struct {
+	*LocalCluster
+}
+
+func (f *GenericCluster) NewCommand(commandId uint8) (interface{}, error) {
+	definition, ok := f.zcl.ClusterLibrary().Clusters()[f.clusterId]
+	if !ok {
+		return nil, fmt.Errorf("unknown cluster [%d]", f.clusterId)
+	}
+	if definition.CommandDescriptors == nil {
+		return nil, fmt.Errorf("cluster [%d] has no commands", f.clusterId)
+	}
+	descriptor, ok := definition.CommandDescriptors.Received[commandId]
+	if !ok {
+		return nil, fmt.Errorf("unknown command [%d] of cluster [%d]", commandId, f.clusterId)
+	}
+	return reflect.New(reflect.TypeOf(descriptor.Command).Elem()).Interface(), nil
+}
+
+func (f *GenericCluster) AttributeType(attributeId uint16) (cluster.ZclDataType, bool) {
+	definition, ok := f.zcl.ClusterLibrary().Clusters()[f.clusterId]
+	if !ok {
+		return cluster.ZclDataTypeUnknown, false
+	}
+	descriptor, ok := definition.AttributeDescriptors[attributeId]
+	if !ok {
+		return cluster.ZclDataTypeUnknown, false
+	}
+	return descriptor.Type, true
+}
+
+func (f *GenericCluster) Command(nwkAddress string, endpoint uint8, commandId uint8, command interface{}) (interface{}, error) {
+	return f.localCommandResponse(nwkAddress, endpoint, commandId, command)
+}
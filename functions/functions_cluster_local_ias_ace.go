@@ -0,0 +1,49 @@
+package functions
+
+import (
+	"errors"
+	"github.com/dyrkin/zigbee-steward/clusters"
+	"github.com/dyrkin/zigbee-steward/model"
+)
+
+var errNoPanelStatus = errors.New("panel status is not set")
+
+type IASACE struct {
+	*LocalCluster
+}
+
+func (f *IASACE) ArmResponse(nwkAddress string, endpoint uint8, transactionId uint8, armNotification model.ArmNotification) error {
+	return f.localResponse(nwkAddress, endpoint, transactionId, 0x00, &clusters.ArmResponseCommand{ArmNotification: uint8(armNotification)})
+}
+
+func (f *IASACE) PanelStatusChanged(nwkAddress string, endpoint uint8, panelStatus *model.PanelStatus) error {
+	if panelStatus == nil {
+		return errNoPanelStatus
+	}
+	return f.localNotification(nwkAddress, endpoint, 0x04, &clusters.PanelStatusChangedCommand{
+		PanelStatus:         uint8(panelStatus.PanelState),
+		SecondsRemaining:    panelStatus.SecondsRemaining,
+		AudibleNotification: uint8(panelStatus.AudibleNotification),
+		AlarmStatus:         uint8(panelStatus.AlarmStatus),
+	})
+}
+
+func (f *IASACE) GetPanelStatusResponse(nwkAddress string, endpoint uint8, transactionId uint8, panelStatus *model.PanelStatus) error {
+	if panelStatus == nil {
+		return errNoPanelStatus
+	}
+	return f.localResponse(nwkAddress, endpoint, transactionId, 0x05, &clusters.GetPanelStatusResponseCommand{
+		PanelStatus:         uint8(panelStatus.PanelState),
+		SecondsRemaining:    panelStatus.SecondsRemaining,
+		AudibleNotification: uint8(panelStatus.AudibleNotification),
+		AlarmStatus:         uint8(panelStatus.AlarmStatus),
+	})
+}
+
+func (f *IASACE) BypassResponse(nwkAddress string, endpoint uint8, transactionId uint8, bypassResults []model.BypassResult) error {
+	results := make([]uint8, len(bypassResults))
+	for i, result := range bypassResults {
+		results[i] = uint8(result)
+	}
+	return f.localResponse(nwkAddress, endpoint, transactionId, 0x07, &clusters.BypassResponseCommand{BypassResults: results})
+}
@@ -0,0 +1,38 @@
+package functions
+
+import (
+	"github.com/dyrkin/zigbee-steward/clusters"
+	"github.com/dyrkin/zigbee-steward/model"
+)
+
+type IASWD struct {
+	*LocalCluster
+}
+
+func (f *IASWD) StartWarning(nwkAddress string, endpoint uint8, warningMode model.WarningMode, strobe model.Strobe, sirenLevel model.SirenLevel,
+	warningDuration uint16, strobeDutyCycle uint8, strobeLevel model.SirenLevel) error {
+	return f.localCommand(nwkAddress, endpoint, 0x00, &clusters.StartWarningCommand{
+		WarningInfo: &clusters.WarningInfo{
+			SirenLevel:  uint8(sirenLevel),
+			Strobe:      uint8(strobe),
+			WarningMode: uint8(warningMode),
+		},
+		WarningDuration: warningDuration,
+		StrobeDutyCycle: strobeDutyCycle,
+		StrobeLevel:     uint8(strobeLevel),
+	})
+}
+
+func (f *IASWD) StopWarning(nwkAddress string, endpoint uint8) error {
+	return f.StartWarning(nwkAddress, endpoint, model.WarningModeStop, model.StrobeNoStrobe, model.SirenLevelLow, 0, 0, model.SirenLevelLow)
+}
+
+func (f *IASWD) Squawk(nwkAddress string, endpoint uint8, squawkMode model.SquawkMode, strobe model.Strobe, squawkLevel model.SirenLevel) error {
+	return f.localCommand(nwkAddress, endpoint, 0x01, &clusters.SquawkCommand{
+		SquawkInfo: &clusters.SquawkInfo{
+			SquawkLevel: uint8(squawkLevel),
+			Strobe:      uint8(strobe),
+			SquawkMode:  uint8(squawkMode),
+		},
+	})
+}
@@ -0,0 +1,24 @@
+package functions
+
+import (
+	"github.com/dyrkin/zigbee-steward/clusters"
+)
+
+type IASZone struct {
+	*LocalCluster
+}
+
+func (f *IASZone) ZoneEnrollResponse(nwkAddress string, endpoint uint8, enrollResponseCode uint8, zoneId uint8) error {
+	return f.localCommand(nwkAddress, endpoint, 0x00, &clusters.ZoneEnrollResponseCommand{EnrollResponseCode: enrollResponseCode, ZoneID: zoneId})
+}
+
+func (f *IASZone) InitiateNormalOperationMode(nwkAddress string, endpoint uint8) error {
+	return f.localCommand(nwkAddress, endpoint, 0x01, &clusters.InitiateNormalOperationModeCommand{})
+}
+
+func (f *IASZone) InitiateTestMode(nwkAddress string, endpoint uint8, testModeDuration uint8, currentZoneSensitivityLevel uint8) error {
+	return f.localCommand(nwkAddress, endpoint, 0x02, &clusters.InitiateTestModeCommand{
+		TestModeDuration:            testModeDuration,
+		CurrentZoneSensitivityLevel: currentZoneSensitivityLevel,
+	})
+}
@@ -0,0 +1,70 @@
+package functions
+
+import (
+	"github.com/dyrkin/zigbee-steward/clusters"
+	"github.com/dyrkin/zigbee-steward/ota"
+	"time"
+)
+
+const imageNotifyPayloadTypeFileVersion uint8 = 0x03
+
+type OTA struct {
+	*LocalCluster
+}
+
+func (f *OTA) ImageNotify(nwkAddress string, endpoint uint8, header *ota.Header, queryJitter uint8) error {
+	return f.localNotification(nwkAddress, endpoint, 0x00, &clusters.ImageNotifyCommand{
+		PayloadType:      imageNotifyPayloadTypeFileVersion,
+		QueryJitter:      queryJitter,
+		ManufacturerCode: header.ManufacturerCode,
+		ImageType:        header.ImageType,
+		NewFileVersion:   header.FileVersion,
+	})
+}
+
+func (f *OTA) QueryNextImageResponse(nwkAddress string, endpoint uint8, transactionId uint8, header *ota.Header) error {
+	if header == nil {
+		return f.localResponse(nwkAddress, endpoint, transactionId, 0x02, &clusters.QueryNextImageResponseCommand{Status: clusters.OTAStatusNoImageAvailable})
+	}
+	return f.localResponse(nwkAddress, endpoint, transactionId, 0x02, &clusters.QueryNextImageResponseCommand{
+		Status:           clusters.OTAStatusSuccess,
+		ManufacturerCode: header.ManufacturerCode,
+		ImageType:        header.ImageType,
+		FileVersion:      header.FileVersion,
+		ImageSize:        header.TotalImageSize,
+	})
+}
+
+func (f *OTA) ImageBlockResponse(nwkAddress string, endpoint uint8, transactionId uint8, header *ota.Header, fileOffset uint32, data []uint8) error {
+	return f.localResponse(nwkAddress, endpoint, transactionId, 0x05, &clusters.ImageBlockResponseCommand{
+		Status:           clusters.OTAStatusSuccess,
+		ManufacturerCode: header.ManufacturerCode,
+		ImageType:        header.ImageType,
+		FileVersion:      header.FileVersion,
+		FileOffset:       fileOffset,
+		ImageData:        data,
+	})
+}
+
+func (f *OTA) ImageBlockWaitResponse(nwkAddress string, endpoint uint8, transactionId uint8, delay time.Duration, minimumBlockPeriod time.Duration) error {
+	return f.localResponse(nwkAddress, endpoint, transactionId, 0x05, &clusters.ImageBlockResponseCommand{
+		Status:             clusters.OTAStatusWaitForData,
+		CurrentTime:        0,
+		RequestTime:        uint32((delay + time.Second - 1) / time.Second),
+		MinimumBlockPeriod: uint16(minimumBlockPeriod / time.Millisecond),
+	})
+}
+
+func (f *OTA) ImageBlockAbortResponse(nwkAddress string, endpoint uint8, transactionId uint8) error {
+	return f.localResponse(nwkAddress, endpoint, transactionId, 0x05, &clusters.ImageBlockResponseCommand{Status: clusters.OTAStatusAbort})
+}
+
+func (f *OTA) UpgradeEndResponse(nwkAddress string, endpoint uint8, transactionId uint8, header *ota.Header, upgradeAfter time.Duration) error {
+	return f.localResponse(nwkAddress, endpoint, transactionId, 0x07, &clusters.UpgradeEndResponseCommand{
+		ManufacturerCode: header.ManufacturerCode,
+		ImageType:        header.ImageType,
+		FileVersion:      header.FileVersion,
+		CurrentTime:      0,
+		UpgradeTime:      uint32(upgradeAfter / time.Second),
+	})
+}
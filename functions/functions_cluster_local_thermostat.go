@@ -0,0 +1,178 @@
+package functions
+
+import (
+	"fmt"
+	"github.com/dyrkin/zcl-go/cluster"
+	"github.com/dyrkin/zigbee-steward/clusters"
+	"github.com/dyrkin/zigbee-steward/model"
+	"math"
+)
+
+type Thermostat struct {
+	*LocalCluster
+	global *GlobalClusterFunctions
+}
+
+func (f *Thermostat) SetpointRaiseLower(nwkAddress string, endpoint uint8, mode model.SetpointMode, amount float64) error {
+	tenths := math.Round(amount * 10)
+	if tenths < math.MinInt8 || tenths > math.MaxInt8 {
+		return model.InvalidRequest("setpoint change [%.1f] is out of range. Expected -12.8 to 12.7", amount)
+	}
+	return f.localCommand(nwkAddress, endpoint, 0x00, &clusters.SetpointRaiseLowerCommand{Mode: uint8(mode), Amount: uint8(int8(tenths))})
+}
+
+func (f *Thermostat) SetWeeklySchedule(nwkAddress string, endpoint uint8, schedule *model.WeeklySchedule) error {
+	weeklySchedule := &clusters.WeeklySchedule{
+		DayOfWeekForSequence: uint8(schedule.Days),
+		ModeForSequence:      weeklyScheduleMode(schedule.Heat, schedule.Cool),
+	}
+	for _, transition := range schedule.Transitions {
+		heatSetpoint, err := model.CelsiusHundredths(transition.HeatSetpoint)
+		if err != nil {
+			return err
+		}
+		coolSetpoint, err := model.CelsiusHundredths(transition.CoolSetpoint)
+		if err != nil {
+			return err
+		}
+		weeklySchedule.Transitions = append(weeklySchedule.Transitions, &clusters.ThermostatTransition{
+			TransitionTime: transition.MinutesSinceMidnight,
+			HeatSetpoint:   int16(heatSetpoint),
+			CoolSetpoint:   int16(coolSetpoint),
+		})
+	}
+	return f.localCommand(nwkAddress, endpoint, 0x01, &clusters.SetWeeklyScheduleCommand{WeeklySchedule: weeklySchedule})
+}
+
+func (f *Thermostat) GetWeeklySchedule(nwkAddress string, endpoint uint8, days model.DayOfWeek, heat bool, cool bool) (*model.WeeklySchedule, error) {
+	response, err := f.localCommandResponse(nwkAddress, endpoint, 0x02, &clusters.GetWeeklyScheduleCommand{
+		DaysToReturn: uint8(days),
+		ModeToReturn: weeklyScheduleMode(heat, cool),
+	})
+	if err != nil {
+		return nil, err
+	}
+	scheduleResponse, ok := response.(*clusters.GetWeeklyScheduleResponseCommand)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response to get weekly schedule: %T", response)
+	}
+	weeklySchedule := scheduleResponse.WeeklySchedule
+	schedule := &model.WeeklySchedule{
+		Days: model.DayOfWeek(weeklySchedule.DayOfWeekForSequence),
+		Heat: weeklySchedule.ModeForSequence&clusters.WeeklyScheduleModeHeat > 0,
+		Cool: weeklySchedule.ModeForSequence&clusters.WeeklyScheduleModeCool > 0,
+	}
+	for _, transition := range weeklySchedule.Transitions {
+		schedule.Transitions = append(schedule.Transitions, &model.ScheduleTransition{
+			MinutesSinceMidnight: transition.TransitionTime,
+			HeatSetpoint:         model.Celsius(int64(transition.HeatSetpoint)),
+			CoolSetpoint:         model.Celsius(int64(transition.CoolSetpoint)),
+		})
+	}
+	return schedule, nil
+}
+
+func (f *Thermostat) ClearWeeklySchedule(nwkAddress string, endpoint uint8) error {
+	return f.localCommand(nwkAddress, endpoint, 0x03, &clusters.ClearWeeklyScheduleCommand{})
+}
+
+func (f *Thermostat) ReadState(nwkAddress string, endpoint uint8) (*model.ThermostatState, error) {
+	values, err := f.global.Endpoint(endpoint).readAttributeValues(nwkAddress, f.clusterId, []uint16{0x0000, 0x0007, 0x0008, 0x0011, 0x0012, 0x001c})
+	if err != nil {
+		return nil, err
+	}
+	state := &model.ThermostatState{}
+	if localTemperature, ok := values[0x0000].(int64); ok && localTemperature != model.TemperatureNotPresent {
+		celsius := model.Celsius(localTemperature)
+		state.LocalTemperature = &celsius
+	}
+	if piCoolingDemand, ok := values[0x0007].(uint64); ok {
+		state.PICoolingDemand = uint8(piCoolingDemand)
+	}
+	if piHeatingDemand, ok := values[0x0008].(uint64); ok {
+		state.PIHeatingDemand = uint8(piHeatingDemand)
+	}
+	if occupiedCoolingSetpoint, ok := values[0x0011].(int64); ok {
+		state.OccupiedCoolingSetpoint = model.Celsius(occupiedCoolingSetpoint)
+	}
+	if occupiedHeatingSetpoint, ok := values[0x0012].(int64); ok {
+		state.OccupiedHeatingSetpoint = model.Celsius(occupiedHeatingSetpoint)
+	}
+	if systemMode, ok := values[0x001c].(uint64); ok {
+		state.SystemMode = model.SystemMode(systemMode)
+	}
+	return state, nil
+}
+
+func (f *Thermostat) SetOccupiedHeatingSetpoint(nwkAddress string, endpoint uint8, celsius float64) error {
+	return f.writeSetpoint(nwkAddress, endpoint, 0x0012, celsius)
+}
+
+func (f *Thermostat) SetOccupiedCoolingSetpoint(nwkAddress string, endpoint uint8, celsius float64) error {
+	return f.writeSetpoint(nwkAddress, endpoint, 0x0011, celsius)
+}
+
+func (f *Thermostat) SetUnoccupiedHeatingSetpoint(nwkAddress string, endpoint uint8, celsius float64) error {
+	return f.writeSetpoint(nwkAddress, endpoint, 0x0014, celsius)
+}
+
+func (f *Thermostat) SetUnoccupiedCoolingSetpoint(nwkAddress string, endpoint uint8, celsius float64) error {
+	return f.writeSetpoint(nwkAddress, endpoint, 0x0013, celsius)
+}
+
+func (f *Thermostat) writeSetpoint(nwkAddress string, endpoint uint8, attributeId uint16, celsius float64) error {
+	hundredths, err := model.CelsiusHundredths(celsius)
+	if err != nil {
+		return err
+	}
+	return f.global.Endpoint(endpoint).writeAttribute(nwkAddress, f.clusterId, attributeId, cluster.ZclDataTypeInt16, hundredths)
+}
+
+func (f *Thermostat) SetSystemMode(nwkAddress string, endpoint uint8, systemMode model.SystemMode) error {
+	return f.global.Endpoint(endpoint).writeAttribute(nwkAddress, f.clusterId, 0x001c, cluster.ZclDataTypeEnum8, uint64(systemMode))
+}
+
+func weeklyScheduleMode(heat bool, cool bool) uint8 {
+	var mode uint8
+	if heat {
+		mode |= clusters.WeeklyScheduleModeHeat
+	}
+	if cool {
+		mode |= clusters.WeeklyScheduleModeCool
+	}
+	return mode
+}
+
+type FanControl struct {
+	*LocalCluster
+	global *GlobalClusterFunctions
+}
+
+func (f *FanControl) ReadFanMode(nwkAddress string, endpoint uint8) (model.FanMode, error) {
+	values, err := f.global.Endpoint(endpoint).readAttributeValues(nwkAddress, f.clusterId, []uint16{0x0000})
+	if err != nil {
+		return model.FanModeOff, err
+	}
+	fanMode, ok := values[0x0000].(uint64)
+	if !ok {
+		return model.FanModeOff, fmt.Errorf("unable to read fan mode")
+	}
+	return model.FanMode(fanMode), nil
+}
+
+func (f *FanControl) SetFanMode(nwkAddress string, endpoint uint8, fanMode model.FanMode) error {
+	return f.global.Endpoint(endpoint).writeAttribute(nwkAddress, f.clusterId, 0x0000, cluster.ZclDataTypeEnum8, uint64(fanMode))
+}
+
+type ThermostatUIConfiguration struct {
+	*LocalCluster
+	global *GlobalClusterFunctions
+}
+
+func (f *ThermostatUIConfiguration) SetTemperatureDisplayMode(nwkAddress string, endpoint uint8, displayMode model.TemperatureDisplayMode) error {
+	return f.global.Endpoint(endpoint).writeAttribute(nwkAddress, f.clusterId, 0x0000, cluster.ZclDataTypeEnum8, uint64(displayMode))
+}
+
+func (f *ThermostatUIConfiguration) SetKeypadLockout(nwkAddress string, endpoint uint8, keypadLockout model.KeypadLockout) error {
+	return f.global.Endpoint(endpoint).writeAttribute(nwkAddress, f.clusterId, 0x0001, cluster.ZclDataTypeEnum8, uint64(keypadLockout))
+}
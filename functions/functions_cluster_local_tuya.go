@@ -0,0 +1,33 @@
+package functions
+
+import (
+	"github.com/dyrkin/zigbee-steward/clusters"
+	"github.com/dyrkin/zigbee-steward/model"
+	"github.com/dyrkin/zigbee-steward/tuya"
+	"sync/atomic"
+)
+
+type Tuya struct {
+	*LocalCluster
+	sequence uint32
+}
+
+func (f *Tuya) SetDatapoints(nwkAddress string, endpoint uint8, datapoints ...*model.TuyaDatapoint) (uint16, error) {
+	command := &clusters.TuyaDataCommand{Sequence: f.nextSequence()}
+	for _, datapoint := range datapoints {
+		raw, err := tuya.Encode(datapoint)
+		if err != nil {
+			return 0, err
+		}
+		command.Datapoints = append(command.Datapoints, raw)
+	}
+	return command.Sequence, f.localCommand(nwkAddress, endpoint, 0x00, command)
+}
+
+func (f *Tuya) Query(nwkAddress string, endpoint uint8) error {
+	return f.localCommand(nwkAddress, endpoint, 0x03, &clusters.TuyaDataQueryCommand{})
+}
+
+func (f *Tuya) nextSequence() uint16 {
+	return uint16(atomic.AddUint32(&f.sequence, 1))
+}
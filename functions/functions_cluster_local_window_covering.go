@@ -0,0 +1,87 @@
+package functions
+
+import (
+	"fmt"
+	"github.com/dyrkin/zcl-go/cluster"
+	"github.com/dyrkin/zigbee-steward/clusters"
+	"github.com/dyrkin/zigbee-steward/model"
+)
+
+type WindowCovering struct {
+	*LocalCluster
+	global *GlobalClusterFunctions
+}
+
+func (f *WindowCovering) UpOpen(nwkAddress string, endpoint uint8) error {
+	return f.localCommand(nwkAddress, endpoint, 0x00, &clusters.UpOpenCommand{})
+}
+
+func (f *WindowCovering) DownClose(nwkAddress string, endpoint uint8) error {
+	return f.localCommand(nwkAddress, endpoint, 0x01, &clusters.DownCloseCommand{})
+}
+
+func (f *WindowCovering) Stop(nwkAddress string, endpoint uint8) error {
+	return f.localCommand(nwkAddress, endpoint, 0x02, &clusters.WindowCoveringStopCommand{})
+}
+
+func (f *WindowCovering) GoToLiftValue(nwkAddress string, endpoint uint8, liftValue uint16) error {
+	return f.localCommand(nwkAddress, endpoint, 0x04, &clusters.GoToLiftValueCommand{LiftValue: liftValue})
+}
+
+func (f *WindowCovering) GoToLiftPercentage(nwkAddress string, endpoint uint8, percentageLiftValue uint8) error {
+	return f.localCommand(nwkAddress, endpoint, 0x05, &clusters.GoToLiftPercentageCommand{PercentageLiftValue: percentageLiftValue})
+}
+
+func (f *WindowCovering) GoToTiltValue(nwkAddress string, endpoint uint8, tiltValue uint16) error {
+	return f.localCommand(nwkAddress, endpoint, 0x07, &clusters.GoToTiltValueCommand{TiltValue: tiltValue})
+}
+
+func (f *WindowCovering) GoToTiltPercentage(nwkAddress string, endpoint uint8, percentageTiltValue uint8) error {
+	return f.localCommand(nwkAddress, endpoint, 0x08, &clusters.GoToTiltPercentageCommand{PercentageTiltValue: percentageTiltValue})
+}
+
+func (f *WindowCovering) ReadPosition(nwkAddress string, endpoint uint8) (*model.WindowCoveringPosition, error) {
+	values, err := f.global.Endpoint(endpoint).readAttributeValues(nwkAddress, f.clusterId, []uint16{0x0003, 0x0004, 0x0008, 0x0009})
+	if err != nil {
+		return nil, err
+	}
+	position := &model.WindowCoveringPosition{}
+	if lift, ok := values[0x0003].(uint64); ok {
+		position.Lift = uint16(lift)
+	}
+	if tilt, ok := values[0x0004].(uint64); ok {
+		position.Tilt = uint16(tilt)
+	}
+	if liftPercentage, ok := values[0x0008].(uint64); ok {
+		position.LiftPercentage = uint8(liftPercentage)
+	}
+	if tiltPercentage, ok := values[0x0009].(uint64); ok {
+		position.TiltPercentage = uint8(tiltPercentage)
+	}
+	return position, nil
+}
+
+func (f *WindowCovering) ReadMode(nwkAddress string, endpoint uint8) (*model.WindowCoveringMode, error) {
+	values, err := f.global.Endpoint(endpoint).readAttributeValues(nwkAddress, f.clusterId, []uint16{0x0017})
+	if err != nil {
+		return nil, err
+	}
+	mode, ok := values[0x0017].(uint64)
+	if !ok {
+		return nil, fmt.Errorf("unable to read window covering mode")
+	}
+	return model.NewWindowCoveringMode(uint8(mode)), nil
+}
+
+func (f *WindowCovering) WriteMode(nwkAddress string, endpoint uint8, mode *model.WindowCoveringMode) error {
+	return f.global.Endpoint(endpoint).writeAttribute(nwkAddress, f.clusterId, 0x0017, cluster.ZclDataTypeBitmap8, uint64(mode.Bitmap()))
+}
+
+func (f *WindowCovering) SetCalibrationMode(nwkAddress string, endpoint uint8, enabled bool) error {
+	mode, err := f.ReadMode(nwkAddress, endpoint)
+	if err != nil {
+		return err
+	}
+	mode.CalibrationMode = enabled
+	return f.WriteMode(nwkAddress, endpoint, mode)
+}
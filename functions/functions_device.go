@@ -0,0 +1,164 @@
+package functions
+
+import (
+	"fmt"
+	"github.com/dyrkin/zcl-go/cluster"
+	"github.com/dyrkin/zigbee-steward/coordinator"
+	"github.com/dyrkin/zigbee-steward/db"
+	"github.com/dyrkin/zigbee-steward/logger"
+	"github.com/dyrkin/zigbee-steward/model"
+	"github.com/dyrkin/znp-go"
+)
+
+type DeviceFunctions struct {
+	functions   *Functions
+	ieeeAddress string
+	endpoint    uint8
+}
+
+func (f *Functions) Device(ieeeAddress string) *DeviceFunctions {
+	return &DeviceFunctions{functions: f, ieeeAddress: ieeeAddress}
+}
+
+func (d *DeviceFunctions) Endpoint(endpoint uint8) *DeviceFunctions {
+	return &DeviceFunctions{functions: d.functions, ieeeAddress: d.ieeeAddress, endpoint: endpoint}
+}
+
+func (d *DeviceFunctions) IEEEAddress() string {
+	return d.ieeeAddress
+}
+
+func (d *DeviceFunctions) Endpoints(clusterId cluster.ClusterId) ([]uint8, error) {
+	device, err := d.device()
+	if err != nil {
+		return nil, err
+	}
+	return device.InClusterEndpoints(uint16(clusterId)), nil
+}
+
+func (d *DeviceFunctions) Call(clusterId cluster.ClusterId, call func(nwkAddress string, endpoint uint8) error) error {
+	return d.call(clusterId, false, call)
+}
+
+// call resolves the endpoint among the ones hosting the cluster as a server. Bindings also resolve client clusters
+// since a switch binds its OnOff or LevelControl output cluster
+func (d *DeviceFunctions) call(clusterId cluster.ClusterId, outClusters bool, call func(nwkAddress string, endpoint uint8) error) error {
+	device, err := d.device()
+	if err != nil {
+		return err
+	}
+	endpoint, err := d.resolveEndpoint(device, clusterId, outClusters)
+	if err != nil {
+		return err
+	}
+	nwkAddress := device.NetworkAddress
+	err = call(nwkAddress, endpoint)
+	if !coordinator.IsRouteFailure(err) {
+		return err
+	}
+	rediscovered, rediscoverErr := d.rediscover(nwkAddress)
+	if rediscoverErr != nil {
+		log.With(logger.FieldIEEE, d.ieeeAddress).Errorf("Unable to rediscover network address of device [%s]: %s", d.ieeeAddress, rediscoverErr)
+		return err
+	}
+	if rediscovered == nwkAddress {
+		return err
+	}
+	return call(rediscovered, endpoint)
+}
+
+func (d *DeviceFunctions) ReadAttributes(clusterId cluster.ClusterId, attributeIds []uint16) (*cluster.ReadAttributesResponse, error) {
+	var response *cluster.ReadAttributesResponse
+	err := d.Call(clusterId, func(nwkAddress string, endpoint uint8) (err error) {
+		response, err = d.functions.Cluster().Global().Endpoint(endpoint).ReadAttributes(nwkAddress, clusterId, attributeIds)
+		return err
+	})
+	return response, err
+}
+
+func (d *DeviceFunctions) WriteAttributes(clusterId cluster.ClusterId, writeAttributeRecords []*cluster.WriteAttributeRecord) (*cluster.WriteAttributesResponse, error) {
+	var response *cluster.WriteAttributesResponse
+	err := d.Call(clusterId, func(nwkAddress string, endpoint uint8) (err error) {
+		response, err = d.functions.Cluster().Global().Endpoint(endpoint).WriteAttributes(nwkAddress, clusterId, writeAttributeRecords)
+		return err
+	})
+	return response, err
+}
+
+func (d *DeviceFunctions) ConfigureReporting(clusterId cluster.ClusterId, attributeReportingConfigurationRecords []*cluster.AttributeReportingConfigurationRecord) (*cluster.ConfigureReportingResponse, error) {
+	var response *cluster.ConfigureReportingResponse
+	err := d.Call(clusterId, func(nwkAddress string, endpoint uint8) (err error) {
+		response, err = d.functions.Cluster().Global().Endpoint(endpoint).ConfigureReporting(nwkAddress, clusterId, attributeReportingConfigurationRecords)
+		return err
+	})
+	return response, err
+}
+
+func (d *DeviceFunctions) Command(clusterId cluster.ClusterId, commandId uint8, command interface{}) (interface{}, error) {
+	var response interface{}
+	err := d.Call(clusterId, func(nwkAddress string, endpoint uint8) (err error) {
+		response, err = d.functions.Cluster().Local().Generic(clusterId).Command(nwkAddress, endpoint, commandId, command)
+		return err
+	})
+	return response, err
+}
+
+func (d *DeviceFunctions) Bind(clusterId cluster.ClusterId, destinationIeeeAddress string, destinationEndpoint uint8) (*znp.ZdoBindRsp, error) {
+	var response *znp.ZdoBindRsp
+	err := d.call(clusterId, true, func(nwkAddress string, endpoint uint8) (err error) {
+		response, err = d.functions.Generic().Bind(nwkAddress, d.ieeeAddress, endpoint, uint16(clusterId), destinationIeeeAddress, destinationEndpoint)
+		return err
+	})
+	return response, err
+}
+
+func (d *DeviceFunctions) Unbind(clusterId cluster.ClusterId, destinationIeeeAddress string, destinationEndpoint uint8) (*znp.ZdoUnbindRsp, error) {
+	var response *znp.ZdoUnbindRsp
+	err := d.call(clusterId, true, func(nwkAddress string, endpoint uint8) (err error) {
+		response, err = d.functions.Generic().Unbind(nwkAddress, d.ieeeAddress, endpoint, uint16(clusterId), destinationIeeeAddress, destinationEndpoint)
+		return err
+	})
+	return response, err
+}
+
+func (d *DeviceFunctions) device() (*model.Device, error) {
+	device, ok := db.Database().Tables().Devices.Get(d.ieeeAddress)
+	if !ok {
+		return nil, model.InvalidRequest("device [%s] is not registered", d.ieeeAddress)
+	}
+	return device, nil
+}
+
+func (d *DeviceFunctions) rediscover(nwkAddress string) (string, error) {
+	response, err := d.functions.Generic().NetworkAddress(d.ieeeAddress)
+	if err != nil {
+		return "", err
+	}
+	if response.Status != znp.StatusSuccess {
+		return "", fmt.Errorf("invalid status: [%s]", response.Status)
+	}
+	if response.IEEEAddr != d.ieeeAddress {
+		return "", fmt.Errorf("unexpected response for device [%s]", response.IEEEAddr)
+	}
+	if response.NwkAddr != nwkAddress {
+		log.With(logger.FieldIEEE, d.ieeeAddress).Infof("Network address of device [%s] changed from [%s] to [%s]", d.ieeeAddress, nwkAddress, response.NwkAddr)
+		db.Database().Tables().Devices.Update(d.ieeeAddress, func(device *model.Device) {
+			device.NetworkAddress = response.NwkAddr
+		})
+	}
+	return response.NwkAddr, nil
+}
+
+func (d *DeviceFunctions) resolveEndpoint(device *model.Device, clusterId cluster.ClusterId, outClusters bool) (uint8, error) {
+	if d.endpoint != 0 {
+		return d.endpoint, nil
+	}
+	endpoints := device.InClusterEndpoints(uint16(clusterId))
+	if outClusters {
+		endpoints = append(endpoints, device.OutClusterEndpoints(uint16(clusterId))...)
+	}
+	if len(endpoints) == 0 {
+		return 0, model.InvalidRequest("device [%s] has no endpoint hosting cluster [%d]", d.ieeeAddress, clusterId)
+	}
+	return endpoints[0], nil
+}
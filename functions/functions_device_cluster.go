@@ -0,0 +1,112 @@
+package functions
+
+import (
+	"github.com/dyrkin/zcl-go/cluster"
+	"github.com/dyrkin/zigbee-steward/clusters"
+)
+
+type DeviceOnOff struct {
+	device *DeviceFunctions
+	onOff  *OnOff
+}
+
+type DeviceLevelControl struct {
+	device       *DeviceFunctions
+	levelControl *LevelControl
+}
+
+type DeviceColorControl struct {
+	device       *DeviceFunctions
+	colorControl *ColorControl
+}
+
+type DeviceWindowCovering struct {
+	device         *DeviceFunctions
+	windowCovering *WindowCovering
+}
+
+func (d *DeviceFunctions) OnOff() *DeviceOnOff {
+	return &DeviceOnOff{device: d, onOff: d.functions.Cluster().Local().OnOff()}
+}
+
+func (d *DeviceFunctions) LevelControl() *DeviceLevelControl {
+	return &DeviceLevelControl{device: d, levelControl: d.functions.Cluster().Local().LevelControl()}
+}
+
+func (d *DeviceFunctions) ColorControl() *DeviceColorControl {
+	return &DeviceColorControl{device: d, colorControl: d.functions.Cluster().Local().ColorControl()}
+}
+
+func (d *DeviceFunctions) WindowCovering() *DeviceWindowCovering {
+	return &DeviceWindowCovering{device: d, windowCovering: d.functions.Cluster().Local().WindowCovering()}
+}
+
+func (f *DeviceOnOff) On() error {
+	return f.device.Call(cluster.OnOff, f.onOff.On)
+}
+
+func (f *DeviceOnOff) Off() error {
+	return f.device.Call(cluster.OnOff, f.onOff.Off)
+}
+
+func (f *DeviceOnOff) Toggle() error {
+	return f.device.Call(cluster.OnOff, f.onOff.Toggle)
+}
+
+func (f *DeviceLevelControl) MoveToLevel(level uint8, transitionTime uint16) error {
+	return f.device.Call(cluster.LevelControl, func(nwkAddress string, endpoint uint8) error {
+		return f.levelControl.MoveToLevel(nwkAddress, endpoint, level, transitionTime)
+	})
+}
+
+func (f *DeviceLevelControl) MoveToLevelOnOff(level uint8, transitionTime uint16) error {
+	return f.device.Call(cluster.LevelControl, func(nwkAddress string, endpoint uint8) error {
+		return f.levelControl.MoveToLevelOnOff(nwkAddress, endpoint, level, transitionTime)
+	})
+}
+
+func (f *DeviceLevelControl) Stop() error {
+	return f.device.Call(cluster.LevelControl, f.levelControl.Stop)
+}
+
+func (f *DeviceColorControl) MoveToColor(colorX uint16, colorY uint16, transitionTime uint16) error {
+	return f.device.Call(clusters.ColorControl, func(nwkAddress string, endpoint uint8) error {
+		return f.colorControl.MoveToColor(nwkAddress, endpoint, colorX, colorY, transitionTime)
+	})
+}
+
+func (f *DeviceColorControl) MoveToHueAndSaturation(hue uint8, saturation uint8, transitionTime uint16) error {
+	return f.device.Call(clusters.ColorControl, func(nwkAddress string, endpoint uint8) error {
+		return f.colorControl.MoveToHueAndSaturation(nwkAddress, endpoint, hue, saturation, transitionTime)
+	})
+}
+
+func (f *DeviceColorControl) MoveToColorTemperature(colorTemperatureMireds uint16, transitionTime uint16) error {
+	return f.device.Call(clusters.ColorControl, func(nwkAddress string, endpoint uint8) error {
+		return f.colorControl.MoveToColorTemperature(nwkAddress, endpoint, colorTemperatureMireds, transitionTime)
+	})
+}
+
+func (f *DeviceWindowCovering) UpOpen() error {
+	return f.device.Call(clusters.WindowCovering, f.windowCovering.UpOpen)
+}
+
+func (f *DeviceWindowCovering) DownClose() error {
+	return f.device.Call(clusters.WindowCovering, f.windowCovering.DownClose)
+}
+
+func (f *DeviceWindowCovering) Stop() error {
+	return f.device.Call(clusters.WindowCovering, f.windowCovering.Stop)
+}
+
+func (f *DeviceWindowCovering) GoToLiftPercentage(percentageLiftValue uint8) error {
+	return f.device.Call(clusters.WindowCovering, func(nwkAddress string, endpoint uint8) error {
+		return f.windowCovering.GoToLiftPercentage(nwkAddress, endpoint, percentageLiftValue)
+	})
+}
+
+func (f *DeviceWindowCovering) GoToTiltPercentage(percentageTiltValue uint8) error {
+	return f.device.Call(clusters.WindowCovering, func(nwkAddress string, endpoint uint8) error {
+		return f.windowCovering.GoToTiltPercentage(nwkAddress, endpoint, percentageTiltValue)
+	})
+}
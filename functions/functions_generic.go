@@ -16,3 +16,7 @@ func (f *GenericFunctions) Bind(sourceAddress string, sourceIeeeAddress string,
 func (f *GenericFunctions) Unbind(sourceAddress string, sourceIeeeAddress string, sourceEndpoint uint8, clusterId uint16, destinationIeeeAddress string, destinationEndpoint uint8) (*znp.ZdoUnbindRsp, error) {
 	return f.coordinator.Unbind(sourceAddress, sourceIeeeAddress, sourceEndpoint, clusterId, znp.AddrModeAddr64Bit, destinationIeeeAddress, destinationEndpoint)
 }
+
+func (f *GenericFunctions) NetworkAddress(ieeeAddress string) (*znp.ZdoNwkAddrRsp, error) {
+	return f.coordinator.NetworkAddress(ieeeAddress)
+}
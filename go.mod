@@ -1,13 +1,44 @@
 module github.com/dyrkin/zigbee-steward
 
+go 1.27.1
+
 require (
 	github.com/davecgh/go-spew v1.1.1
 	github.com/dyrkin/bin v0.0.0-20190204210718-06bd23f8c0ce
 	github.com/dyrkin/unp-go v1.0.2
 	github.com/dyrkin/zcl-go v0.0.0-20190327145041-12e9da09dc07
 	github.com/dyrkin/znp-go v0.0.0-20190319130731-f2cccabe8c69
+	github.com/eclipse/paho.mqtt.golang v1.4.3
+	github.com/gorilla/websocket v1.5.0
 	github.com/natefinch/atomic v0.0.0-20150920032501-a62ce929ffcc
-	github.com/op/go-logging v0.0.0-20160315200505-970db520ece7
+	github.com/prometheus/client_golang v1.20.5
 	github.com/tv42/topic v0.0.0-20130729201830-aa72cbe81b48
 	go.bug.st/serial.v1 v0.0.0-20180827123349-5f7892a7bb45
+	gopkg.in/yaml.v2 v2.4.0
+)
+
+require (
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/creack/goselect v0.0.0-20180501195510-58854f77ee8d // indirect
+	github.com/dyrkin/composer v0.0.0-20190327144947-a28f7162c421 // indirect
+	github.com/dyrkin/unpi-go v1.0.0 // indirect
+	github.com/google/pprof v0.0.0-20190109223431-e84dfd68c163 // indirect
+	github.com/ianlancetaylor/demangle v0.0.0-20181102032728-5e5cf60278f6 // indirect
+	github.com/klauspost/compress v1.17.9 // indirect
+	github.com/kr/pretty v0.3.1 // indirect
+	github.com/kr/pty v1.1.1 // indirect
+	github.com/kr/text v0.2.0 // indirect
+	github.com/munnerz/goautoneg v0.0.0-20191010083416-a7dc8b61c822 // indirect
+	github.com/prometheus/client_model v0.6.1 // indirect
+	github.com/prometheus/common v0.55.0 // indirect
+	github.com/prometheus/procfs v0.15.1 // indirect
+	golang.org/x/arch v0.0.0-20181203225421-5a4828bb7045 // indirect
+	golang.org/x/crypto v0.24.0 // indirect
+	golang.org/x/net v0.26.0 // indirect
+	golang.org/x/sync v0.7.0 // indirect
+	golang.org/x/sys v0.22.0 // indirect
+	golang.org/x/tools v0.0.0-20190116002428-2e4132e53b93 // indirect
+	google.golang.org/protobuf v1.34.2 // indirect
+	gopkg.in/check.v1 v1.0.0-20201130134442-10cb98267c6c // indirect
 )
@@ -0,0 +1,170 @@
+package host
+
+import (
+	"github.com/dyrkin/zcl-go/cluster"
+	"github.com/dyrkin/zigbee-steward/model"
+	"sort"
+	"sync"
+)
+
+type CommandHandler func(message *model.DeviceIncomingMessage) cluster.ZclStatus
+
+type Attribute struct {
+	Id       uint16
+	DataType cluster.ZclDataType
+	Access   cluster.Access
+	Value    interface{}
+	Getter   func() interface{}
+	Setter   func(value interface{}) cluster.ZclStatus
+}
+
+type Cluster struct {
+	Id         cluster.ClusterId
+	mutex      sync.RWMutex
+	attributes map[uint16]*Attribute
+	handlers   map[uint8]CommandHandler
+}
+
+func NewCluster(id cluster.ClusterId) *Cluster {
+	return &Cluster{
+		Id:         id,
+		attributes: map[uint16]*Attribute{},
+		handlers:   map[uint8]CommandHandler{},
+	}
+}
+
+func (c *Cluster) AddAttribute(attribute *Attribute) *Cluster {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.attributes[attribute.Id] = attribute
+	return c
+}
+
+func (c *Cluster) HandleCommand(commandId uint8, handler CommandHandler) *Cluster {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.handlers[commandId] = handler
+	return c
+}
+
+func (c *Cluster) Value(attributeId uint16) (interface{}, bool) {
+	c.mutex.RLock()
+	attribute, ok := c.attributes[attributeId]
+	c.mutex.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	return c.value(attribute), true
+}
+
+func (c *Cluster) SetValue(attributeId uint16, value interface{}) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	attribute, ok := c.attributes[attributeId]
+	if ok {
+		attribute.Value = value
+	}
+	return ok
+}
+
+func (c *Cluster) value(attribute *Attribute) interface{} {
+	if attribute.Getter != nil {
+		return attribute.Getter()
+	}
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return attribute.Value
+}
+
+func (c *Cluster) handler(commandId uint8) (CommandHandler, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	handler, ok := c.handlers[commandId]
+	return handler, ok
+}
+
+func (c *Cluster) attribute(attributeId uint16) (*Attribute, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	attribute, ok := c.attributes[attributeId]
+	return attribute, ok
+}
+
+func (c *Cluster) sortedAttributes(startAttributeId uint16) []*Attribute {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	var attributes []*Attribute
+	for id, attribute := range c.attributes {
+		if id >= startAttributeId {
+			attributes = append(attributes, attribute)
+		}
+	}
+	sort.Slice(attributes, func(i, j int) bool {
+		return attributes[i].Id < attributes[j].Id
+	})
+	return attributes
+}
+
+func (c *Cluster) readAttributes(attributeIds []uint16) []*cluster.ReadAttributeStatus {
+	var readAttributeStatuses []*cluster.ReadAttributeStatus
+	for _, attributeId := range attributeIds {
+		status := &cluster.ReadAttributeStatus{AttributeID: attributeId, Status: cluster.ZclStatusSuccess}
+		attribute, ok := c.attribute(attributeId)
+		switch {
+		case !ok:
+			status.Status = cluster.ZclStatusUnsupportedAttribute
+		case attribute.Access&cluster.Read == 0:
+			status.Status = cluster.ZclStatusWriteOnly
+		default:
+			status.Attribute = &cluster.Attribute{DataType: attribute.DataType, Value: c.value(attribute)}
+		}
+		readAttributeStatuses = append(readAttributeStatuses, status)
+	}
+	return readAttributeStatuses
+}
+
+func (c *Cluster) writeAttributes(writeAttributeRecords []*cluster.WriteAttributeRecord, undivided bool) []*cluster.WriteAttributeStatus {
+	var writeAttributeStatuses []*cluster.WriteAttributeStatus
+	failed := false
+	for _, record := range writeAttributeRecords {
+		status := &cluster.WriteAttributeStatus{AttributeID: record.AttributeID, Status: c.checkWrite(record)}
+		if status.Status != cluster.ZclStatusSuccess {
+			failed = true
+		}
+		writeAttributeStatuses = append(writeAttributeStatuses, status)
+	}
+	if undivided && failed {
+		return writeAttributeStatuses
+	}
+	for i, record := range writeAttributeRecords {
+		status := writeAttributeStatuses[i]
+		if status.Status == cluster.ZclStatusSuccess {
+			status.Status = c.write(record)
+		}
+	}
+	return writeAttributeStatuses
+}
+
+func (c *Cluster) checkWrite(record *cluster.WriteAttributeRecord) cluster.ZclStatus {
+	attribute, ok := c.attribute(record.AttributeID)
+	switch {
+	case !ok:
+		return cluster.ZclStatusUnsupportedAttribute
+	case attribute.Access&cluster.Write == 0:
+		return cluster.ZclStatusReadOnly
+	case record.Attribute == nil || record.Attribute.DataType != attribute.DataType:
+		return cluster.ZclStatusInvalidDataType
+	}
+	return cluster.ZclStatusSuccess
+}
+
+func (c *Cluster) write(record *cluster.WriteAttributeRecord) cluster.ZclStatus {
+	attribute, _ := c.attribute(record.AttributeID)
+	if attribute.Setter != nil {
+		if status := attribute.Setter(record.Attribute.Value); status != cluster.ZclStatusSuccess {
+			return status
+		}
+	}
+	c.SetValue(record.AttributeID, record.Attribute.Value)
+	return cluster.ZclStatusSuccess
+}
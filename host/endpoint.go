@@ -0,0 +1,63 @@
+package host
+
+import (
+	"github.com/dyrkin/zcl-go/cluster"
+	"github.com/dyrkin/zigbee-steward/coordinator"
+	"sync"
+)
+
+type Endpoint struct {
+	Id            uint8
+	ProfileId     uint16
+	DeviceId      uint16
+	DeviceVersion uint8
+	mutex         sync.RWMutex
+	inClusters    []*Cluster
+	outClusters   []cluster.ClusterId
+}
+
+func (e *Endpoint) AddInCluster(inCluster *Cluster) *Endpoint {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.inClusters = append(e.inClusters, inCluster)
+	return e
+}
+
+func (e *Endpoint) AddOutCluster(clusterId cluster.ClusterId) *Endpoint {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.outClusters = append(e.outClusters, clusterId)
+	return e
+}
+
+func (e *Endpoint) InCluster(clusterId cluster.ClusterId) (*Cluster, bool) {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+	for _, inCluster := range e.inClusters {
+		if inCluster.Id == clusterId {
+			return inCluster, true
+		}
+	}
+	return nil, false
+}
+
+func (e *Endpoint) descriptor() *coordinator.Endpoint {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+	inClusterList := []uint16{}
+	for _, inCluster := range e.inClusters {
+		inClusterList = append(inClusterList, uint16(inCluster.Id))
+	}
+	outClusterList := []uint16{}
+	for _, clusterId := range e.outClusters {
+		outClusterList = append(outClusterList, uint16(clusterId))
+	}
+	return &coordinator.Endpoint{
+		Id:             e.Id,
+		ProfileId:      e.ProfileId,
+		DeviceId:       e.DeviceId,
+		DeviceVersion:  e.DeviceVersion,
+		InClusterList:  inClusterList,
+		OutClusterList: outClusterList,
+	}
+}
@@ -0,0 +1,238 @@
+package host
+
+import (
+	"github.com/dyrkin/zcl-go/cluster"
+	"github.com/dyrkin/zcl-go/frame"
+	"github.com/dyrkin/zigbee-steward/coordinator"
+	"github.com/dyrkin/zigbee-steward/functions"
+	"github.com/dyrkin/zigbee-steward/logger"
+	"github.com/dyrkin/zigbee-steward/model"
+)
+
+var log = logger.MustGetLogger("host")
+
+const ZclStatusUnsupportedCluster cluster.ZclStatus = 0xc3
+
+const maximumDiscoveredAttributes = 32
+
+var globalResponses = map[uint8]bool{
+	0x01: true,
+	0x04: true,
+	0x07: true,
+	0x09: true,
+	0x0b: true,
+	0x0d: true,
+	0x10: true,
+	0x12: true,
+	0x14: true,
+	0x16: true,
+}
+
+type Host struct {
+	endpoints []*Endpoint
+	global    *functions.GlobalClusterFunctions
+}
+
+func New(endpoints []*coordinator.Endpoint, global *functions.GlobalClusterFunctions) *Host {
+	host := &Host{global: global}
+	for _, endpoint := range endpoints {
+		host.AddEndpoint(&Endpoint{
+			Id:            endpoint.Id,
+			ProfileId:     endpoint.ProfileId,
+			DeviceId:      endpoint.DeviceId,
+			DeviceVersion: endpoint.DeviceVersion,
+		})
+	}
+	return host
+}
+
+func (h *Host) AddEndpoint(endpoint *Endpoint) *Endpoint {
+	h.endpoints = append(h.endpoints, endpoint)
+	return endpoint
+}
+
+func (h *Host) Endpoint(id uint8) (*Endpoint, bool) {
+	for _, endpoint := range h.endpoints {
+		if endpoint.Id == id {
+			return endpoint, true
+		}
+	}
+	return nil, false
+}
+
+func (h *Host) Endpoints() []*Endpoint {
+	return h.endpoints
+}
+
+func (h *Host) Descriptors() []*coordinator.Endpoint {
+	var descriptors []*coordinator.Endpoint
+	for _, endpoint := range h.endpoints {
+		descriptors = append(descriptors, endpoint.descriptor())
+	}
+	return descriptors
+}
+
+func (h *Host) Process(message *model.DeviceIncomingMessage) {
+	incomingMessage := message.IncomingMessage
+	endpoint, ok := h.Endpoint(incomingMessage.DstEndpoint)
+	if !ok {
+		return
+	}
+	clusterId := cluster.ClusterId(incomingMessage.ClusterID)
+	hostedCluster, hosted := endpoint.InCluster(clusterId)
+	data := incomingMessage.Data
+	var status cluster.ZclStatus
+	switch data.FrameControl.FrameType {
+	case frame.FrameTypeGlobal:
+		if globalResponses[data.CommandIdentifier] {
+			return
+		}
+		status = h.processGlobal(message, hostedCluster, hosted)
+	case frame.FrameTypeLocal:
+		status = h.processLocal(message, hostedCluster, hosted)
+	}
+	if status == cluster.ZclStatusCmdHasRsp {
+		return
+	}
+	if status == cluster.ZclStatusSuccess && data.FrameControl.DisableDefaultResponse {
+		return
+	}
+	if incomingMessage.WasBroadcast || incomingMessage.GroupID != 0 {
+		return
+	}
+	direction := frame.DirectionClientServer
+	if data.FrameControl.Direction == frame.DirectionClientServer {
+		direction = frame.DirectionServerClient
+	}
+	h.respond(message, func() error {
+		return h.global.DefaultResponse(message.Device.NetworkAddress, incomingMessage.SrcEndpoint, incomingMessage.DstEndpoint,
+			clusterId, data.TransactionSequenceNumber, direction, data.CommandIdentifier, status)
+	})
+}
+
+func (h *Host) processGlobal(message *model.DeviceIncomingMessage, hostedCluster *Cluster, hosted bool) cluster.ZclStatus {
+	incomingMessage := message.IncomingMessage
+	nwkAddress := message.Device.NetworkAddress
+	endpoint := incomingMessage.SrcEndpoint
+	srcEndpoint := incomingMessage.DstEndpoint
+	clusterId := cluster.ClusterId(incomingMessage.ClusterID)
+	transactionId := incomingMessage.Data.TransactionSequenceNumber
+	switch command := incomingMessage.Data.Command.(type) {
+	case *cluster.ReportAttributesCommand:
+		return cluster.ZclStatusSuccess
+	case *cluster.ReadAttributesCommand:
+		if !hosted {
+			return ZclStatusUnsupportedCluster
+		}
+		readAttributeStatuses := hostedCluster.readAttributes(command.AttributeIDs)
+		h.respond(message, func() error {
+			return h.global.ReadAttributesResponse(nwkAddress, endpoint, srcEndpoint, clusterId, transactionId, readAttributeStatuses)
+		})
+		return cluster.ZclStatusCmdHasRsp
+	case *cluster.WriteAttributesCommand:
+		if !hosted {
+			return ZclStatusUnsupportedCluster
+		}
+		writeAttributeStatuses := hostedCluster.writeAttributes(command.WriteAttributeRecords, false)
+		h.respond(message, func() error {
+			return h.global.WriteAttributesResponse(nwkAddress, endpoint, srcEndpoint, clusterId, transactionId, writeAttributeStatuses)
+		})
+		return cluster.ZclStatusCmdHasRsp
+	case *cluster.WriteAttributesUndividedCommand:
+		if !hosted {
+			return ZclStatusUnsupportedCluster
+		}
+		writeAttributeStatuses := hostedCluster.writeAttributes(command.WriteAttributeRecords, true)
+		h.respond(message, func() error {
+			return h.global.WriteAttributesResponse(nwkAddress, endpoint, srcEndpoint, clusterId, transactionId, writeAttributeStatuses)
+		})
+		return cluster.ZclStatusCmdHasRsp
+	case *cluster.WriteAttributesNoResponseCommand:
+		if !hosted {
+			return ZclStatusUnsupportedCluster
+		}
+		hostedCluster.writeAttributes(command.WriteAttributeRecords, false)
+		return cluster.ZclStatusCmdHasRsp
+	case *cluster.DiscoverAttributesCommand:
+		if !hosted {
+			return ZclStatusUnsupportedCluster
+		}
+		attributes, complete := discover(hostedCluster, command.StartAttributeID, command.MaximumAttributeIdentifiers)
+		var attributeInformations []*cluster.AttributeInformation
+		for _, attribute := range attributes {
+			attributeInformations = append(attributeInformations, &cluster.AttributeInformation{
+				AttributeID:       attribute.Id,
+				AttributeDataType: attribute.DataType,
+			})
+		}
+		h.respond(message, func() error {
+			return h.global.DiscoverAttributesResponse(nwkAddress, endpoint, srcEndpoint, clusterId, transactionId, complete, attributeInformations)
+		})
+		return cluster.ZclStatusCmdHasRsp
+	case *cluster.DiscoverAttributesExtendedCommand:
+		if !hosted {
+			return ZclStatusUnsupportedCluster
+		}
+		attributes, complete := discover(hostedCluster, command.StartAttributeID, command.MaximumAttributeIdentifiers)
+		var extendedAttributeInformations []*cluster.ExtendedAttributeInformation
+		for _, attribute := range attributes {
+			extendedAttributeInformations = append(extendedAttributeInformations, &cluster.ExtendedAttributeInformation{
+				AttributeID:       attribute.Id,
+				AttributeDataType: attribute.DataType,
+				AttributeAccessControl: &cluster.AttributeAccessControl{
+					Readable:   accessFlag(attribute.Access, cluster.Read),
+					Writeable:  accessFlag(attribute.Access, cluster.Write),
+					Reportable: accessFlag(attribute.Access, cluster.Reportable),
+				},
+			})
+		}
+		h.respond(message, func() error {
+			return h.global.DiscoverAttributesExtendedResponse(nwkAddress, endpoint, srcEndpoint, clusterId, transactionId, complete, extendedAttributeInformations)
+		})
+		return cluster.ZclStatusCmdHasRsp
+	}
+	return cluster.ZclStatusUnsupGeneralCommand
+}
+
+func (h *Host) processLocal(message *model.DeviceIncomingMessage, hostedCluster *Cluster, hosted bool) cluster.ZclStatus {
+	data := message.IncomingMessage.Data
+	if data.FrameControl.Direction == frame.DirectionServerClient {
+		return cluster.ZclStatusSuccess
+	}
+	if !hosted {
+		return ZclStatusUnsupportedCluster
+	}
+	handler, ok := hostedCluster.handler(data.CommandIdentifier)
+	if !ok {
+		return cluster.ZclStatusUnsupClusterCommand
+	}
+	return handler(message)
+}
+
+func (h *Host) respond(message *model.DeviceIncomingMessage, response func() error) {
+	go func() {
+		if err := response(); err != nil {
+			log.Errorf("Unable to respond to command [%s] from [%s]: %s",
+				message.IncomingMessage.Data.CommandName, message.Device.IEEEAddress, err)
+		}
+	}()
+}
+
+func discover(hostedCluster *Cluster, startAttributeId uint16, maximumAttributeIdentifiers uint8) ([]*Attribute, bool) {
+	attributes := hostedCluster.sortedAttributes(startAttributeId)
+	maximum := int(maximumAttributeIdentifiers)
+	if maximum > maximumDiscoveredAttributes {
+		maximum = maximumDiscoveredAttributes
+	}
+	if len(attributes) <= maximum {
+		return attributes, true
+	}
+	return attributes[:maximum], false
+}
+
+func accessFlag(access cluster.Access, flag cluster.Access) uint8 {
+	if access&flag > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,146 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+const (
+	FieldModule  = "module"
+	FieldIEEE    = "ieee"
+	FieldNwk     = "nwk"
+	FieldCluster = "cluster"
+	FieldTsn     = "tsn"
+)
+
+type Configuration struct {
+	Level Level
+	//per module levels override Level, e.g. {"coordinator": DEBUG}
+	Modules map[string]Level
+	Format  string
+	Output  io.Writer
+	//custom backend, e.g. an adapter to zap. Format and Output are ignored when it's set
+	Handler slog.Handler
+}
+
+type backend struct {
+	level   Level
+	modules map[string]Level
+	handler slog.Handler
+}
+
+var configured atomic.Pointer[backend]
+
+func init() {
+	if err := Configure(Default()); err != nil {
+		panic(err)
+	}
+}
+
+func Default() *Configuration {
+	return &Configuration{
+		Level:   INFO,
+		Modules: map[string]Level{},
+		Format:  FormatText,
+		Output:  os.Stderr,
+	}
+}
+
+func Configure(config *Configuration) error {
+	modules := map[string]Level{}
+	for module, level := range config.Modules {
+		modules[module] = level
+	}
+	b := &backend{level: config.Level, modules: modules, handler: config.Handler}
+	if b.handler == nil {
+		output := config.Output
+		if output == nil {
+			output = os.Stderr
+		}
+		//levels are checked per module before records reach the handler
+		options := &slog.HandlerOptions{Level: slog.LevelDebug}
+		switch config.Format {
+		case FormatText, "":
+			b.handler = slog.NewTextHandler(output, options)
+		case FormatJSON:
+			b.handler = slog.NewJSONHandler(output, options)
+		default:
+			return fmt.Errorf("unknown log format [%s]", config.Format)
+		}
+	}
+	configured.Store(b)
+	return nil
+}
+
+// silences all loggers until Configure is called again
+func Disable() {
+	config := Default()
+	config.Level = OFF
+	Configure(config)
+}
+
+// parses a comma separated list of a default level and module overrides, e.g. "info,coordinator=debug"
+func ParseLevels(value string) (Level, map[string]Level, error) {
+	level := INFO
+	modules := map[string]Level{}
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		module, levelName, isModule := strings.Cut(part, "=")
+		if !isModule {
+			levelName = module
+		}
+		parsed, err := ParseLevel(levelName)
+		if err != nil {
+			return level, nil, err
+		}
+		if isModule {
+			modules[strings.TrimSpace(module)] = parsed
+		} else {
+			level = parsed
+		}
+	}
+	return level, modules, nil
+}
+
+// ConfigureFromFlags configures logging from the -log-level, -log-format and -log-file flags of the commands.
+// Logs are appended to the file when it's set
+func ConfigureFromFlags(levels string, format string, file string) error {
+	config := Default()
+	level, modules, err := ParseLevels(levels)
+	if err != nil {
+		return err
+	}
+	config.Level, config.Modules, config.Format = level, modules, format
+	if file != "" {
+		output, err := os.OpenFile(file, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return err
+		}
+		config.Output = output
+	}
+	return Configure(config)
+}
+
+func current() *backend {
+	return configured.Load()
+}
+
+func (b *backend) enabled(module string, level Level) bool {
+	threshold, ok := b.modules[module]
+	if !ok {
+		threshold = b.level
+	}
+	return threshold != OFF && level >= threshold
+}
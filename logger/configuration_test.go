@@ -0,0 +1,62 @@
+package logger
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLevels(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		wantLevel   Level
+		wantModules map[string]Level
+		wantErr     bool
+	}{
+		{"empty", "", INFO, map[string]Level{}, false},
+		{"default only", "debug", DEBUG, map[string]Level{}, false},
+		{"warn alias", "WARN", WARNING, map[string]Level{}, false},
+		{"modules only", "coordinator=debug", INFO, map[string]Level{"coordinator": DEBUG}, false},
+		{"default and modules", " error , coordinator = debug,mqtt=off ", ERROR, map[string]Level{"coordinator": DEBUG, "mqtt": OFF}, false},
+		{"last default wins", "debug,warning", WARNING, map[string]Level{}, false},
+		{"empty parts", ",,info,", INFO, map[string]Level{}, false},
+		{"unknown level", "verbose", INFO, nil, true},
+		{"unknown module level", "coordinator=verbose", INFO, nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			level, modules, err := ParseLevels(test.value)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %s %v", level, modules)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if level != test.wantLevel || !reflect.DeepEqual(modules, test.wantModules) {
+				t.Errorf("got %s %v, want %s %v", level, modules, test.wantLevel, test.wantModules)
+			}
+		})
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	b := &backend{level: WARNING, modules: map[string]Level{"coordinator": DEBUG, "mqtt": OFF}}
+	tests := []struct {
+		module string
+		level  Level
+		want   bool
+	}{
+		{"steward", INFO, false},
+		{"steward", WARNING, true},
+		{"coordinator", DEBUG, true},
+		{"mqtt", ERROR, false},
+	}
+	for _, test := range tests {
+		if got := b.enabled(test.module, test.level); got != test.want {
+			t.Errorf("%s at %s: got %t, want %t", test.module, test.level, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package logger
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+type Level uint8
+
+const (
+	DEBUG Level = iota
+	INFO
+	WARNING
+	ERROR
+	OFF
+)
+
+var levelStrings = map[Level]string{
+	DEBUG:   "debug",
+	INFO:    "info",
+	WARNING: "warning",
+	ERROR:   "error",
+	OFF:     "off",
+}
+
+var slogLevels = map[Level]slog.Level{
+	DEBUG:   slog.LevelDebug,
+	INFO:    slog.LevelInfo,
+	WARNING: slog.LevelWarn,
+	ERROR:   slog.LevelError,
+}
+
+func ParseLevel(value string) (Level, error) {
+	name := strings.ToLower(strings.TrimSpace(value))
+	if name == "warn" {
+		name = "warning"
+	}
+	for level, s := range levelStrings {
+		if s == name {
+			return level, nil
+		}
+	}
+	return INFO, fmt.Errorf("unknown log level [%s]", value)
+}
+
+func (l Level) String() string {
+	return levelStrings[l]
+}
+
+func (l Level) slogLevel() slog.Level {
+	return slogLevels[l]
+}
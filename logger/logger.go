@@ -1,68 +1,109 @@
 package logger
 
 import (
-	"github.com/op/go-logging"
+	"context"
+	"fmt"
+	"log/slog"
 	"os"
+	"time"
 )
 
+// defers expensive rendering, e.g. of spew dumps, until a record is actually written
+type Lazy func() string
+
+func (l Lazy) String() string {
+	return l()
+}
+
 type Logger struct {
-	*logging.Logger
+	module string
+	fields []interface{}
 }
 
+// modules share the backend configured with Configure, so getting a logger has no side effects
 func MustGetLogger(module string) *Logger {
-	log := logging.MustGetLogger(module)
-	format := logging.MustStringFormatter(
-		`%{color}%{time:15:04:05.000} %{level:.5s} %{module} %{color:reset} %{message}`,
-	)
+	return &Logger{module: module}
+}
 
-	backend := logging.NewLogBackend(os.Stdout, "", 0)
-	backendFormatter := logging.NewBackendFormatter(backend, format)
-	backendLeveled := logging.AddModuleLevel(backendFormatter)
-	backendLeveled.SetLevel(logging.DEBUG, "")
+// returns a logger which adds key-value pairs, e.g. FieldIEEE and FieldCluster, to every record
+func (log *Logger) With(keyValues ...interface{}) *Logger {
+	fields := make([]interface{}, 0, len(log.fields)+len(keyValues))
+	fields = append(fields, log.fields...)
+	fields = append(fields, keyValues...)
+	return &Logger{module: log.module, fields: fields}
+}
 
-	logging.SetBackend(backendLeveled)
-	return &Logger{log}
+func (log *Logger) IsEnabledFor(level Level) bool {
+	return current().enabled(log.module, level)
 }
 
 func (log *Logger) Debugf(format string, args ...interface{}) {
-	if log.IsEnabledFor(logging.DEBUG) {
-		renderLazyArgs(args...)
-		log.Logger.Debugf(format, args...)
-	}
+	log.logf(DEBUG, format, args...)
 }
 
 func (log *Logger) Debug(args ...interface{}) {
-	if log.IsEnabledFor(logging.DEBUG) {
-		renderLazyArgs(args...)
-		log.Logger.Debug(args...)
-	}
+	log.log(DEBUG, args...)
 }
 
 func (log *Logger) Infof(format string, args ...interface{}) {
-	if log.IsEnabledFor(logging.INFO) {
-		renderLazyArgs(args...)
-		log.Logger.Infof(format, args...)
-	}
+	log.logf(INFO, format, args...)
 }
 
 func (log *Logger) Info(args ...interface{}) {
-	if log.IsEnabledFor(logging.INFO) {
-		renderLazyArgs(args...)
-		log.Logger.Info(args...)
-	}
+	log.log(INFO, args...)
+}
+
+func (log *Logger) Warningf(format string, args ...interface{}) {
+	log.logf(WARNING, format, args...)
+}
+
+func (log *Logger) Warning(args ...interface{}) {
+	log.log(WARNING, args...)
 }
 
 func (log *Logger) Errorf(format string, args ...interface{}) {
-	if log.IsEnabledFor(logging.ERROR) {
+	log.logf(ERROR, format, args...)
+}
+
+func (log *Logger) Error(args ...interface{}) {
+	log.log(ERROR, args...)
+}
+
+func (log *Logger) Fatalf(format string, args ...interface{}) {
+	log.logf(ERROR, format, args...)
+	os.Exit(1)
+}
+
+func (log *Logger) Fatal(args ...interface{}) {
+	log.log(ERROR, args...)
+	os.Exit(1)
+}
+
+func (log *Logger) logf(level Level, format string, args ...interface{}) {
+	if log.IsEnabledFor(level) {
 		renderLazyArgs(args...)
-		log.Logger.Errorf(format, args...)
+		log.write(level, fmt.Sprintf(format, args...))
 	}
 }
 
-func (log *Logger) Error(args ...interface{}) {
-	if log.IsEnabledFor(logging.ERROR) {
+func (log *Logger) log(level Level, args ...interface{}) {
+	if log.IsEnabledFor(level) {
 		renderLazyArgs(args...)
-		log.Logger.Error(args...)
+		log.write(level, fmt.Sprint(args...))
+	}
+}
+
+func (log *Logger) write(level Level, message string) {
+	handler := current().handler
+	ctx := context.Background()
+	if !handler.Enabled(ctx, level.slogLevel()) {
+		return
+	}
+	record := slog.NewRecord(time.Now(), level.slogLevel(), message, 0)
+	record.AddAttrs(slog.String(FieldModule, log.module))
+	record.Add(log.fields...)
+	if err := handler.Handle(ctx, record); err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to write log record: %s\n", err)
 	}
 }
 
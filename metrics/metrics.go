@@ -0,0 +1,119 @@
+package metrics
+
+import (
+	"fmt"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"time"
+)
+
+const namespace = "steward"
+
+var registry = prometheus.NewRegistry()
+
+var (
+	dataRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "data_request_duration_seconds",
+		Help:      "Duration of data requests including retries, by cluster and result.",
+		Buckets:   []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 20},
+	}, []string{"cluster", "result"})
+
+	dataRequestRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "data_request_retries_total",
+		Help:      "Retried data requests by cluster.",
+	}, []string{"cluster"})
+
+	dataRequestTimeouts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "data_request_timeouts_total",
+		Help:      "Data requests which timed out waiting for the AF confirm or the response, by cluster.",
+	}, []string{"cluster", "stage"})
+
+	dataConfirms = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "af_data_confirms_total",
+		Help:      "AF data confirms by status.",
+	}, []string{"status"})
+
+	incomingMessages = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "incoming_messages_total",
+		Help:      "Incoming ZCL messages by cluster and device.",
+	}, []string{"cluster", "ieee_address"})
+
+	channelDrops = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "channel_drops_total",
+		Help:      "Events dropped because the channel had no capacity.",
+	}, []string{"channel"})
+
+	linkQuality = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "device_link_quality",
+		Help:      "Link quality of the last message received from a device.",
+	}, []string{"ieee_address"})
+)
+
+func init() {
+	registry.MustRegister(
+		dataRequestDuration,
+		dataRequestRetries,
+		dataRequestTimeouts,
+		dataConfirms,
+		incomingMessages,
+		channelDrops,
+		linkQuality,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+func Register(collector prometheus.Collector) error {
+	return registry.Register(collector)
+}
+
+func ObserveDataRequest(clusterId uint16, started time.Time, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	dataRequestDuration.WithLabelValues(clusterLabel(clusterId), result).Observe(time.Since(started).Seconds())
+}
+
+func DataRequestRetry(clusterId uint16) {
+	dataRequestRetries.WithLabelValues(clusterLabel(clusterId)).Inc()
+}
+
+func DataRequestTimeout(clusterId uint16, stage string) {
+	dataRequestTimeouts.WithLabelValues(clusterLabel(clusterId), stage).Inc()
+}
+
+func DataConfirm(status fmt.Stringer) {
+	dataConfirms.WithLabelValues(status.String()).Inc()
+}
+
+func IncomingMessage(clusterId uint16, ieeeAddress string, lqi uint8) {
+	incomingMessages.WithLabelValues(clusterLabel(clusterId), ieeeAddress).Inc()
+	linkQuality.WithLabelValues(ieeeAddress).Set(float64(lqi))
+}
+
+func ChannelDrop(channel string) {
+	channelDrops.WithLabelValues(channel).Inc()
+}
+
+func ForgetDevice(ieeeAddress string) {
+	incomingMessages.DeletePartialMatch(prometheus.Labels{"ieee_address": ieeeAddress})
+	linkQuality.DeleteLabelValues(ieeeAddress)
+}
+
+func clusterLabel(clusterId uint16) string {
+	return fmt.Sprintf("0x%04x", clusterId)
+}
@@ -0,0 +1,33 @@
+package model
+
+type ClickType uint8
+
+const (
+	ClickSingle ClickType = iota
+	ClickDouble
+	ClickTriple
+	ClickQuadruple
+	ClickLong
+	ClickRelease
+	ClickShake
+)
+
+type DeviceClick struct {
+	Device   *Device
+	Endpoint uint8
+	Click    ClickType
+}
+
+var clickTypeStrings = map[ClickType]string{
+	ClickSingle:    "Single",
+	ClickDouble:    "Double",
+	ClickTriple:    "Triple",
+	ClickQuadruple: "Quadruple",
+	ClickLong:      "Long",
+	ClickRelease:   "Release",
+	ClickShake:     "Shake",
+}
+
+func (c ClickType) String() string {
+	return clickTypeStrings[c]
+}
@@ -1,6 +1,11 @@
 package model
 
-import "github.com/dyrkin/znp-go"
+import (
+	"github.com/dyrkin/znp-go"
+	"regexp"
+)
+
+var ieeeAddressPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{16}$`)
 
 type PowerSource uint8
 
@@ -24,6 +29,41 @@ type Device struct {
 	NetworkAddress string
 	IEEEAddress    string
 	Endpoints      []*Endpoint
+	LinkQuality    *LinkQuality
+}
+
+// IsIEEEAddress reports whether the address is written as 0x followed by 16 hex digits
+func IsIEEEAddress(address string) bool {
+	return ieeeAddressPattern.MatchString(address)
+}
+
+func (d *Device) Endpoint(id uint8) (*Endpoint, bool) {
+	for _, e := range d.Endpoints {
+		if e.Id == id {
+			return e, true
+		}
+	}
+	return nil, false
+}
+
+func (d *Device) InClusterEndpoints(clusterId uint16) []uint8 {
+	var endpoints []uint8
+	for _, e := range d.Endpoints {
+		if e.HasInCluster(clusterId) {
+			endpoints = append(endpoints, e.Id)
+		}
+	}
+	return endpoints
+}
+
+func (d *Device) OutClusterEndpoints(clusterId uint16) []uint8 {
+	var endpoints []uint8
+	for _, e := range d.Endpoints {
+		if e.HasOutCluster(clusterId) {
+			endpoints = append(endpoints, e.Id)
+		}
+	}
+	return endpoints
 }
 
 func (d *Device) SupportedInClusters() []*Cluster {
@@ -3,6 +3,15 @@ package model
 import "github.com/dyrkin/zcl-go"
 
 type DeviceIncomingMessage struct {
-	Device          *Device
+	Device       *Device
+	GroupId      uint16
+	SrcEndpoint  uint8
+	DstEndpoint  uint8
+	WasBroadcast bool
+	LinkQuality  uint8
+	//ZNP reports link quality only, so RSSI is estimated from it
+	RSSI            int8
+	SecurityUse     bool
+	Timestamp       uint32
 	IncomingMessage *zcl.ZclIncomingMessage
 }
@@ -0,0 +1,10 @@
+package model
+
+type DeviceZoneStatusChange struct {
+	Device         *Device
+	Endpoint       uint8
+	ZoneId         uint8
+	ZoneStatus     *ZoneStatus
+	ExtendedStatus uint8
+	Delay          uint16
+}
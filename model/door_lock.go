@@ -0,0 +1,123 @@
+package model
+
+import "time"
+
+type LockUserStatus uint8
+
+const (
+	LockUserStatusAvailable        LockUserStatus = 0x00
+	LockUserStatusOccupiedEnabled  LockUserStatus = 0x01
+	LockUserStatusOccupiedDisabled LockUserStatus = 0x03
+	LockUserStatusNotSupported     LockUserStatus = 0xff
+)
+
+type LockUserType uint8
+
+const (
+	LockUserTypeUnrestricted    LockUserType = 0x00
+	LockUserTypeYearDaySchedule LockUserType = 0x01
+	LockUserTypeWeekDaySchedule LockUserType = 0x02
+	LockUserTypeMaster          LockUserType = 0x03
+	LockUserTypeNonAccess       LockUserType = 0x04
+	LockUserTypeNotSupported    LockUserType = 0xff
+)
+
+type LockOperatingMode uint8
+
+const (
+	LockOperatingModeNormal LockOperatingMode = iota
+	LockOperatingModeVacation
+	LockOperatingModePrivacy
+	LockOperatingModeNoRFLockOrUnlock
+	LockOperatingModePassage
+)
+
+type LockEventSource uint8
+
+const (
+	LockEventSourceKeypad        LockEventSource = 0x00
+	LockEventSourceRF            LockEventSource = 0x01
+	LockEventSourceManual        LockEventSource = 0x02
+	LockEventSourceRFID          LockEventSource = 0x03
+	LockEventSourceIndeterminate LockEventSource = 0xff
+)
+
+type LockOperationEventCode uint8
+
+const (
+	LockOperationEventCodeUnknown LockOperationEventCode = iota
+	LockOperationEventCodeLock
+	LockOperationEventCodeUnlock
+	LockOperationEventCodeLockFailureInvalidPINOrID
+	LockOperationEventCodeLockFailureInvalidSchedule
+	LockOperationEventCodeUnlockFailureInvalidPINOrID
+	LockOperationEventCodeUnlockFailureInvalidSchedule
+	LockOperationEventCodeOneTouchLock
+	LockOperationEventCodeKeyLock
+	LockOperationEventCodeKeyUnlock
+	LockOperationEventCodeAutoLock
+	LockOperationEventCodeScheduleLock
+	LockOperationEventCodeScheduleUnlock
+	LockOperationEventCodeManualLock
+	LockOperationEventCodeManualUnlock
+	LockOperationEventCodeNonAccessUserOperationalEvent
+)
+
+type LockProgrammingEventCode uint8
+
+const (
+	LockProgrammingEventCodeUnknown LockProgrammingEventCode = iota
+	LockProgrammingEventCodeMasterCodeChanged
+	LockProgrammingEventCodePINCodeAdded
+	LockProgrammingEventCodePINCodeDeleted
+	LockProgrammingEventCodePINCodeChanged
+	LockProgrammingEventCodeRFIDCodeAdded
+	LockProgrammingEventCodeRFIDCodeDeleted
+)
+
+type DeviceLockOperationEvent struct {
+	Device    *Device
+	Endpoint  uint8
+	Source    LockEventSource
+	Code      LockOperationEventCode
+	UserId    uint16
+	PIN       string
+	LocalTime time.Time
+	Data      string
+}
+
+type DeviceLockProgrammingEvent struct {
+	Device     *Device
+	Endpoint   uint8
+	Source     LockEventSource
+	Code       LockProgrammingEventCode
+	UserId     uint16
+	PIN        string
+	UserType   LockUserType
+	UserStatus LockUserStatus
+	LocalTime  time.Time
+	Data       string
+}
+
+var lockOperationEventCodeStrings = map[LockOperationEventCode]string{
+	LockOperationEventCodeUnknown:                       "Unknown",
+	LockOperationEventCodeLock:                          "Lock",
+	LockOperationEventCodeUnlock:                        "Unlock",
+	LockOperationEventCodeLockFailureInvalidPINOrID:     "LockFailureInvalidPINOrID",
+	LockOperationEventCodeLockFailureInvalidSchedule:    "LockFailureInvalidSchedule",
+	LockOperationEventCodeUnlockFailureInvalidPINOrID:   "UnlockFailureInvalidPINOrID",
+	LockOperationEventCodeUnlockFailureInvalidSchedule:  "UnlockFailureInvalidSchedule",
+	LockOperationEventCodeOneTouchLock:                  "OneTouchLock",
+	LockOperationEventCodeKeyLock:                       "KeyLock",
+	LockOperationEventCodeKeyUnlock:                     "KeyUnlock",
+	LockOperationEventCodeAutoLock:                      "AutoLock",
+	LockOperationEventCodeScheduleLock:                  "ScheduleLock",
+	LockOperationEventCodeScheduleUnlock:                "ScheduleUnlock",
+	LockOperationEventCodeManualLock:                    "ManualLock",
+	LockOperationEventCodeManualUnlock:                  "ManualUnlock",
+	LockOperationEventCodeNonAccessUserOperationalEvent: "NonAccessUserOperationalEvent",
+}
+
+func (c LockOperationEventCode) String() string {
+	return lockOperationEventCodeStrings[c]
+}
@@ -7,4 +7,24 @@ type Endpoint struct {
 	DeviceVersion  uint8
 	InClusterList  []*Cluster
 	OutClusterList []*Cluster
+
+	MeteringScaling              *MeteringScaling
+	ElectricalMeasurementScaling *ElectricalMeasurementScaling
+}
+
+func (e *Endpoint) HasInCluster(clusterId uint16) bool {
+	return hasCluster(e.InClusterList, clusterId)
+}
+
+func (e *Endpoint) HasOutCluster(clusterId uint16) bool {
+	return hasCluster(e.OutClusterList, clusterId)
+}
+
+func hasCluster(clusters []*Cluster, clusterId uint16) bool {
+	for _, c := range clusters {
+		if c.Id == clusterId {
+			return true
+		}
+	}
+	return false
 }
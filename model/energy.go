@@ -0,0 +1,67 @@
+package model
+
+import "strconv"
+
+type MeteringScaling struct {
+	UnitOfMeasure       uint8
+	Multiplier          uint32
+	Divisor             uint32
+	SummationFormatting uint8
+	DemandFormatting    uint8
+}
+
+type ElectricalMeasurementScaling struct {
+	VoltageMultiplier uint16
+	VoltageDivisor    uint16
+	CurrentMultiplier uint16
+	CurrentDivisor    uint16
+	PowerMultiplier   uint16
+	PowerDivisor      uint16
+}
+
+type DeviceEnergyMeasurement struct {
+	Device   *Device
+	Endpoint uint8
+	Power    *float64
+	Energy   *float64
+	Voltage  *float64
+	Current  *float64
+}
+
+func (s *MeteringScaling) Energy(summation uint64) float64 {
+	return scale(float64(summation), uint64(s.Multiplier), uint64(s.Divisor))
+}
+
+func (s *MeteringScaling) Power(demand int64) float64 {
+	return scale(float64(demand), uint64(s.Multiplier), uint64(s.Divisor)) * 1000
+}
+
+func (s *MeteringScaling) FormatEnergy(energy float64) string {
+	return strconv.FormatFloat(energy, 'f', int(s.SummationFormatting&0x07), 64)
+}
+
+func (s *MeteringScaling) FormatPower(power float64) string {
+	return strconv.FormatFloat(power/1000, 'f', int(s.DemandFormatting&0x07), 64)
+}
+
+func (s *ElectricalMeasurementScaling) Voltage(rmsVoltage uint64) float64 {
+	return scale(float64(rmsVoltage), uint64(s.VoltageMultiplier), uint64(s.VoltageDivisor))
+}
+
+func (s *ElectricalMeasurementScaling) Current(rmsCurrent uint64) float64 {
+	return scale(float64(rmsCurrent), uint64(s.CurrentMultiplier), uint64(s.CurrentDivisor))
+}
+
+func (s *ElectricalMeasurementScaling) Power(activePower int64) float64 {
+	return scale(float64(activePower), uint64(s.PowerMultiplier), uint64(s.PowerDivisor))
+}
+
+func scale(value float64, multiplier uint64, divisor uint64) float64 {
+	if multiplier == 0 {
+		multiplier = 1
+	}
+	if divisor == 0 {
+		divisor = 1
+	}
+	return value * float64(multiplier) / float64(divisor)
+}
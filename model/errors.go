@@ -0,0 +1,16 @@
+package model
+
+import "fmt"
+
+// InvalidRequestError is returned when a request to a device is rejected before it's sent because of its content
+type InvalidRequestError struct {
+	message string
+}
+
+func (e *InvalidRequestError) Error() string {
+	return e.message
+}
+
+func InvalidRequest(format string, args ...interface{}) error {
+	return &InvalidRequestError{message: fmt.Sprintf(format, args...)}
+}
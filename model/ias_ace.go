@@ -0,0 +1,86 @@
+package model
+
+type ArmMode uint8
+
+const (
+	ArmModeDisarm ArmMode = iota
+	ArmModeArmDayHomeZonesOnly
+	ArmModeArmNightSleepZonesOnly
+	ArmModeArmAllZones
+)
+
+type ArmNotification uint8
+
+const (
+	ArmNotificationAllZonesDisarmed ArmNotification = iota
+	ArmNotificationOnlyDayHomeZonesArmed
+	ArmNotificationOnlyNightSleepZonesArmed
+	ArmNotificationAllZonesArmed
+	ArmNotificationInvalidArmDisarmCode
+	ArmNotificationNotReadyToArm
+	ArmNotificationAlreadyDisarmed
+)
+
+type PanelState uint8
+
+const (
+	PanelStateDisarmed PanelState = iota
+	PanelStateArmedStay
+	PanelStateArmedNight
+	PanelStateArmedAway
+	PanelStateExitDelay
+	PanelStateEntryDelay
+	PanelStateNotReadyToArm
+	PanelStateInAlarm
+	PanelStateArmingStay
+	PanelStateArmingNight
+	PanelStateArmingAway
+)
+
+type AudibleNotification uint8
+
+const (
+	AudibleNotificationMute AudibleNotification = iota
+	AudibleNotificationDefaultSound
+)
+
+type AlarmStatus uint8
+
+const (
+	AlarmStatusNoAlarm AlarmStatus = iota
+	AlarmStatusBurglar
+	AlarmStatusFire
+	AlarmStatusEmergency
+	AlarmStatusPolicePanic
+	AlarmStatusFirePanic
+	AlarmStatusEmergencyPanic
+)
+
+type BypassResult uint8
+
+const (
+	BypassResultZoneBypassed BypassResult = iota
+	BypassResultZoneNotBypassed
+	BypassResultNotAllowed
+	BypassResultInvalidZoneId
+	BypassResultUnknownZoneId
+	BypassResultInvalidArmDisarmCode
+)
+
+type PanelStatus struct {
+	PanelState          PanelState
+	SecondsRemaining    uint8
+	AudibleNotification AudibleNotification
+	AlarmStatus         AlarmStatus
+}
+
+var armModeStrings = map[ArmMode]string{
+	ArmModeDisarm:                 "Disarm",
+	ArmModeArmDayHomeZonesOnly:    "ArmDayHomeZonesOnly",
+	ArmModeArmNightSleepZonesOnly: "ArmNightSleepZonesOnly",
+	ArmModeArmAllZones:            "ArmAllZones",
+}
+
+func (am ArmMode) String() string {
+	return armModeStrings[am]
+}
@@ -0,0 +1,36 @@
+package model
+
+type WarningMode uint8
+
+const (
+	WarningModeStop WarningMode = iota
+	WarningModeBurglar
+	WarningModeFire
+	WarningModeEmergency
+	WarningModePolicePanic
+	WarningModeFirePanic
+	WarningModeEmergencyPanic
+)
+
+type Strobe uint8
+
+const (
+	StrobeNoStrobe Strobe = iota
+	StrobeUseStrobe
+)
+
+type SirenLevel uint8
+
+const (
+	SirenLevelLow SirenLevel = iota
+	SirenLevelMedium
+	SirenLevelHigh
+	SirenLevelVeryHigh
+)
+
+type SquawkMode uint8
+
+const (
+	SquawkModeSystemArmed SquawkMode = iota
+	SquawkModeSystemDisarmed
+)
@@ -0,0 +1,36 @@
+package model
+
+type InterviewStage uint8
+
+const (
+	InterviewStarted InterviewStage = iota
+	InterviewBasicAttributes
+	InterviewNodeDescription
+	InterviewActiveEndpoints
+	InterviewEndpointDescription
+	InterviewConfiguration
+	InterviewCompleted
+	InterviewFailed
+)
+
+type DeviceInterviewProgress struct {
+	Device   *Device
+	Stage    InterviewStage
+	Endpoint uint8
+	Error    string
+}
+
+var interviewStageStrings = map[InterviewStage]string{
+	InterviewStarted:             "Started",
+	InterviewBasicAttributes:     "BasicAttributes",
+	InterviewNodeDescription:     "NodeDescription",
+	InterviewActiveEndpoints:     "ActiveEndpoints",
+	InterviewEndpointDescription: "EndpointDescription",
+	InterviewConfiguration:       "Configuration",
+	InterviewCompleted:           "Completed",
+	InterviewFailed:              "Failed",
+}
+
+func (s InterviewStage) String() string {
+	return interviewStageStrings[s]
+}
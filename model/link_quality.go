@@ -0,0 +1,23 @@
+package model
+
+const (
+	radioSensitivity = -97
+	radioSaturation  = 10
+)
+
+type LinkQuality struct {
+	Last     uint8
+	Average  float64
+	Degraded bool
+}
+
+type DeviceLinkQualityAlert struct {
+	Device      *Device
+	LinkQuality LinkQuality
+	Threshold   uint8
+}
+
+// inverse of the linear RSSI to LQI mapping used by TI radios
+func RSSI(linkQuality uint8) int8 {
+	return int8(int(linkQuality)*(radioSaturation-radioSensitivity)/255 + radioSensitivity)
+}
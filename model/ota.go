@@ -0,0 +1,39 @@
+package model
+
+type OTAUpgradeStatus uint8
+
+const (
+	OTAUpgradeStatusStarted OTAUpgradeStatus = iota
+	OTAUpgradeStatusDownloading
+	OTAUpgradeStatusCompleted
+	OTAUpgradeStatusFailed
+)
+
+type DeviceOTAProgress struct {
+	Device           *Device
+	Endpoint         uint8
+	ManufacturerCode uint16
+	ImageType        uint16
+	FileVersion      uint32
+	Offset           uint32
+	ImageSize        uint32
+	Status           OTAUpgradeStatus
+}
+
+func (p *DeviceOTAProgress) Percent() uint8 {
+	if p.ImageSize == 0 {
+		return 0
+	}
+	return uint8(uint64(p.Offset) * 100 / uint64(p.ImageSize))
+}
+
+var otaUpgradeStatusStrings = map[OTAUpgradeStatus]string{
+	OTAUpgradeStatusStarted:     "Started",
+	OTAUpgradeStatusDownloading: "Downloading",
+	OTAUpgradeStatusCompleted:   "Completed",
+	OTAUpgradeStatusFailed:      "Failed",
+}
+
+func (s OTAUpgradeStatus) String() string {
+	return otaUpgradeStatusStrings[s]
+}
@@ -0,0 +1,18 @@
+package model
+
+type DeviceSensorReading struct {
+	Device            *Device
+	Endpoint          uint8
+	Temperature       *float64
+	Humidity          *float64
+	Pressure          *float64
+	Illuminance       *float64
+	Occupied          *bool
+	BatteryPercentage *float64
+	BatteryVoltage    *float64
+}
+
+func (r *DeviceSensorReading) Empty() bool {
+	return r.Temperature == nil && r.Humidity == nil && r.Pressure == nil && r.Illuminance == nil &&
+		r.Occupied == nil && r.BatteryPercentage == nil && r.BatteryVoltage == nil
+}
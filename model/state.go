@@ -0,0 +1,9 @@
+package model
+
+type State map[string]interface{}
+
+type DeviceStateChange struct {
+	Device   *Device
+	Endpoint uint8
+	State    State
+}
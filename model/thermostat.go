@@ -0,0 +1,123 @@
+package model
+
+import "math"
+
+type SystemMode uint8
+
+const (
+	SystemModeOff              SystemMode = 0x00
+	SystemModeAuto             SystemMode = 0x01
+	SystemModeCool             SystemMode = 0x03
+	SystemModeHeat             SystemMode = 0x04
+	SystemModeEmergencyHeating SystemMode = 0x05
+	SystemModePrecooling       SystemMode = 0x06
+	SystemModeFanOnly          SystemMode = 0x07
+	SystemModeDry              SystemMode = 0x08
+	SystemModeSleep            SystemMode = 0x09
+)
+
+type SetpointMode uint8
+
+const (
+	SetpointModeHeat SetpointMode = iota
+	SetpointModeCool
+	SetpointModeBoth
+)
+
+type DayOfWeek uint8
+
+const (
+	Sunday         DayOfWeek = 0x01
+	Monday         DayOfWeek = 0x02
+	Tuesday        DayOfWeek = 0x04
+	Wednesday      DayOfWeek = 0x08
+	Thursday       DayOfWeek = 0x10
+	Friday         DayOfWeek = 0x20
+	Saturday       DayOfWeek = 0x40
+	AwayOrVacation DayOfWeek = 0x80
+)
+
+type FanMode uint8
+
+const (
+	FanModeOff FanMode = iota
+	FanModeLow
+	FanModeMedium
+	FanModeHigh
+	FanModeOn
+	FanModeAuto
+	FanModeSmart
+)
+
+type TemperatureDisplayMode uint8
+
+const (
+	TemperatureDisplayModeCelsius TemperatureDisplayMode = iota
+	TemperatureDisplayModeFahrenheit
+)
+
+type KeypadLockout uint8
+
+const (
+	KeypadLockoutNoLockout KeypadLockout = iota
+	KeypadLockoutLevel1
+	KeypadLockoutLevel2
+	KeypadLockoutLevel3
+	KeypadLockoutLevel4
+	KeypadLockoutLevel5
+)
+
+type ThermostatState struct {
+	//LocalTemperature is nil when the sensor reports no valid measurement
+	LocalTemperature        *float64
+	OccupiedHeatingSetpoint float64
+	OccupiedCoolingSetpoint float64
+	SystemMode              SystemMode
+	PIHeatingDemand         uint8
+	PICoolingDemand         uint8
+}
+
+type ScheduleTransition struct {
+	MinutesSinceMidnight uint16
+	HeatSetpoint         float64
+	CoolSetpoint         float64
+}
+
+type WeeklySchedule struct {
+	Days        DayOfWeek
+	Heat        bool
+	Cool        bool
+	Transitions []*ScheduleTransition
+}
+
+func Celsius(hundredths int64) float64 {
+	return float64(hundredths) / 100
+}
+
+// TemperatureNotPresent is the value of int16 temperature attributes without a valid measurement
+const TemperatureNotPresent int64 = math.MinInt16
+
+// CelsiusHundredths returns an InvalidRequestError when the temperature doesn't fit into an int16 temperature attribute
+func CelsiusHundredths(celsius float64) (int64, error) {
+	hundredths := math.Round(celsius * 100)
+	if math.IsNaN(hundredths) || hundredths < -27315 || hundredths > math.MaxInt16 {
+		return 0, InvalidRequest("temperature [%.2f] is out of range. Expected -273.15 to 327.67", celsius)
+	}
+	return int64(hundredths), nil
+}
+
+var systemModeStrings = map[SystemMode]string{
+	SystemModeOff:              "Off",
+	SystemModeAuto:             "Auto",
+	SystemModeCool:             "Cool",
+	SystemModeHeat:             "Heat",
+	SystemModeEmergencyHeating: "EmergencyHeating",
+	SystemModePrecooling:       "Precooling",
+	SystemModeFanOnly:          "FanOnly",
+	SystemModeDry:              "Dry",
+	SystemModeSleep:            "Sleep",
+}
+
+func (sm SystemMode) String() string {
+	return systemModeStrings[sm]
+}
@@ -0,0 +1,38 @@
+package model
+
+type Relationship uint8
+
+const (
+	RelationshipParent Relationship = iota
+	RelationshipChild
+	RelationshipSibling
+	RelationshipNone
+	RelationshipPreviousChild
+)
+
+type Link struct {
+	Source         string
+	Target         string
+	NetworkAddress string
+	Relationship   Relationship
+	Depth          uint8
+	LinkQuality    uint8
+}
+
+type Topology struct {
+	Links []*Link
+	//routers which didn't respond with their neighbor table
+	Unreachable []string
+}
+
+var relationshipStrings = map[Relationship]string{
+	RelationshipParent:        "Parent",
+	RelationshipChild:         "Child",
+	RelationshipSibling:       "Sibling",
+	RelationshipNone:          "None",
+	RelationshipPreviousChild: "PreviousChild",
+}
+
+func (r Relationship) String() string {
+	return relationshipStrings[r]
+}
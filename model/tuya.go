@@ -0,0 +1,38 @@
+package model
+
+type TuyaDatapointType uint8
+
+const (
+	TuyaDatapointTypeRaw TuyaDatapointType = iota
+	TuyaDatapointTypeBool
+	TuyaDatapointTypeValue
+	TuyaDatapointTypeString
+	TuyaDatapointTypeEnum
+	TuyaDatapointTypeBitmap
+)
+
+type TuyaDatapoint struct {
+	Id    uint8
+	Type  TuyaDatapointType
+	Value interface{}
+}
+
+type DeviceTuyaReport struct {
+	Device     *Device
+	Endpoint   uint8
+	Sequence   uint16
+	Datapoints []*TuyaDatapoint
+}
+
+var tuyaDatapointTypeStrings = map[TuyaDatapointType]string{
+	TuyaDatapointTypeRaw:    "Raw",
+	TuyaDatapointTypeBool:   "Bool",
+	TuyaDatapointTypeValue:  "Value",
+	TuyaDatapointTypeString: "String",
+	TuyaDatapointTypeEnum:   "Enum",
+	TuyaDatapointTypeBitmap: "Bitmap",
+}
+
+func (t TuyaDatapointType) String() string {
+	return tuyaDatapointTypeStrings[t]
+}
@@ -0,0 +1,38 @@
+package model
+
+type WindowCoveringPosition struct {
+	Lift           uint16
+	Tilt           uint16
+	LiftPercentage uint8
+	TiltPercentage uint8
+}
+
+type WindowCoveringMode struct {
+	ReversedMotorDirection bool
+	CalibrationMode        bool
+	MaintenanceMode        bool
+	LEDFeedback            bool
+}
+
+func NewWindowCoveringMode(mode uint8) *WindowCoveringMode {
+	return &WindowCoveringMode{
+		ReversedMotorDirection: mode&0x01 > 0,
+		CalibrationMode:        mode&0x02 > 0,
+		MaintenanceMode:        mode&0x04 > 0,
+		LEDFeedback:            mode&0x08 > 0,
+	}
+}
+
+func (m *WindowCoveringMode) Bitmap() uint8 {
+	var mode uint8
+	flag := func(enabled bool, bit uint8) {
+		if enabled {
+			mode |= bit
+		}
+	}
+	flag(m.ReversedMotorDirection, 0x01)
+	flag(m.CalibrationMode, 0x02)
+	flag(m.MaintenanceMode, 0x04)
+	flag(m.LEDFeedback, 0x08)
+	return mode
+}
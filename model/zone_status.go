@@ -0,0 +1,74 @@
+package model
+
+type ZoneType uint16
+
+const (
+	ZoneTypeStandardCIE             ZoneType = 0x0000
+	ZoneTypeMotionSensor            ZoneType = 0x000d
+	ZoneTypeContactSwitch           ZoneType = 0x0015
+	ZoneTypeFireSensor              ZoneType = 0x0028
+	ZoneTypeWaterSensor             ZoneType = 0x002a
+	ZoneTypeCOSensor                ZoneType = 0x002b
+	ZoneTypePersonalEmergencyDevice ZoneType = 0x002c
+	ZoneTypeVibrationMovementSensor ZoneType = 0x002d
+	ZoneTypeRemoteControl           ZoneType = 0x010f
+	ZoneTypeKeyFob                  ZoneType = 0x0115
+	ZoneTypeKeypad                  ZoneType = 0x021d
+	ZoneTypeStandardWarningDevice   ZoneType = 0x0225
+	ZoneTypeGlassBreakSensor        ZoneType = 0x0226
+	ZoneTypeSecurityRepeater        ZoneType = 0x0229
+	ZoneTypeInvalid                 ZoneType = 0xffff
+)
+
+type ZoneStatus struct {
+	Alarm1             bool
+	Alarm2             bool
+	Tamper             bool
+	BatteryLow         bool
+	SupervisionReports bool
+	RestoreReports     bool
+	Trouble            bool
+	ACMainsFault       bool
+	Test               bool
+	BatteryDefect      bool
+}
+
+func NewZoneStatus(zoneStatus uint16) *ZoneStatus {
+	bit := func(position uint) bool {
+		return zoneStatus&(1<<position) > 0
+	}
+	return &ZoneStatus{
+		Alarm1:             bit(0),
+		Alarm2:             bit(1),
+		Tamper:             bit(2),
+		BatteryLow:         bit(3),
+		SupervisionReports: bit(4),
+		RestoreReports:     bit(5),
+		Trouble:            bit(6),
+		ACMainsFault:       bit(7),
+		Test:               bit(8),
+		BatteryDefect:      bit(9),
+	}
+}
+
+var zoneTypeStrings = map[ZoneType]string{
+	ZoneTypeStandardCIE:             "StandardCIE",
+	ZoneTypeMotionSensor:            "MotionSensor",
+	ZoneTypeContactSwitch:           "ContactSwitch",
+	ZoneTypeFireSensor:              "FireSensor",
+	ZoneTypeWaterSensor:             "WaterSensor",
+	ZoneTypeCOSensor:                "COSensor",
+	ZoneTypePersonalEmergencyDevice: "PersonalEmergencyDevice",
+	ZoneTypeVibrationMovementSensor: "VibrationMovementSensor",
+	ZoneTypeRemoteControl:           "RemoteControl",
+	ZoneTypeKeyFob:                  "KeyFob",
+	ZoneTypeKeypad:                  "Keypad",
+	ZoneTypeStandardWarningDevice:   "StandardWarningDevice",
+	ZoneTypeGlassBreakSensor:        "GlassBreakSensor",
+	ZoneTypeSecurityRepeater:        "SecurityRepeater",
+	ZoneTypeInvalid:                 "Invalid",
+}
+
+func (zt ZoneType) String() string {
+	return zoneTypeStrings[zt]
+}
@@ -0,0 +1,63 @@
+package mqtt
+
+import (
+	"github.com/dyrkin/zigbee-steward"
+	"github.com/dyrkin/zigbee-steward/db"
+	"github.com/dyrkin/zigbee-steward/model"
+	"sync"
+	"time"
+)
+
+const availabilityCheckInterval = time.Minute
+
+type availabilities struct {
+	mutex  sync.Mutex
+	states map[string]string
+}
+
+func newAvailabilities() *availabilities {
+	return &availabilities{states: map[string]string{}}
+}
+
+func (a *availabilities) set(ieeeAddress string, availability string) bool {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	changed := a.states[ieeeAddress] != availability
+	a.states[ieeeAddress] = availability
+	return changed
+}
+
+func (a *availabilities) remove(ieeeAddress string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	delete(a.states, ieeeAddress)
+}
+
+// devices which weren't heard from since the bridge started get the full timeout before going offline
+func availability(lastSeen time.Time, seen bool, started time.Time, timeout time.Duration, now time.Time) string {
+	if !seen {
+		lastSeen = started
+	}
+	if now.Sub(lastSeen) < timeout {
+		return online
+	}
+	return offline
+}
+
+func (b *Bridge) deviceAvailability(device *model.Device) string {
+	lastSeen, seen := b.steward.LastSeen(device.IEEEAddress)
+	return availability(lastSeen, seen, b.started, steward.OnlineTimeout(device), time.Now())
+}
+
+func (b *Bridge) updateAvailability(device *model.Device, force bool) {
+	availability := b.deviceAvailability(device)
+	if b.availabilities.set(device.IEEEAddress, availability) || force {
+		b.publishAvailability(device, availability)
+	}
+}
+
+func (b *Bridge) checkAvailability() {
+	for _, device := range db.Database().Tables().Devices.All() {
+		b.updateAvailability(device, false)
+	}
+}
@@ -0,0 +1,396 @@
+package mqtt
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/dyrkin/zigbee-steward"
+	"github.com/dyrkin/zigbee-steward/db"
+	"github.com/dyrkin/zigbee-steward/definitions"
+	"github.com/dyrkin/zigbee-steward/logger"
+	"github.com/dyrkin/zigbee-steward/model"
+	paho "github.com/eclipse/paho.mqtt.golang"
+	"strings"
+	"time"
+)
+
+var log = logger.MustGetLogger("mqtt")
+
+const (
+	bridgeName = "bridge"
+	online     = "online"
+	offline    = "offline"
+)
+
+const (
+	defaultPermitJoinTimeout uint8 = 0xff
+	disconnectQuiesce              = 250
+)
+
+type Bridge struct {
+	steward        *steward.Steward
+	config         *Configuration
+	client         paho.Client
+	names          *friendlyNames
+	availabilities *availabilities
+	started        time.Time
+	stop           chan struct{}
+}
+
+type deviceInfo struct {
+	IEEEAddress    string                `json:"ieee_address"`
+	FriendlyName   string                `json:"friendly_name"`
+	NetworkAddress string                `json:"network_address"`
+	Manufacturer   string                `json:"manufacturer"`
+	Model          string                `json:"model"`
+	Type           string                `json:"type"`
+	PowerSource    string                `json:"power_source"`
+	Supported      bool                  `json:"supported"`
+	Vendor         string                `json:"vendor,omitempty"`
+	Description    string                `json:"description,omitempty"`
+	Exposes        []definitions.Feature `json:"exposes,omitempty"`
+}
+
+type event struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+}
+
+type deviceEventData struct {
+	IEEEAddress  string `json:"ieee_address"`
+	FriendlyName string `json:"friendly_name"`
+}
+
+type response struct {
+	Status      string      `json:"status"`
+	Data        interface{} `json:"data,omitempty"`
+	Error       string      `json:"error,omitempty"`
+	Transaction interface{} `json:"transaction,omitempty"`
+}
+
+type permitJoinRequest struct {
+	Value       bool        `json:"value"`
+	Time        *uint8      `json:"time"`
+	Transaction interface{} `json:"transaction"`
+}
+
+type removeRequest struct {
+	Id          string      `json:"id"`
+	Force       bool        `json:"force"`
+	Transaction interface{} `json:"transaction"`
+}
+
+type renameRequest struct {
+	From        string      `json:"from"`
+	To          string      `json:"to"`
+	Transaction interface{} `json:"transaction"`
+}
+
+func New(steward *steward.Steward, config *Configuration) (*Bridge, error) {
+	names, err := loadFriendlyNames(config.FriendlyNamesFile)
+	if err != nil {
+		return nil, err
+	}
+	return &Bridge{
+		steward:        steward,
+		config:         config,
+		names:          names,
+		availabilities: newAvailabilities(),
+		started:        time.Now(),
+		stop:           make(chan struct{}),
+	}, nil
+}
+
+func (b *Bridge) Start() error {
+	log.Infof("Connecting to MQTT server [%s]", b.config.Server)
+	options := paho.NewClientOptions().
+		AddBroker(b.config.Server).
+		SetClientID(b.config.ClientId).
+		SetUsername(b.config.Username).
+		SetPassword(b.config.Password).
+		SetAutoReconnect(true).
+		SetOrderMatters(false).
+		SetWill(b.topic(bridgeName, "state"), offline, b.config.QoS, true).
+		SetOnConnectHandler(b.onConnect).
+		SetConnectionLostHandler(func(client paho.Client, err error) {
+			log.Errorf("Lost connection to MQTT server: %s", err)
+		})
+	b.client = paho.NewClient(options)
+	if token := b.client.Connect(); token.Wait() && token.Error() != nil {
+		return fmt.Errorf("unable to connect to MQTT server [%s]: %s", b.config.Server, token.Error())
+	}
+	go b.listen()
+	return nil
+}
+
+func (b *Bridge) Stop() {
+	close(b.stop)
+	b.publish(b.topic(bridgeName, "state"), offline, true)
+	b.client.Disconnect(disconnectQuiesce)
+}
+
+func (b *Bridge) onConnect(client paho.Client) {
+	log.Info("Connected to MQTT server")
+	subscriptions := map[string]paho.MessageHandler{
+		b.topic("+", "set"):                                b.onSet,
+		b.topic(bridgeName, "request", "permit_join"):      b.onPermitJoin,
+		b.topic(bridgeName, "request", "device", "remove"): b.onRemove,
+		b.topic(bridgeName, "request", "device", "rename"): b.onRename,
+	}
+	if b.config.HomeAssistant {
+		subscriptions[b.config.HomeAssistantPrefix+"/status"] = b.onHomeAssistantStatus
+	}
+	for topic, handler := range subscriptions {
+		if token := client.Subscribe(topic, b.config.QoS, handler); token.Wait() && token.Error() != nil {
+			log.Errorf("Unable to subscribe to [%s]: %s", topic, token.Error())
+		}
+	}
+	b.publish(b.topic(bridgeName, "state"), online, true)
+	b.publishDevices()
+	for _, device := range db.Database().Tables().Devices.All() {
+		b.publishDiscovery(device)
+		b.updateAvailability(device, true)
+		if state, ok := b.steward.GetState(device.IEEEAddress); ok && len(state) > 0 {
+			b.publishState(device, state)
+		}
+	}
+}
+
+func (b *Bridge) listen() {
+	channels := b.steward.Channels()
+	ticker := time.NewTicker(availabilityCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case device := <-channels.OnDeviceRegistered():
+			b.publishDevices()
+			b.publishDiscovery(device)
+			b.updateAvailability(device, true)
+			b.publishEvent("device_joined", device)
+		case device := <-channels.OnDeviceBecameAvailable():
+			b.updateAvailability(device, true)
+			b.publishEvent("device_announce", device)
+		case device := <-channels.OnDeviceUnregistered():
+			b.publishEvent("device_leave", device)
+			b.removeDiscovery(device)
+			b.forget(device)
+			b.publishDevices()
+		case change := <-channels.OnDeviceStateChange():
+			state, _ := b.steward.GetState(change.Device.IEEEAddress)
+			if state == nil {
+				state = model.State{}
+			}
+			for key, value := range change.State {
+				state[key] = value
+			}
+			b.updateAvailability(change.Device, false)
+			b.publishState(change.Device, state)
+		case alert := <-channels.OnDeviceLinkQualityAlert():
+			if alert.LinkQuality.Degraded {
+				b.publishEvent("device_link_degraded", alert.Device)
+			} else {
+				b.publishEvent("device_link_recovered", alert.Device)
+			}
+		case <-ticker.C:
+			b.checkAvailability()
+		case <-b.stop:
+			return
+		}
+	}
+}
+
+func (b *Bridge) onSet(client paho.Client, message paho.Message) {
+	name := strings.TrimSuffix(strings.TrimPrefix(message.Topic(), b.config.BaseTopic+"/"), "/set")
+	if name == bridgeName {
+		return
+	}
+	device, state, err := b.setRequest(name, message.Payload())
+	if err != nil {
+		log.Errorf("Unable to set state of device [%s]: %s", name, err)
+		return
+	}
+	if err := b.steward.SetState(device.IEEEAddress, state); err != nil {
+		log.Errorf("Unable to set state of device [%s]: %s", name, err)
+		return
+	}
+	if state, ok := b.steward.GetState(device.IEEEAddress); ok {
+		b.publishState(device, state)
+	}
+}
+
+func (b *Bridge) setRequest(name string, payload []uint8) (*model.Device, model.State, error) {
+	device, ok := b.device(name)
+	if !ok {
+		return nil, nil, fmt.Errorf("device [%s] is not registered", name)
+	}
+	state := model.State{}
+	if err := json.Unmarshal(payload, &state); err != nil {
+		return nil, nil, fmt.Errorf("invalid payload [%s]: %s", payload, err)
+	}
+	return device, state, nil
+}
+
+func (b *Bridge) onPermitJoin(client paho.Client, message paho.Message) {
+	request := &permitJoinRequest{}
+	if err := parseRequest(message.Payload(), request, &request.Value); err != nil {
+		b.respond(message, nil, nil, err)
+		return
+	}
+	timeout := defaultPermitJoinTimeout
+	switch {
+	case !request.Value:
+		timeout = 0
+	case request.Time != nil:
+		timeout = *request.Time
+	}
+	err := b.steward.PermitJoin(timeout)
+	b.respond(message, request.Transaction, map[string]interface{}{"value": request.Value, "time": timeout}, err)
+}
+
+func (b *Bridge) onRemove(client paho.Client, message paho.Message) {
+	request := &removeRequest{}
+	if err := parseRequest(message.Payload(), request, &request.Id); err != nil {
+		b.respond(message, nil, nil, err)
+		return
+	}
+	device, ok := b.device(request.Id)
+	if !ok {
+		b.respond(message, request.Transaction, nil, fmt.Errorf("device [%s] is not registered", request.Id))
+		return
+	}
+	err := b.steward.RemoveDevice(device.IEEEAddress, request.Force)
+	b.respond(message, request.Transaction, map[string]interface{}{"id": request.Id, "force": request.Force}, err)
+}
+
+func (b *Bridge) onRename(client paho.Client, message paho.Message) {
+	request := &renameRequest{}
+	if err := json.Unmarshal(message.Payload(), request); err != nil {
+		b.respond(message, nil, nil, fmt.Errorf("invalid payload: %s", err))
+		return
+	}
+	device, ok := b.device(request.From)
+	if !ok {
+		b.respond(message, request.Transaction, nil, fmt.Errorf("device [%s] is not registered", request.From))
+		return
+	}
+	previous := b.names.name(device.IEEEAddress)
+	if err := b.names.rename(device.IEEEAddress, request.To); err != nil {
+		b.respond(message, request.Transaction, nil, err)
+		return
+	}
+	b.clear(previous)
+	b.publishDevices()
+	b.publishDiscovery(device)
+	b.updateAvailability(device, true)
+	if state, ok := b.steward.GetState(device.IEEEAddress); ok && len(state) > 0 {
+		b.publishState(device, state)
+	}
+	b.respond(message, request.Transaction, map[string]interface{}{"from": previous, "to": request.To}, nil)
+}
+
+func (b *Bridge) device(id string) (*model.Device, bool) {
+	if ieeeAddress, ok := b.names.ieeeAddress(id); ok {
+		id = ieeeAddress
+	}
+	return db.Database().Tables().Devices.Get(id)
+}
+
+func (b *Bridge) forget(device *model.Device) {
+	b.clear(b.names.name(device.IEEEAddress))
+	b.availabilities.remove(device.IEEEAddress)
+	if err := b.names.remove(device.IEEEAddress); err != nil {
+		log.Errorf("Unable to save friendly names: %s", err)
+	}
+}
+
+func (b *Bridge) clear(name string) {
+	if !b.config.Retain {
+		return
+	}
+	b.publish(b.topic(name), "", true)
+	b.publish(b.topic(name, "availability"), "", true)
+}
+
+func (b *Bridge) publishDevices() {
+	devices := []*deviceInfo{}
+	for _, device := range db.Database().Tables().Devices.All() {
+		devices = append(devices, b.deviceInfo(device))
+	}
+	b.publishJSON(b.topic(bridgeName, "devices"), devices, true)
+}
+
+func (b *Bridge) publishAvailability(device *model.Device, availability string) {
+	b.publish(b.topic(b.names.name(device.IEEEAddress), "availability"), availability, true)
+}
+
+func (b *Bridge) publishState(device *model.Device, state model.State) {
+	b.publishJSON(b.topic(b.names.name(device.IEEEAddress)), state, b.config.Retain)
+}
+
+func (b *Bridge) publishEvent(eventType string, device *model.Device) {
+	data := &deviceEventData{IEEEAddress: device.IEEEAddress, FriendlyName: b.names.name(device.IEEEAddress)}
+	b.publishJSON(b.topic(bridgeName, "event"), &event{Type: eventType, Data: data}, false)
+}
+
+func (b *Bridge) respond(message paho.Message, transaction interface{}, data interface{}, err error) {
+	topic := strings.Replace(message.Topic(), b.topic(bridgeName, "request"), b.topic(bridgeName, "response"), 1)
+	payload := &response{Status: "ok", Data: data, Transaction: transaction}
+	if err != nil {
+		log.Errorf("Request [%s] failed: %s", message.Topic(), err)
+		payload = &response{Status: "error", Error: err.Error(), Transaction: transaction}
+	}
+	b.publishJSON(topic, payload, false)
+}
+
+func (b *Bridge) publishJSON(topic string, payload interface{}, retain bool) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		log.Errorf("Unable to marshal payload for [%s]: %s", topic, err)
+		return
+	}
+	b.publish(topic, data, retain)
+}
+
+func (b *Bridge) publish(topic string, payload interface{}, retain bool) {
+	token := b.client.Publish(topic, b.config.QoS, retain, payload)
+	go func() {
+		if token.WaitTimeout(10*time.Second) && token.Error() != nil {
+			log.Errorf("Unable to publish to [%s]: %s", topic, token.Error())
+		}
+	}()
+}
+
+func (b *Bridge) deviceInfo(device *model.Device) *deviceInfo {
+	info := &deviceInfo{
+		IEEEAddress:    device.IEEEAddress,
+		FriendlyName:   b.names.name(device.IEEEAddress),
+		NetworkAddress: device.NetworkAddress,
+		Manufacturer:   device.Manufacturer,
+		Model:          device.Model,
+		Type:           device.LogicalType.String(),
+		PowerSource:    device.PowerSource.String(),
+	}
+	if definition, ok := b.steward.Definitions().Find(device); ok {
+		info.Supported = true
+		info.Vendor = definition.Vendor
+		info.Description = definition.Description
+		info.Exposes = definition.Exposes
+	}
+	return info
+}
+
+func (b *Bridge) topic(levels ...string) string {
+	return strings.Join(append([]string{b.config.BaseTopic}, levels...), "/")
+}
+
+func parseRequest(payload []uint8, request interface{}, value interface{}) error {
+	if err := json.Unmarshal(payload, request); err == nil {
+		return nil
+	}
+	if err := json.Unmarshal(payload, value); err == nil {
+		return nil
+	}
+	if err := json.Unmarshal([]uint8(fmt.Sprintf("%q", payload)), value); err != nil {
+		return fmt.Errorf("invalid payload [%s]", payload)
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package mqtt
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/dyrkin/zigbee-steward/db"
+	"github.com/dyrkin/zigbee-steward/model"
+	paho "github.com/eclipse/paho.mqtt.golang"
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+type publication struct {
+	topic    string
+	payload  string
+	retained bool
+}
+
+type fakeClient struct {
+	paho.Client
+	mutex        sync.Mutex
+	publications []publication
+}
+
+func (c *fakeClient) Publish(topic string, qos byte, retained bool, payload interface{}) paho.Token {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	var data string
+	switch payload := payload.(type) {
+	case string:
+		data = payload
+	case []uint8:
+		data = string(payload)
+	}
+	c.publications = append(c.publications, publication{topic: topic, payload: data, retained: retained})
+	return &paho.DummyToken{}
+}
+
+type fakeMessage struct {
+	paho.Message
+	topic   string
+	payload []uint8
+}
+
+func (m *fakeMessage) Topic() string {
+	return m.topic
+}
+
+func (m *fakeMessage) Payload() []uint8 {
+	return m.payload
+}
+
+func newTestBridge(names map[string]string) (*Bridge, *fakeClient) {
+	client := &fakeClient{}
+	config := Default()
+	config.BaseTopic = "zigbee"
+	return &Bridge{
+		config:         config,
+		client:         client,
+		names:          &friendlyNames{names: names},
+		availabilities: newAvailabilities(),
+		started:        time.Now(),
+	}, client
+}
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	os.Remove("db.json")
+	os.Exit(code)
+}
+
+func TestTopics(t *testing.T) {
+	bridge, client := newTestBridge(map[string]string{"0x00124b0001020304": "living_room"})
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"bridge state", bridge.topic(bridgeName, "state"), "zigbee/bridge/state"},
+		{"set subscription", bridge.topic("+", "set"), "zigbee/+/set"},
+		{"request", bridge.topic(bridgeName, "request", "device", "rename"), "zigbee/bridge/request/device/rename"},
+	}
+	for _, test := range tests {
+		if test.got != test.want {
+			t.Errorf("%s: got [%s], want [%s]", test.name, test.got, test.want)
+		}
+	}
+
+	named := &model.Device{IEEEAddress: "0x00124b0001020304"}
+	unnamed := &model.Device{IEEEAddress: "0x00124b00aabbccdd"}
+	bridge.publishState(named, model.State{"state": "ON"})
+	bridge.publishAvailability(unnamed, offline)
+	want := []publication{
+		{topic: "zigbee/living_room", payload: `{"state":"ON"}`, retained: true},
+		{topic: "zigbee/0x00124b00aabbccdd/availability", payload: "offline", retained: true},
+	}
+	if !reflect.DeepEqual(client.publications, want) {
+		t.Errorf("got %+v, want %+v", client.publications, want)
+	}
+}
+
+func TestPayloads(t *testing.T) {
+	bridge, client := newTestBridge(map[string]string{"0x00124b0001020304": "living_room"})
+	device := &model.Device{IEEEAddress: "0x00124b0001020304"}
+	request := &fakeMessage{topic: "zigbee/bridge/request/permit_join"}
+	bridge.publishEvent("device_joined", device)
+	bridge.respond(request, "tx1", map[string]interface{}{"value": true}, nil)
+	bridge.respond(request, nil, nil, errors.New("failed"))
+
+	tests := []struct {
+		topic string
+		want  map[string]interface{}
+	}{
+		{"zigbee/bridge/event", map[string]interface{}{"type": "device_joined",
+			"data": map[string]interface{}{"ieee_address": "0x00124b0001020304", "friendly_name": "living_room"}}},
+		{"zigbee/bridge/response/permit_join", map[string]interface{}{"status": "ok", "data": map[string]interface{}{"value": true}, "transaction": "tx1"}},
+		{"zigbee/bridge/response/permit_join", map[string]interface{}{"status": "error", "error": "failed"}},
+	}
+	if len(client.publications) != len(tests) {
+		t.Fatalf("got %d publications, want %d", len(client.publications), len(tests))
+	}
+	for i, test := range tests {
+		publication := client.publications[i]
+		got := map[string]interface{}{}
+		if err := json.Unmarshal([]uint8(publication.payload), &got); err != nil {
+			t.Fatal(err)
+		}
+		if publication.topic != test.topic || publication.retained || !reflect.DeepEqual(got, test.want) {
+			t.Errorf("got [%s] %v, want [%s] %v", publication.topic, got, test.topic, test.want)
+		}
+	}
+}
+
+func TestSetRequest(t *testing.T) {
+	bridge, _ := newTestBridge(map[string]string{"0x00124b0001020304": "living_room"})
+	db.Database().Tables().Devices.Add(&model.Device{IEEEAddress: "0x00124b0001020304"})
+	db.Database().Tables().Devices.Add(&model.Device{IEEEAddress: "0x00124b00aabbccdd"})
+	defer db.Database().Tables().Devices.Remove("0x00124b0001020304")
+	defer db.Database().Tables().Devices.Remove("0x00124b00aabbccdd")
+
+	tests := []struct {
+		name       string
+		target     string
+		payload    string
+		wantDevice string
+		wantState  model.State
+		wantErr    bool
+	}{
+		{"friendly name", "living_room", `{"state":"ON"}`, "0x00124b0001020304", model.State{"state": "ON"}, false},
+		{"ieee address", "0x00124b00aabbccdd", `{"brightness":10}`, "0x00124b00aabbccdd", model.State{"brightness": float64(10)}, false},
+		{"ieee address of renamed device", "0x00124b0001020304", `{}`, "0x00124b0001020304", model.State{}, false},
+		{"unknown device", "kitchen", `{"state":"ON"}`, "", nil, true},
+		{"invalid payload", "living_room", `ON`, "", nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			device, state, err := bridge.setRequest(test.target, []uint8(test.payload))
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", state)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if device.IEEEAddress != test.wantDevice || !reflect.DeepEqual(state, test.wantState) {
+				t.Errorf("got [%s] %v, want [%s] %v", device.IEEEAddress, state, test.wantDevice, test.wantState)
+			}
+		})
+	}
+}
+
+func TestAvailability(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		lastSeen time.Time
+		seen     bool
+		started  time.Time
+		want     string
+	}{
+		{"seen recently", now.Add(-time.Minute), true, now.Add(-2 * time.Hour), online},
+		{"silent for too long", now.Add(-2 * time.Hour), true, now.Add(-3 * time.Hour), offline},
+		{"not seen since recent start", time.Time{}, false, now.Add(-time.Minute), online},
+		{"not seen since start", time.Time{}, false, now.Add(-2 * time.Hour), offline},
+	}
+	for _, test := range tests {
+		if got := availability(test.lastSeen, test.seen, test.started, time.Hour, now); got != test.want {
+			t.Errorf("%s: got %s, want %s", test.name, got, test.want)
+		}
+	}
+
+	availabilities := newAvailabilities()
+	changes := []struct {
+		availability string
+		want         bool
+	}{{online, true}, {online, false}, {offline, true}, {online, true}}
+	for _, change := range changes {
+		if got := availabilities.set("0x00124b0001020304", change.availability); got != change.want {
+			t.Errorf("set %s: got %t, want %t", change.availability, got, change.want)
+		}
+	}
+}
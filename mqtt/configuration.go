@@ -0,0 +1,29 @@
+package mqtt
+
+type Configuration struct {
+	Server              string
+	ClientId            string
+	Username            string
+	Password            string
+	BaseTopic           string
+	QoS                 uint8
+	Retain              bool
+	FriendlyNamesFile   string
+	HomeAssistant       bool
+	HomeAssistantPrefix string
+}
+
+func Default() *Configuration {
+	return &Configuration{
+		Server:              "tcp://localhost:1883",
+		ClientId:            "zigbee-steward",
+		Username:            "",
+		Password:            "",
+		BaseTopic:           "zigbee-steward",
+		QoS:                 0,
+		Retain:              true,
+		FriendlyNamesFile:   "",
+		HomeAssistant:       false,
+		HomeAssistantPrefix: "homeassistant",
+	}
+}
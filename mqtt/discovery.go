@@ -0,0 +1,223 @@
+package mqtt
+
+import (
+	"fmt"
+	"github.com/dyrkin/zigbee-steward/db"
+	"github.com/dyrkin/zigbee-steward/definitions"
+	"github.com/dyrkin/zigbee-steward/model"
+	paho "github.com/eclipse/paho.mqtt.golang"
+	"strings"
+)
+
+const homeAssistantOnline = "online"
+
+type component struct {
+	kind     string
+	objectId string
+	config   map[string]interface{}
+}
+
+type sensor struct {
+	feature     definitions.Feature
+	unit        string
+	deviceClass string
+	stateClass  string
+	icon        string
+}
+
+var sensors = []*sensor{
+	{feature: definitions.FeatureTemperature, unit: "°C", deviceClass: "temperature", stateClass: "measurement"},
+	{feature: definitions.FeatureHumidity, unit: "%", deviceClass: "humidity", stateClass: "measurement"},
+	{feature: definitions.FeaturePressure, unit: "hPa", deviceClass: "pressure", stateClass: "measurement"},
+	{feature: definitions.FeatureIlluminance, unit: "lx", deviceClass: "illuminance", stateClass: "measurement"},
+	{feature: definitions.FeatureBattery, unit: "%", deviceClass: "battery", stateClass: "measurement"},
+	{feature: definitions.FeatureAction, icon: "mdi:gesture-double-tap"},
+}
+
+func (b *Bridge) onHomeAssistantStatus(client paho.Client, message paho.Message) {
+	if string(message.Payload()) == homeAssistantOnline {
+		log.Info("Home Assistant is online. Publishing discovery")
+		b.publishAllDiscovery()
+	}
+}
+
+func (b *Bridge) publishAllDiscovery() {
+	for _, device := range db.Database().Tables().Devices.All() {
+		b.publishDiscovery(device)
+	}
+}
+
+func (b *Bridge) publishDiscovery(device *model.Device) {
+	if !b.config.HomeAssistant {
+		return
+	}
+	for _, c := range b.components(device) {
+		b.publishJSON(b.discoveryTopic(device, c), c.config, true)
+	}
+}
+
+func (b *Bridge) removeDiscovery(device *model.Device) {
+	if !b.config.HomeAssistant {
+		return
+	}
+	for _, c := range b.components(device) {
+		b.publish(b.discoveryTopic(device, c), "", true)
+	}
+}
+
+func (b *Bridge) discoveryTopic(device *model.Device, c *component) string {
+	return strings.Join([]string{b.config.HomeAssistantPrefix, c.kind, device.IEEEAddress, c.objectId, "config"}, "/")
+}
+
+func (b *Bridge) components(device *model.Device) []*component {
+	definition, _ := b.steward.Definitions().Find(device)
+	features := definitions.Features(device, definition)
+	has := func(feature definitions.Feature) bool {
+		for _, f := range features {
+			if f == feature {
+				return true
+			}
+		}
+		return false
+	}
+	name := b.names.name(device.IEEEAddress)
+	stateTopic := b.topic(name)
+	setTopic := b.topic(name, "set")
+	newComponent := func(kind string, objectId string, config map[string]interface{}) *component {
+		config["name"] = fmt.Sprintf("%s %s", name, objectId)
+		config["unique_id"] = fmt.Sprintf("%s_%s_steward", device.IEEEAddress, objectId)
+		config["state_topic"] = stateTopic
+		config["availability_topic"] = b.topic(name, "availability")
+		config["device"] = b.discoveryDevice(device, definition)
+		return &component{kind: kind, objectId: objectId, config: config}
+	}
+
+	var components []*component
+	switch {
+	case has(definitions.FeatureLight) || (has(definitions.FeatureSwitch) && has(definitions.FeatureBrightness)):
+		colorModes := []string{}
+		if has(definitions.FeatureColor) {
+			colorModes = append(colorModes, "xy")
+		}
+		if has(definitions.FeatureColorTemp) {
+			colorModes = append(colorModes, "color_temp")
+		}
+		if len(colorModes) == 0 && has(definitions.FeatureBrightness) {
+			colorModes = append(colorModes, "brightness")
+		}
+		if len(colorModes) == 0 {
+			colorModes = append(colorModes, "onoff")
+		}
+		components = append(components, newComponent("light", "light", map[string]interface{}{
+			"schema":                "json",
+			"command_topic":         setTopic,
+			"brightness":            has(definitions.FeatureBrightness),
+			"brightness_scale":      254,
+			"supported_color_modes": colorModes,
+		}))
+	case has(definitions.FeatureSwitch):
+		components = append(components, newComponent("switch", "switch", map[string]interface{}{
+			"command_topic":  setTopic,
+			"value_template": "{{ value_json.state }}",
+			"payload_on":     `{"state": "ON"}`,
+			"payload_off":    `{"state": "OFF"}`,
+			"state_on":       "ON",
+			"state_off":      "OFF",
+		}))
+	}
+	for _, s := range sensors {
+		if !has(s.feature) {
+			continue
+		}
+		config := map[string]interface{}{
+			"value_template": fmt.Sprintf("{{ value_json.%s }}", s.feature),
+		}
+		if s.unit != "" {
+			config["unit_of_measurement"] = s.unit
+		}
+		if s.deviceClass != "" {
+			config["device_class"] = s.deviceClass
+		}
+		if s.stateClass != "" {
+			config["state_class"] = s.stateClass
+		}
+		if s.icon != "" {
+			config["icon"] = s.icon
+		}
+		components = append(components, newComponent("sensor", string(s.feature), config))
+	}
+	if has(definitions.FeatureOccupancy) {
+		components = append(components, newComponent("binary_sensor", "occupancy", binarySensor("occupancy", "occupancy")))
+	}
+	if has(definitions.FeatureAlarm) {
+		components = append(components,
+			newComponent("binary_sensor", "alarm", binarySensor("alarm", "safety")),
+			newComponent("binary_sensor", "tamper", binarySensor("tamper", "tamper")),
+			newComponent("binary_sensor", "battery_low", binarySensor("battery_low", "battery")))
+	}
+	if has(definitions.FeatureCover) {
+		components = append(components, newComponent("cover", "cover", map[string]interface{}{
+			"command_topic":         setTopic,
+			"payload_open":          `{"cover": "OPEN"}`,
+			"payload_close":         `{"cover": "CLOSE"}`,
+			"payload_stop":          `{"cover": "STOP"}`,
+			"position_topic":        stateTopic,
+			"position_template":     "{{ value_json.position }}",
+			"set_position_topic":    setTopic,
+			"set_position_template": `{"position": {{ position }}}`,
+		}))
+	}
+	if has(definitions.FeatureClimate) {
+		components = append(components, newComponent("climate", "climate", map[string]interface{}{
+			"temperature_unit":             "C",
+			"temp_step":                    0.5,
+			"min_temp":                     5,
+			"max_temp":                     30,
+			"modes":                        []string{"off", "auto", "cool", "heat"},
+			"current_temperature_topic":    stateTopic,
+			"current_temperature_template": "{{ value_json.local_temperature }}",
+			"temperature_state_topic":      stateTopic,
+			"temperature_state_template":   "{{ value_json.occupied_heating_setpoint }}",
+			"temperature_command_topic":    setTopic,
+			"temperature_command_template": `{"occupied_heating_setpoint": {{ value }}}`,
+			"mode_state_topic":             stateTopic,
+			"mode_state_template":          "{{ value_json.system_mode }}",
+			"mode_command_topic":           setTopic,
+			"mode_command_template":        `{"system_mode": "{{ value }}"}`,
+		}))
+	}
+	if has(definitions.FeatureLock) {
+		components = append(components, newComponent("lock", "lock", map[string]interface{}{
+			"command_topic":  setTopic,
+			"value_template": "{{ value_json.lock }}",
+			"payload_lock":   `{"lock": "LOCK"}`,
+			"payload_unlock": `{"lock": "UNLOCK"}`,
+			"state_locked":   "LOCKED",
+			"state_unlocked": "UNLOCKED",
+		}))
+	}
+	return components
+}
+
+func (b *Bridge) discoveryDevice(device *model.Device, definition *definitions.Definition) map[string]interface{} {
+	discoveryDevice := map[string]interface{}{
+		"identifiers":  []string{"steward_" + device.IEEEAddress},
+		"name":         b.names.name(device.IEEEAddress),
+		"manufacturer": device.Manufacturer,
+		"model":        device.Model,
+	}
+	if definition != nil {
+		discoveryDevice["manufacturer"] = definition.Vendor
+		discoveryDevice["model"] = fmt.Sprintf("%s (%s)", definition.Description, device.Model)
+	}
+	return discoveryDevice
+}
+
+func binarySensor(key string, deviceClass string) map[string]interface{} {
+	return map[string]interface{}{
+		"value_template": fmt.Sprintf("{{ value_json.%s }}", key),
+		"payload_on":     true,
+		"payload_off":    false,
+		"device_class":   deviceClass,
+	}
+}
@@ -0,0 +1,101 @@
+package mqtt
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/natefinch/atomic"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+)
+
+type friendlyNames struct {
+	mutex    sync.RWMutex
+	location string
+	names    map[string]string
+}
+
+func loadFriendlyNames(location string) (*friendlyNames, error) {
+	names := &friendlyNames{location: location, names: map[string]string{}}
+	if location == "" {
+		return names, nil
+	}
+	data, err := ioutil.ReadFile(location)
+	if os.IsNotExist(err) {
+		return names, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &names.names); err != nil {
+		return nil, fmt.Errorf("unable to parse friendly names [%s]: %s", location, err)
+	}
+	return names, nil
+}
+
+func (n *friendlyNames) name(ieeeAddress string) string {
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	if name, ok := n.names[ieeeAddress]; ok {
+		return name
+	}
+	return ieeeAddress
+}
+
+func (n *friendlyNames) ieeeAddress(name string) (string, bool) {
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	for ieeeAddress, friendlyName := range n.names {
+		if friendlyName == name {
+			return ieeeAddress, true
+		}
+	}
+	return "", false
+}
+
+func (n *friendlyNames) rename(ieeeAddress string, name string) error {
+	if err := validateFriendlyName(name); err != nil {
+		return err
+	}
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	for other, friendlyName := range n.names {
+		if friendlyName == name && other != ieeeAddress {
+			return fmt.Errorf("friendly name [%s] is already used by [%s]", name, other)
+		}
+	}
+	n.names[ieeeAddress] = name
+	return n.save()
+}
+
+func (n *friendlyNames) remove(ieeeAddress string) error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	delete(n.names, ieeeAddress)
+	return n.save()
+}
+
+func (n *friendlyNames) save() error {
+	if n.location == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(n.names, "", "  ")
+	if err != nil {
+		return err
+	}
+	return atomic.WriteFile(n.location, bytes.NewReader(data))
+}
+
+func validateFriendlyName(name string) error {
+	switch {
+	case name == "":
+		return fmt.Errorf("friendly name is empty")
+	case name == bridgeName:
+		return fmt.Errorf("friendly name [%s] is reserved", name)
+	case strings.ContainsAny(name, "/+#"):
+		return fmt.Errorf("friendly name [%s] contains one of the forbidden characters: / + #", name)
+	}
+	return nil
+}
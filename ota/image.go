@@ -0,0 +1,143 @@
+package ota
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+const fileIdentifier uint32 = 0x0beef11e
+
+const (
+	fieldControlSecurityCredentialVersion uint16 = 0x0001
+	fieldControlDeviceSpecificFile        uint16 = 0x0002
+	fieldControlHardwareVersions          uint16 = 0x0004
+)
+
+var fileIdentifierBytes = []byte{0x1e, 0xf1, 0xee, 0x0b}
+
+type Header struct {
+	HeaderVersion             uint16
+	HeaderLength              uint16
+	FieldControl              uint16
+	ManufacturerCode          uint16
+	ImageType                 uint16
+	FileVersion               uint32
+	StackVersion              uint16
+	HeaderString              string
+	TotalImageSize            uint32
+	SecurityCredentialVersion uint8
+	UpgradeFileDestination    [8]uint8
+	MinimumHardwareVersion    uint16
+	MaximumHardwareVersion    uint16
+}
+
+type Image struct {
+	Path   string
+	Offset int64
+	Header *Header
+}
+
+func (h *Header) HasHardwareVersions() bool {
+	return h.FieldControl&fieldControlHardwareVersions > 0
+}
+
+func (h *Header) SupportsHardwareVersion(hardwareVersion uint16) bool {
+	if !h.HasHardwareVersions() {
+		return true
+	}
+	return hardwareVersion >= h.MinimumHardwareVersion && hardwareVersion <= h.MaximumHardwareVersion
+}
+
+func Open(path string) (*Image, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	offset := bytes.Index(content, fileIdentifierBytes)
+	if offset < 0 {
+		return nil, fmt.Errorf("file [%s] is not an OTA image", path)
+	}
+	header, err := ParseHeader(bytes.NewReader(content[offset:]))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse OTA header of [%s]: %s", path, err)
+	}
+	if int64(offset)+int64(header.TotalImageSize) > int64(len(content)) {
+		return nil, fmt.Errorf("file [%s] is shorter than declared image size [%d]", path, header.TotalImageSize)
+	}
+	return &Image{Path: path, Offset: int64(offset), Header: header}, nil
+}
+
+func ParseHeader(r io.Reader) (*Header, error) {
+	var identifier uint32
+	if err := binary.Read(r, binary.LittleEndian, &identifier); err != nil {
+		return nil, err
+	}
+	if identifier != fileIdentifier {
+		return nil, errors.New("invalid OTA file identifier")
+	}
+	header := &Header{}
+	var headerString [32]uint8
+	fields := []interface{}{
+		&header.HeaderVersion,
+		&header.HeaderLength,
+		&header.FieldControl,
+		&header.ManufacturerCode,
+		&header.ImageType,
+		&header.FileVersion,
+		&header.StackVersion,
+		&headerString,
+		&header.TotalImageSize,
+	}
+	for _, field := range fields {
+		if err := binary.Read(r, binary.LittleEndian, field); err != nil {
+			return nil, err
+		}
+	}
+	header.HeaderString = strings.TrimRight(string(headerString[:]), "\x00")
+	if header.FieldControl&fieldControlSecurityCredentialVersion > 0 {
+		if err := binary.Read(r, binary.LittleEndian, &header.SecurityCredentialVersion); err != nil {
+			return nil, err
+		}
+	}
+	if header.FieldControl&fieldControlDeviceSpecificFile > 0 {
+		if err := binary.Read(r, binary.LittleEndian, &header.UpgradeFileDestination); err != nil {
+			return nil, err
+		}
+	}
+	if header.HasHardwareVersions() {
+		if err := binary.Read(r, binary.LittleEndian, &header.MinimumHardwareVersion); err != nil {
+			return nil, err
+		}
+		if err := binary.Read(r, binary.LittleEndian, &header.MaximumHardwareVersion); err != nil {
+			return nil, err
+		}
+	}
+	return header, nil
+}
+
+func (i *Image) ReadBlock(fileOffset uint32, maximumDataSize uint8) ([]uint8, error) {
+	if fileOffset >= i.Header.TotalImageSize {
+		return nil, fmt.Errorf("offset [%d] is out of image [%s] bounds", fileOffset, i.Path)
+	}
+	size := uint32(maximumDataSize)
+	if remaining := i.Header.TotalImageSize - fileOffset; remaining < size {
+		size = remaining
+	}
+	file, err := os.Open(i.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	block := make([]uint8, size)
+	_, err = file.ReadAt(block, i.Offset+int64(fileOffset))
+	if err != nil {
+		return nil, err
+	}
+	return block, nil
+}
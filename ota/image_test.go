@@ -0,0 +1,149 @@
+package ota
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func header(fieldControl uint16, totalImageSize uint32, optional ...interface{}) []byte {
+	buf := &bytes.Buffer{}
+	var headerString [32]uint8
+	copy(headerString[:], "test image")
+	for _, field := range []interface{}{fileIdentifier, uint16(0x0100), uint16(56), fieldControl, uint16(0x115f),
+		uint16(0x2801), uint32(0x00000011), uint16(0x0002), headerString, totalImageSize} {
+		binary.Write(buf, binary.LittleEndian, field)
+	}
+	for _, field := range optional {
+		binary.Write(buf, binary.LittleEndian, field)
+	}
+	return buf.Bytes()
+}
+
+func TestParseHeader(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		want    *Header
+		wantErr bool
+	}{
+		{
+			name: "mandatory fields only",
+			data: header(0x0000, 120),
+			want: &Header{HeaderVersion: 0x0100, HeaderLength: 56, ManufacturerCode: 0x115f, ImageType: 0x2801,
+				FileVersion: 0x11, StackVersion: 2, HeaderString: "test image", TotalImageSize: 120},
+		},
+		{
+			name: "all optional fields",
+			data: header(0x0007, 140, uint8(3), [8]uint8{1, 2, 3, 4, 5, 6, 7, 8}, uint16(1), uint16(5)),
+			want: &Header{HeaderVersion: 0x0100, HeaderLength: 56, FieldControl: 0x0007, ManufacturerCode: 0x115f,
+				ImageType: 0x2801, FileVersion: 0x11, StackVersion: 2, HeaderString: "test image", TotalImageSize: 140,
+				SecurityCredentialVersion: 3, UpgradeFileDestination: [8]uint8{1, 2, 3, 4, 5, 6, 7, 8},
+				MinimumHardwareVersion: 1, MaximumHardwareVersion: 5},
+		},
+		{
+			name:    "invalid identifier",
+			data:    append([]byte{0, 0, 0, 0}, header(0x0000, 120)[4:]...),
+			wantErr: true,
+		},
+		{
+			name:    "truncated mandatory fields",
+			data:    header(0x0000, 120)[:20],
+			wantErr: true,
+		},
+		{
+			name:    "missing hardware versions",
+			data:    header(0x0004, 120),
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseHeader(bytes.NewReader(test.data))
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *got != *test.want {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSupportsHardwareVersion(t *testing.T) {
+	tests := []struct {
+		fieldControl    uint16
+		hardwareVersion uint16
+		want            bool
+	}{
+		{0x0000, 100, true},
+		{0x0004, 0, false},
+		{0x0004, 1, true},
+		{0x0004, 5, true},
+		{0x0004, 6, false},
+	}
+	for _, test := range tests {
+		h := &Header{FieldControl: test.fieldControl, MinimumHardwareVersion: 1, MaximumHardwareVersion: 5}
+		if got := h.SupportsHardwareVersion(test.hardwareVersion); got != test.want {
+			t.Errorf("field control [0x%04x], version [%d]: got %t, want %t", test.fieldControl, test.hardwareVersion, got, test.want)
+		}
+	}
+}
+
+func TestOpenAndReadBlock(t *testing.T) {
+	image := header(0x0000, 0)
+	image = append(image, []byte("firmware payload")...)
+	binary.LittleEndian.PutUint32(image[52:], uint32(len(image)))
+	//vendor prefix before the file identifier is skipped
+	content := append([]byte{0xde, 0xad}, image...)
+	path := filepath.Join(t.TempDir(), "image.ota")
+	if err := ioutil.WriteFile(path, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	opened, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opened.Offset != 2 {
+		t.Errorf("offset: got %d, want 2", opened.Offset)
+	}
+
+	tests := []struct {
+		fileOffset      uint32
+		maximumDataSize uint8
+		want            []byte
+		wantErr         bool
+	}{
+		{0, 4, image[:4], false},
+		{uint32(len(image)) - 3, 64, image[len(image)-3:], false},
+		{uint32(len(image)), 4, nil, true},
+	}
+	for _, test := range tests {
+		block, err := opened.ReadBlock(test.fileOffset, test.maximumDataSize)
+		if test.wantErr != (err != nil) {
+			t.Fatalf("offset [%d]: unexpected error state: %v", test.fileOffset, err)
+		}
+		if !bytes.Equal(block, test.want) {
+			t.Errorf("offset [%d]: got %v, want %v", test.fileOffset, block, test.want)
+		}
+	}
+}
+
+func TestOpenRejectsShortFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "short.ota")
+	if err := ioutil.WriteFile(path, header(0x0000, 1000), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(path); err == nil {
+		t.Fatal("expected error for image shorter than declared size")
+	}
+}
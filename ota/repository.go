@@ -0,0 +1,97 @@
+package ota
+
+import (
+	"github.com/dyrkin/zigbee-steward/logger"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+)
+
+var log = logger.MustGetLogger("ota")
+
+type imageKey struct {
+	manufacturerCode uint16
+	imageType        uint16
+}
+
+type Repository struct {
+	directory string
+	mutex     sync.RWMutex
+	images    map[imageKey]*Image
+}
+
+func NewRepository(directory string) *Repository {
+	return &Repository{
+		directory: directory,
+		images:    map[imageKey]*Image{},
+	}
+}
+
+func (r *Repository) Directory() string {
+	return r.directory
+}
+
+func (r *Repository) Reload() error {
+	files, err := ioutil.ReadDir(r.directory)
+	if err != nil {
+		return err
+	}
+	images := map[imageKey]*Image{}
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		image, err := Open(filepath.Join(r.directory, file.Name()))
+		if err != nil {
+			log.Warningf("Skipping file: %s", err)
+			continue
+		}
+		key := imageKey{image.Header.ManufacturerCode, image.Header.ImageType}
+		if indexed, ok := images[key]; !ok || indexed.Header.FileVersion < image.Header.FileVersion {
+			images[key] = image
+		}
+		log.Debugf("Indexed OTA image [%s]. Manufacturer: [0x%04x], Image type: [0x%04x], Version: [0x%08x]",
+			image.Path, image.Header.ManufacturerCode, image.Header.ImageType, image.Header.FileVersion)
+	}
+	r.mutex.Lock()
+	r.images = images
+	r.mutex.Unlock()
+	log.Infof("Indexed %d OTA images in [%s]", len(images), r.directory)
+	return nil
+}
+
+func (r *Repository) Images() []*Image {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	var images []*Image
+	for _, image := range r.images {
+		images = append(images, image)
+	}
+	return images
+}
+
+func (r *Repository) Find(manufacturerCode uint16, imageType uint16) (*Image, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	image, ok := r.images[imageKey{manufacturerCode, imageType}]
+	return image, ok
+}
+
+func (r *Repository) FindVersion(manufacturerCode uint16, imageType uint16, fileVersion uint32) (*Image, bool) {
+	image, ok := r.Find(manufacturerCode, imageType)
+	if !ok || image.Header.FileVersion != fileVersion {
+		return nil, false
+	}
+	return image, true
+}
+
+func (r *Repository) FindUpgrade(manufacturerCode uint16, imageType uint16, currentFileVersion uint32, hardwareVersion uint16, hasHardwareVersion bool) (*Image, bool) {
+	image, ok := r.Find(manufacturerCode, imageType)
+	if !ok || image.Header.FileVersion <= currentFileVersion {
+		return nil, false
+	}
+	if hasHardwareVersion && !image.Header.SupportsHardwareVersion(hardwareVersion) {
+		return nil, false
+	}
+	return image, true
+}
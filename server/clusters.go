@@ -0,0 +1,175 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/dyrkin/zcl-go/cluster"
+	"github.com/dyrkin/zigbee-steward/converters"
+	"net/http"
+	"strings"
+)
+
+type attribute struct {
+	Id     uint16              `json:"id"`
+	Status cluster.ZclStatus   `json:"status"`
+	Type   cluster.ZclDataType `json:"type,omitempty"`
+	Value  interface{}         `json:"value,omitempty"`
+}
+
+type writeAttributesRequest struct {
+	Attributes []*writeAttribute `json:"attributes"`
+}
+
+type writeAttribute struct {
+	Id    uint16               `json:"id"`
+	Type  *cluster.ZclDataType `json:"type"`
+	Value interface{}          `json:"value"`
+}
+
+type commandRequest struct {
+	Payload json.RawMessage `json:"payload"`
+}
+
+type commandResponse struct {
+	Type     string      `json:"type"`
+	Response interface{} `json:"response"`
+}
+
+func (s *Server) readAttributes(r *http.Request) (interface{}, error) {
+	device, err := requestDevice(r)
+	if err != nil {
+		return nil, err
+	}
+	clusterId, err := requestCluster(r)
+	if err != nil {
+		return nil, err
+	}
+	endpoint, err := optionalEndpoint(r)
+	if err != nil {
+		return nil, err
+	}
+	ids := r.URL.Query().Get("ids")
+	if ids == "" {
+		return nil, badRequest("ids are required")
+	}
+	var attributeIds []uint16
+	for _, id := range strings.Split(ids, ",") {
+		attributeId, err := parseUint("attribute id", strings.TrimSpace(id), 16)
+		if err != nil {
+			return nil, err
+		}
+		attributeIds = append(attributeIds, uint16(attributeId))
+	}
+	response, err := s.steward.Device(device.IEEEAddress).Endpoint(endpoint).ReadAttributes(clusterId, attributeIds)
+	if err != nil {
+		return nil, deviceError(err)
+	}
+	attributes := []*attribute{}
+	for _, status := range response.ReadAttributeStatuses {
+		a := &attribute{Id: status.AttributeID, Status: status.Status}
+		if status.Attribute != nil {
+			a.Type = status.Attribute.DataType
+			a.Value = status.Attribute.Value
+		}
+		attributes = append(attributes, a)
+	}
+	return attributes, nil
+}
+
+func (s *Server) writeAttributes(r *http.Request) (interface{}, error) {
+	device, err := requestDevice(r)
+	if err != nil {
+		return nil, err
+	}
+	clusterId, err := requestCluster(r)
+	if err != nil {
+		return nil, err
+	}
+	endpoint, err := optionalEndpoint(r)
+	if err != nil {
+		return nil, err
+	}
+	request := &writeAttributesRequest{}
+	if err := decode(r, request); err != nil {
+		return nil, err
+	}
+	if len(request.Attributes) == 0 {
+		return nil, badRequest("attributes are required")
+	}
+	generic := s.steward.Functions().Cluster().Local().Generic(clusterId)
+	var records []*cluster.WriteAttributeRecord
+	for _, a := range request.Attributes {
+		var dataType cluster.ZclDataType
+		switch {
+		case a.Type != nil:
+			dataType = *a.Type
+		default:
+			knownType, ok := generic.AttributeType(a.Id)
+			if !ok {
+				return nil, badRequest("type of attribute [%d] is unknown and must be provided", a.Id)
+			}
+			dataType = knownType
+		}
+		value, err := converters.AttributeValue(dataType, a.Value)
+		if err != nil {
+			return nil, badRequest("attribute [%d]: %s", a.Id, err)
+		}
+		records = append(records, &cluster.WriteAttributeRecord{
+			AttributeID: a.Id,
+			Attribute:   &cluster.Attribute{DataType: dataType, Value: value},
+		})
+	}
+	response, err := s.steward.Device(device.IEEEAddress).Endpoint(endpoint).WriteAttributes(clusterId, records)
+	if err != nil {
+		return nil, deviceError(err)
+	}
+	attributes := []*attribute{}
+	for _, status := range response.WriteAttributeStatuses {
+		attributes = append(attributes, &attribute{Id: status.AttributeID, Status: status.Status})
+	}
+	return attributes, nil
+}
+
+func (s *Server) command(r *http.Request) (interface{}, error) {
+	device, err := requestDevice(r)
+	if err != nil {
+		return nil, err
+	}
+	clusterId, err := requestCluster(r)
+	if err != nil {
+		return nil, err
+	}
+	commandId, err := parseUint("command", r.PathValue("command"), 8)
+	if err != nil {
+		return nil, err
+	}
+	endpoint, err := optionalEndpoint(r)
+	if err != nil {
+		return nil, err
+	}
+	request := &commandRequest{}
+	if err := decode(r, request); err != nil {
+		return nil, err
+	}
+	command, err := s.steward.Functions().Cluster().Local().Generic(clusterId).NewCommand(uint8(commandId))
+	if err != nil {
+		return nil, notFound("%s", err)
+	}
+	if len(request.Payload) > 0 {
+		decoder := json.NewDecoder(strings.NewReader(string(request.Payload)))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(command); err != nil {
+			return nil, badRequest("invalid payload of command [%d]: %s", commandId, err)
+		}
+	}
+	response, err := s.steward.Device(device.IEEEAddress).Endpoint(endpoint).Command(clusterId, uint8(commandId), command)
+	if err != nil {
+		return nil, deviceError(err)
+	}
+	return &commandResponse{Type: fmt.Sprintf("%T", response), Response: response}, nil
+}
+
+func requestCluster(r *http.Request) (cluster.ClusterId, error) {
+	clusterId, err := parseUint("cluster", r.PathValue("cluster"), 16)
+	return cluster.ClusterId(clusterId), err
+}
@@ -0,0 +1,17 @@
+package server
+
+type Configuration struct {
+	Address string
+	//origins allowed to open the event stream. Same origin is always allowed, "*" allows any
+	AllowedOrigins []string
+	//expose prometheus metrics on /metrics
+	Metrics bool
+}
+
+func Default() *Configuration {
+	return &Configuration{
+		Address:        ":8080",
+		AllowedOrigins: []string{},
+		Metrics:        false,
+	}
+}
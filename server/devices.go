@@ -0,0 +1,168 @@
+package server
+
+import (
+	"github.com/dyrkin/zcl-go/cluster"
+	"github.com/dyrkin/zigbee-steward/db"
+	"github.com/dyrkin/zigbee-steward/model"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+const coordinatorTarget = "coordinator"
+
+type network struct {
+	Address     string  `json:"address"`
+	IEEEAddress string  `json:"ieee_address"`
+	PanId       uint16  `json:"pan_id"`
+	Channels    []uint8 `json:"channels"`
+}
+
+type permitJoinRequest struct {
+	Timeout *uint8 `json:"timeout"`
+}
+
+type bindRequest struct {
+	Cluster        uint16 `json:"cluster"`
+	Target         string `json:"target"`
+	TargetEndpoint uint8  `json:"target_endpoint"`
+}
+
+func (s *Server) network(r *http.Request) (interface{}, error) {
+	configuration := s.steward.Configuration()
+	return &network{
+		Address:     s.steward.Network().Address,
+		IEEEAddress: configuration.IEEEAddress,
+		PanId:       configuration.PanId,
+		Channels:    configuration.Channels,
+	}, nil
+}
+
+func (s *Server) permitJoin(r *http.Request) (interface{}, error) {
+	request := &permitJoinRequest{}
+	if err := decode(r, request); err != nil {
+		return nil, err
+	}
+	if request.Timeout == nil {
+		return nil, badRequest("timeout is required")
+	}
+	if err := s.steward.PermitJoin(*request.Timeout); err != nil {
+		return nil, deviceError(err)
+	}
+	return nil, nil
+}
+
+func (s *Server) devices(r *http.Request) (interface{}, error) {
+	devices := db.Database().Tables().Devices.All()
+	sort.Slice(devices, func(i, j int) bool {
+		return devices[i].IEEEAddress < devices[j].IEEEAddress
+	})
+	if devices == nil {
+		devices = []*model.Device{}
+	}
+	return devices, nil
+}
+
+func (s *Server) device(r *http.Request) (interface{}, error) {
+	return requestDevice(r)
+}
+
+func (s *Server) removeDevice(r *http.Request) (interface{}, error) {
+	device, err := requestDevice(r)
+	if err != nil {
+		return nil, err
+	}
+	force := false
+	if value := r.URL.Query().Get("force"); value != "" {
+		if force, err = strconv.ParseBool(value); err != nil {
+			return nil, badRequest("invalid force [%s]", value)
+		}
+	}
+	if err := s.steward.RemoveDevice(device.IEEEAddress, force); err != nil {
+		return nil, deviceError(err)
+	}
+	return nil, nil
+}
+
+func (s *Server) state(r *http.Request) (interface{}, error) {
+	device, err := requestDevice(r)
+	if err != nil {
+		return nil, err
+	}
+	state, _ := s.steward.GetState(device.IEEEAddress)
+	return state, nil
+}
+
+func (s *Server) setState(r *http.Request) (interface{}, error) {
+	device, err := requestDevice(r)
+	if err != nil {
+		return nil, err
+	}
+	state := model.State{}
+	if err := decode(r, &state); err != nil {
+		return nil, err
+	}
+	if err := s.steward.SetState(device.IEEEAddress, state); err != nil {
+		return nil, deviceError(err)
+	}
+	state, _ = s.steward.GetState(device.IEEEAddress)
+	return state, nil
+}
+
+func (s *Server) bind(r *http.Request) (interface{}, error) {
+	return s.binding(r, true)
+}
+
+func (s *Server) unbind(r *http.Request) (interface{}, error) {
+	return s.binding(r, false)
+}
+
+func (s *Server) binding(r *http.Request, bind bool) (interface{}, error) {
+	device, err := requestDevice(r)
+	if err != nil {
+		return nil, err
+	}
+	endpoint, err := optionalEndpoint(r)
+	if err != nil {
+		return nil, err
+	}
+	request := &bindRequest{}
+	if err := decode(r, request); err != nil {
+		return nil, err
+	}
+	target, targetEndpoint := request.Target, request.TargetEndpoint
+	switch target {
+	case "", coordinatorTarget:
+		target = s.steward.Configuration().IEEEAddress
+		if targetEndpoint == 0 {
+			targetEndpoint = s.steward.HostEndpoint()
+		}
+	default:
+		if !db.Database().Tables().Devices.Exists(target) {
+			return nil, deviceNotFound(target)
+		}
+		if targetEndpoint == 0 {
+			return nil, badRequest("target_endpoint is required")
+		}
+	}
+	deviceFunctions := s.steward.Device(device.IEEEAddress).Endpoint(endpoint)
+	clusterId := cluster.ClusterId(request.Cluster)
+	if bind {
+		_, err = deviceFunctions.Bind(clusterId, target, targetEndpoint)
+	} else {
+		_, err = deviceFunctions.Unbind(clusterId, target, targetEndpoint)
+	}
+	if err != nil {
+		return nil, deviceError(err)
+	}
+	return nil, nil
+}
+
+func requestDevice(r *http.Request) (*model.Device, error) {
+	ieeeAddress := r.PathValue("ieee")
+	device, ok := db.Database().Tables().Devices.Get(ieeeAddress)
+	if !ok {
+		return nil, deviceNotFound(ieeeAddress)
+	}
+	return device, nil
+}
@@ -0,0 +1,38 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"github.com/dyrkin/zigbee-steward/model"
+	"net/http"
+)
+
+type apiError struct {
+	status  int
+	code    string
+	message string
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
+func badRequest(format string, args ...interface{}) error {
+	return &apiError{status: http.StatusBadRequest, code: "bad_request", message: fmt.Sprintf(format, args...)}
+}
+
+func notFound(format string, args ...interface{}) error {
+	return &apiError{status: http.StatusNotFound, code: "not_found", message: fmt.Sprintf(format, args...)}
+}
+
+func deviceError(err error) error {
+	var invalidRequest *model.InvalidRequestError
+	if errors.As(err, &invalidRequest) {
+		return &apiError{status: http.StatusBadRequest, code: "invalid_request", message: err.Error()}
+	}
+	return &apiError{status: http.StatusBadGateway, code: "device_error", message: err.Error()}
+}
+
+func deviceNotFound(ieeeAddress string) error {
+	return notFound("device [%s] is not registered", ieeeAddress)
+}
@@ -0,0 +1,31 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"github.com/dyrkin/zigbee-steward/model"
+	"net/http"
+	"testing"
+)
+
+func TestDeviceError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"invalid request", model.InvalidRequest("unsupported state [color]"), http.StatusBadRequest, "invalid_request"},
+		{"wrapped invalid request", fmt.Errorf("unable to set [state]: %w", model.InvalidRequest("invalid value")), http.StatusBadRequest, "invalid_request"},
+		{"radio failure", errors.New("timeout. didn't receive response for transaction: 1"), http.StatusBadGateway, "device_error"},
+	}
+	for _, test := range tests {
+		apiErr, ok := deviceError(test.err).(*apiError)
+		if !ok {
+			t.Fatalf("%s: expected api error", test.name)
+		}
+		if apiErr.status != test.wantStatus || apiErr.code != test.wantCode || apiErr.message != test.err.Error() {
+			t.Errorf("%s: got %d %s [%s], want %d %s", test.name, apiErr.status, apiErr.code, apiErr.message, test.wantStatus, test.wantCode)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package server
+
+import (
+	"github.com/dyrkin/zcl-go/cluster"
+	"github.com/dyrkin/zigbee-steward"
+	"github.com/dyrkin/zigbee-steward/clusters"
+	"github.com/dyrkin/zigbee-steward/model"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	eventDeviceRegistered     = "device_registered"
+	eventDeviceUnregistered   = "device_unregistered"
+	eventDeviceAvailable      = "device_available"
+	eventIncomingMessage      = "incoming_message"
+	eventZoneStatusChange     = "zone_status_change"
+	eventLockOperation        = "lock_operation"
+	eventLockProgramming      = "lock_programming"
+	eventOTAProgress          = "ota_progress"
+	eventEnergyMeasurement    = "energy_measurement"
+	eventSensorReading        = "sensor_reading"
+	eventStateChange          = "state_change"
+	eventClick                = "click"
+	eventTuyaReport           = "tuya_report"
+	eventInterviewProgress    = "interview_progress"
+	eventLinkQualityAlert     = "link_quality_alert"
+	subscriberEventsQueueSize = 100
+)
+
+type event struct {
+	Type        string      `json:"type"`
+	Time        time.Time   `json:"time"`
+	IEEEAddress string      `json:"ieee_address"`
+	Cluster     *uint16     `json:"cluster,omitempty"`
+	Data        interface{} `json:"data"`
+}
+
+type eventFilter struct {
+	IEEEAddresses []string `json:"ieee"`
+	Clusters      []uint16 `json:"cluster"`
+	Types         []string `json:"type"`
+}
+
+type subscriber struct {
+	events chan *event
+	mutex  sync.RWMutex
+	filter *eventFilter
+}
+
+type eventHub struct {
+	mutex       sync.RWMutex
+	subscribers map[*subscriber]bool
+}
+
+func newEventHub() *eventHub {
+	return &eventHub{subscribers: map[*subscriber]bool{}}
+}
+
+func (h *eventHub) subscribe(filter *eventFilter) *subscriber {
+	sub := &subscriber{events: make(chan *event, subscriberEventsQueueSize), filter: filter}
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.subscribers[sub] = true
+	return sub
+}
+
+func (h *eventHub) unsubscribe(sub *subscriber) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	delete(h.subscribers, sub)
+}
+
+func (h *eventHub) publish(e *event) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	for sub := range h.subscribers {
+		if !sub.matches(e) {
+			continue
+		}
+		select {
+		case sub.events <- e:
+		default:
+			log.Errorf("Event subscriber queue has no capacity. Dropping [%s] event of [%s]", e.Type, e.IEEEAddress)
+		}
+	}
+}
+
+func (h *eventHub) listen(channels *steward.Channels) {
+	for {
+		select {
+		case device := <-channels.OnDeviceRegistered():
+			h.publish(newEvent(eventDeviceRegistered, device, nil, device))
+		case device := <-channels.OnDeviceUnregistered():
+			h.publish(newEvent(eventDeviceUnregistered, device, nil, device))
+		case device := <-channels.OnDeviceBecameAvailable():
+			h.publish(newEvent(eventDeviceAvailable, device, nil, device))
+		case message := <-channels.OnDeviceIncomingMessage():
+			clusterId := message.IncomingMessage.ClusterID
+			h.publish(newEvent(eventIncomingMessage, message.Device, &clusterId, message))
+		case change := <-channels.OnDeviceZoneStatusChange():
+			h.publish(newEvent(eventZoneStatusChange, change.Device, clusterOf(clusters.IASZone), change))
+		case operation := <-channels.OnDeviceLockOperationEvent():
+			h.publish(newEvent(eventLockOperation, operation.Device, clusterOf(clusters.DoorLock), operation))
+		case programming := <-channels.OnDeviceLockProgrammingEvent():
+			h.publish(newEvent(eventLockProgramming, programming.Device, clusterOf(clusters.DoorLock), programming))
+		case progress := <-channels.OnDeviceOTAProgress():
+			h.publish(newEvent(eventOTAProgress, progress.Device, clusterOf(cluster.OTA), progress))
+		case measurement := <-channels.OnDeviceEnergyMeasurement():
+			h.publish(newEvent(eventEnergyMeasurement, measurement.Device, nil, measurement))
+		case reading := <-channels.OnDeviceSensorReading():
+			h.publish(newEvent(eventSensorReading, reading.Device, nil, reading))
+		case change := <-channels.OnDeviceStateChange():
+			h.publish(newEvent(eventStateChange, change.Device, nil, change))
+		case click := <-channels.OnDeviceClick():
+			h.publish(newEvent(eventClick, click.Device, nil, click))
+		case report := <-channels.OnDeviceTuyaReport():
+			h.publish(newEvent(eventTuyaReport, report.Device, clusterOf(clusters.Tuya), report))
+		case progress := <-channels.OnDeviceInterviewProgress():
+			h.publish(newEvent(eventInterviewProgress, progress.Device, nil, progress))
+		case alert := <-channels.OnDeviceLinkQualityAlert():
+			h.publish(newEvent(eventLinkQualityAlert, alert.Device, nil, alert))
+		}
+	}
+}
+
+func newEvent(eventType string, device *model.Device, clusterId *uint16, data interface{}) *event {
+	return &event{
+		Type:        eventType,
+		Time:        time.Now(),
+		IEEEAddress: device.IEEEAddress,
+		Cluster:     clusterId,
+		Data:        data,
+	}
+}
+
+func clusterOf(clusterId cluster.ClusterId) *uint16 {
+	id := uint16(clusterId)
+	return &id
+}
+
+func (s *subscriber) setFilter(filter *eventFilter) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.filter = filter
+}
+
+func (s *subscriber) matches(e *event) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.filter.matches(e)
+}
+
+func (f *eventFilter) matches(e *event) bool {
+	if len(f.Types) > 0 && !containsString(f.Types, e.Type) {
+		return false
+	}
+	if len(f.IEEEAddresses) > 0 && !containsString(f.IEEEAddresses, e.IEEEAddress) {
+		return false
+	}
+	if len(f.Clusters) > 0 {
+		if e.Cluster == nil {
+			return false
+		}
+		for _, clusterId := range f.Clusters {
+			if clusterId == *e.Cluster {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
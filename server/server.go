@@ -0,0 +1,144 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/dyrkin/zigbee-steward"
+	"github.com/dyrkin/zigbee-steward/logger"
+	"github.com/dyrkin/zigbee-steward/metrics"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var log = logger.MustGetLogger("server")
+
+const shutdownTimeout = 5 * time.Second
+
+type handlerFunc func(r *http.Request) (interface{}, error)
+
+type errorBody struct {
+	Error *errorDetails `json:"error"`
+}
+
+type errorDetails struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type Server struct {
+	steward *steward.Steward
+	config  *Configuration
+	mux     *http.ServeMux
+	http    *http.Server
+	events  *eventHub
+}
+
+func New(steward *steward.Steward, config *Configuration) *Server {
+	s := &Server{
+		steward: steward,
+		config:  config,
+		mux:     http.NewServeMux(),
+		events:  newEventHub(),
+	}
+	s.routes()
+	go s.events.listen(steward.Channels())
+	s.http = &http.Server{Addr: config.Address, Handler: s.mux}
+	return s
+}
+
+func (s *Server) Handler() http.Handler {
+	return s.mux
+}
+
+func (s *Server) Start() error {
+	log.Infof("Starting HTTP server on [%s]", s.config.Address)
+	err := s.http.ListenAndServe()
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
+
+func (s *Server) Stop() error {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return s.http.Shutdown(ctx)
+}
+
+func (s *Server) routes() {
+	s.mux.HandleFunc("GET /api/network", s.handle(s.network))
+	s.mux.HandleFunc("POST /api/permit_join", s.handle(s.permitJoin))
+	s.mux.HandleFunc("GET /api/devices", s.handle(s.devices))
+	s.mux.HandleFunc("GET /api/devices/{ieee}", s.handle(s.device))
+	s.mux.HandleFunc("DELETE /api/devices/{ieee}", s.handle(s.removeDevice))
+	s.mux.HandleFunc("GET /api/devices/{ieee}/state", s.handle(s.state))
+	s.mux.HandleFunc("PUT /api/devices/{ieee}/state", s.handle(s.setState))
+	s.mux.HandleFunc("POST /api/devices/{ieee}/bindings", s.handle(s.bind))
+	s.mux.HandleFunc("DELETE /api/devices/{ieee}/bindings", s.handle(s.unbind))
+	s.mux.HandleFunc("GET /api/devices/{ieee}/clusters/{cluster}/attributes", s.handle(s.readAttributes))
+	s.mux.HandleFunc("PUT /api/devices/{ieee}/clusters/{cluster}/attributes", s.handle(s.writeAttributes))
+	s.mux.HandleFunc("POST /api/devices/{ieee}/clusters/{cluster}/commands/{command}", s.handle(s.command))
+	s.mux.HandleFunc("GET /api/events", s.stream)
+	if s.config.Metrics {
+		s.mux.Handle("GET /metrics", metrics.Handler())
+	}
+}
+
+func (s *Server) handle(handler handlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		result, err := handler(r)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+		if result == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writeJSON(w, http.StatusOK, result)
+	}
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	e, ok := err.(*apiError)
+	if !ok {
+		e = &apiError{status: http.StatusInternalServerError, code: "internal_error", message: err.Error()}
+	}
+	log.Errorf("%s %s failed: %s", r.Method, r.URL.Path, e.message)
+	writeJSON(w, e.status, &errorBody{Error: &errorDetails{Code: e.code, Message: e.message}})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Errorf("Unable to write response: %s", err)
+	}
+}
+
+func decode(r *http.Request, body interface{}) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(body); err != nil {
+		return badRequest("invalid request body: %s", err)
+	}
+	return nil
+}
+
+func parseUint(name string, value string, bitSize int) (uint64, error) {
+	parsed, err := strconv.ParseUint(value, 0, bitSize)
+	if err != nil {
+		return 0, badRequest("invalid %s [%s]", name, value)
+	}
+	return parsed, nil
+}
+
+func optionalEndpoint(r *http.Request) (uint8, error) {
+	value := r.URL.Query().Get("endpoint")
+	if value == "" {
+		return 0, nil
+	}
+	endpoint, err := parseUint("endpoint", value, 8)
+	return uint8(endpoint), err
+}
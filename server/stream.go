@@ -0,0 +1,121 @@
+package server
+
+import (
+	"github.com/gorilla/websocket"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	streamWriteTimeout = 10 * time.Second
+	streamPongTimeout  = 60 * time.Second
+	streamPingPeriod   = streamPongTimeout * 9 / 10
+)
+
+func (s *Server) upgrader() *websocket.Upgrader {
+	return &websocket.Upgrader{CheckOrigin: s.checkOrigin}
+}
+
+func (s *Server) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	for _, allowed := range s.config.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	u, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(u.Host, r.Host)
+}
+
+func (s *Server) stream(w http.ResponseWriter, r *http.Request) {
+	filter, err := queryFilter(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	conn, err := s.upgrader().Upgrade(w, r, nil)
+	if err != nil {
+		log.Errorf("Unable to open event stream: %s", err)
+		return
+	}
+	sub := s.events.subscribe(filter)
+	log.Debugf("Event stream opened by [%s]", r.RemoteAddr)
+	done := make(chan struct{})
+	go s.readFilters(conn, sub, done)
+	s.writeEvents(conn, sub, done)
+	s.events.unsubscribe(sub)
+	conn.Close()
+	log.Debugf("Event stream closed by [%s]", r.RemoteAddr)
+}
+
+func (s *Server) readFilters(conn *websocket.Conn, sub *subscriber, done chan struct{}) {
+	defer close(done)
+	conn.SetReadDeadline(time.Now().Add(streamPongTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(streamPongTimeout))
+	})
+	//clients replace their filter by sending it as a json message
+	for {
+		filter := &eventFilter{}
+		if err := conn.ReadJSON(filter); err != nil {
+			if _, ok := err.(*websocket.CloseError); !ok && !strings.Contains(err.Error(), "timeout") {
+				log.Errorf("Unable to read event filter: %s", err)
+			}
+			return
+		}
+		sub.setFilter(filter)
+	}
+}
+
+func (s *Server) writeEvents(conn *websocket.Conn, sub *subscriber, done chan struct{}) {
+	ticker := time.NewTicker(streamPingPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case e := <-sub.events:
+			conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+			if err := conn.WriteJSON(e); err != nil {
+				log.Errorf("Unable to write event: %s", err)
+				return
+			}
+		case <-ticker.C:
+			conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+func queryFilter(r *http.Request) (*eventFilter, error) {
+	query := r.URL.Query()
+	filter := &eventFilter{
+		IEEEAddresses: splitQuery(query.Get("ieee")),
+		Types:         splitQuery(query.Get("type")),
+	}
+	for _, value := range splitQuery(query.Get("cluster")) {
+		clusterId, err := parseUint("cluster", value, 16)
+		if err != nil {
+			return nil, err
+		}
+		filter.Clusters = append(filter.Clusters, uint16(clusterId))
+	}
+	return filter, nil
+}
+
+func splitQuery(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
@@ -1,14 +1,19 @@
 package steward
 
 import (
+	"fmt"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/dyrkin/zcl-go"
 	"github.com/dyrkin/zcl-go/cluster"
+	"github.com/dyrkin/zigbee-steward/clusters"
 	"github.com/dyrkin/zigbee-steward/configuration"
 	"github.com/dyrkin/zigbee-steward/coordinator"
 	"github.com/dyrkin/zigbee-steward/db"
+	"github.com/dyrkin/zigbee-steward/definitions"
 	"github.com/dyrkin/zigbee-steward/functions"
+	"github.com/dyrkin/zigbee-steward/host"
 	"github.com/dyrkin/zigbee-steward/logger"
+	"github.com/dyrkin/zigbee-steward/metrics"
 	"github.com/dyrkin/zigbee-steward/model"
 	"github.com/dyrkin/znp-go"
 )
@@ -22,30 +27,64 @@ type Steward struct {
 	zcl               *zcl.Zcl
 	channels          *Channels
 	functions         *functions.Functions
+	iasAceHandler     IASACEHandler
+	ota               *otaServer
+	host              *host.Host
+	definitions       *definitions.Registry
+	states            *stateCache
+	lastSeen          *lastSeenCache
+	scalingReads      *scalingReads
 }
 
-func New(configuration *configuration.Configuration) *Steward {
+const hostEndpoint uint8 = 0x01
+
+// New returns an error when the configuration is invalid. Use configuration.Load to get one with the network parameters generated
+func New(configuration *configuration.Configuration) (*Steward, error) {
+	if err := configuration.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
 	coordinator := coordinator.New(configuration)
 	zcl := zcl.New()
+	clusters.Register(zcl.ClusterLibrary())
 	steward := &Steward{
 		configuration:     configuration,
 		coordinator:       coordinator,
 		registrationQueue: make(chan *znp.ZdoEndDeviceAnnceInd),
 		zcl:               zcl,
+		ota:               newOTAServer(configuration.OTA),
+		definitions:       newDefinitions(configuration.DefinitionsDirectory),
+		states:            newStateCache(),
+		lastSeen:          newLastSeenCache(),
+		scalingReads:      newScalingReads(),
 		channels: &Channels{
-			onDeviceRegistered:      make(chan *model.Device, 10),
-			onDeviceBecameAvailable: make(chan *model.Device, 10),
-			onDeviceUnregistered:    make(chan *model.Device, 10),
-			onDeviceIncomingMessage: make(chan *model.DeviceIncomingMessage, 100),
+			onDeviceRegistered:           make(chan *model.Device, 10),
+			onDeviceBecameAvailable:      make(chan *model.Device, 10),
+			onDeviceUnregistered:         make(chan *model.Device, 10),
+			onDeviceIncomingMessage:      make(chan *model.DeviceIncomingMessage, 100),
+			onDeviceZoneStatusChange:     make(chan *model.DeviceZoneStatusChange, 100),
+			onDeviceLockOperationEvent:   make(chan *model.DeviceLockOperationEvent, 100),
+			onDeviceLockProgrammingEvent: make(chan *model.DeviceLockProgrammingEvent, 100),
+			onDeviceOTAProgress:          make(chan *model.DeviceOTAProgress, 100),
+			onDeviceEnergyMeasurement:    make(chan *model.DeviceEnergyMeasurement, 100),
+			onDeviceSensorReading:        make(chan *model.DeviceSensorReading, 100),
+			onDeviceStateChange:          make(chan *model.DeviceStateChange, 100),
+			onDeviceClick:                make(chan *model.DeviceClick, 100),
+			onDeviceTuyaReport:           make(chan *model.DeviceTuyaReport, 100),
+			onDeviceInterviewProgress:    make(chan *model.DeviceInterviewProgress, 100),
+			onDeviceLinkQualityAlert:     make(chan *model.DeviceLinkQualityAlert, 100),
 		},
 	}
 	steward.functions = functions.New(coordinator, zcl)
-	return steward
+	steward.host = host.New(coordinator.Endpoints(), steward.functions.Cluster().Global())
+	steward.registerHostedClusters()
+	steward.registerMetrics()
+	return steward, nil
 }
 
 func (s *Steward) Start() {
 	go s.enableRegistrationQueue()
 	go s.enableListeners()
+	s.coordinator.SetEndpoints(s.host.Descriptors())
 	err := s.coordinator.Start()
 	if err != nil {
 		panic(err)
@@ -60,6 +99,18 @@ func (s *Steward) Functions() *functions.Functions {
 	return s.functions
 }
 
+func (s *Steward) Device(ieeeAddress string) *functions.DeviceFunctions {
+	return s.functions.Device(ieeeAddress)
+}
+
+func (s *Steward) Host() *host.Host {
+	return s.host
+}
+
+func (s *Steward) HostEndpoint() uint8 {
+	return hostEndpoint
+}
+
 func (s *Steward) Network() *coordinator.Network {
 	return s.coordinator.Network()
 }
@@ -68,6 +119,17 @@ func (s *Steward) Configuration() *configuration.Configuration {
 	return s.configuration
 }
 
+func (s *Steward) registerHostedClusters() {
+	endpoint, ok := s.host.Endpoint(hostEndpoint)
+	if !ok {
+		log.Errorf("Endpoint [%d] is not registered. Hosted clusters are disabled", hostEndpoint)
+		return
+	}
+	endpoint.AddInCluster(s.timeCluster()).
+		AddInCluster(s.iasAceCluster()).
+		AddInCluster(s.otaCluster())
+}
+
 func (s *Steward) enableListeners() {
 	for {
 		select {
@@ -92,7 +154,9 @@ func (s *Steward) enableRegistrationQueue() {
 
 func (s *Steward) registerDevice(announcedDevice *znp.ZdoEndDeviceAnnceInd) {
 	ieeeAddress := announcedDevice.IEEEAddr
+	log := log.With(logger.FieldIEEE, ieeeAddress, logger.FieldNwk, announcedDevice.NwkAddr)
 	log.Infof("Registering device [%s]", ieeeAddress)
+	s.lastSeen.touch(ieeeAddress)
 	if device, ok := db.Database().Tables().Devices.Get(ieeeAddress); ok {
 		log.Debugf("Device [%s] already exists in DB. Updating network address", ieeeAddress)
 		device.NetworkAddress = announcedDevice.NwkAddr
@@ -100,6 +164,7 @@ func (s *Steward) registerDevice(announcedDevice *znp.ZdoEndDeviceAnnceInd) {
 		select {
 		case s.channels.onDeviceBecameAvailable <- device:
 		default:
+			metrics.ChannelDrop("onDeviceBecameAvailable")
 			log.Errorf("onDeviceBecameAvailable channel has no capacity. Maybe channel has no subscribers")
 		}
 		return
@@ -112,10 +177,13 @@ func (s *Steward) registerDevice(announcedDevice *znp.ZdoEndDeviceAnnceInd) {
 	if announcedDevice.Capabilities.MainPowered > 0 {
 		device.MainPowered = true
 	}
+	s.notifyInterviewProgress(device, model.InterviewStarted, 0, nil)
 
+	s.notifyInterviewProgress(device, model.InterviewBasicAttributes, 0, nil)
 	deviceDetails, err := s.Functions().Cluster().Global().ReadAttributes(nwkAddress, cluster.Basic, []uint16{0x0004, 0x0005, 0x0007})
 	if err != nil {
 		log.Errorf("Unable to register device: %s", err)
+		s.notifyInterviewProgress(device, model.InterviewFailed, 0, err)
 		return
 	}
 	if manufacturer, ok := deviceDetails.ReadAttributeStatuses[0].Attribute.Value.(string); ok {
@@ -129,9 +197,11 @@ func (s *Steward) registerDevice(announcedDevice *znp.ZdoEndDeviceAnnceInd) {
 	}
 
 	log.Debugf("Request node description: [%s]", ieeeAddress)
+	s.notifyInterviewProgress(device, model.InterviewNodeDescription, 0, nil)
 	nodeDescription, err := s.coordinator.NodeDescription(nwkAddress)
 	if err != nil {
 		log.Errorf("Unable to register device: %s", err)
+		s.notifyInterviewProgress(device, model.InterviewFailed, 0, err)
 		return
 	}
 
@@ -139,14 +209,17 @@ func (s *Steward) registerDevice(announcedDevice *znp.ZdoEndDeviceAnnceInd) {
 	device.ManufacturerId = nodeDescription.ManufacturerCode
 
 	log.Debugf("Request active endpoints: [%s]", ieeeAddress)
+	s.notifyInterviewProgress(device, model.InterviewActiveEndpoints, 0, nil)
 	activeEndpoints, err := s.coordinator.ActiveEndpoints(nwkAddress)
 	if err != nil {
 		log.Errorf("Unable to register device: %s", err)
+		s.notifyInterviewProgress(device, model.InterviewFailed, 0, err)
 		return
 	}
 
 	for _, ep := range activeEndpoints.ActiveEPList {
 		log.Debugf("Request endpoint description: [%s], ep: [%d]", ieeeAddress, ep)
+		s.notifyInterviewProgress(device, model.InterviewEndpointDescription, ep, nil)
 		simpleDescription, err := s.coordinator.SimpleDescription(nwkAddress, ep)
 		if err != nil {
 			log.Errorf("Unable to receive endpoint data: %d. Reason: %s", ep, err)
@@ -156,16 +229,24 @@ func (s *Steward) registerDevice(announcedDevice *znp.ZdoEndDeviceAnnceInd) {
 		device.Endpoints = append(device.Endpoints, endpoint)
 	}
 
+	s.interviewEnergyScaling(device)
+
 	db.Database().Tables().Devices.Add(device)
 	select {
 	case s.channels.onDeviceRegistered <- device:
 	default:
+		metrics.ChannelDrop("onDeviceRegistered")
 		log.Errorf("onDeviceRegistered channel has no capacity. Maybe channel has no subscribers")
 	}
 
+	s.notifyInterviewProgress(device, model.InterviewConfiguration, 0, nil)
+	s.enrollZones(device)
+	s.configureDevice(device)
+	s.notifyInterviewProgress(device, model.InterviewCompleted, 0, nil)
+
 	log.Infof("Registered new device [%s]. Manufacturer: [%s], Model: [%s], Logical type: [%s]",
 		ieeeAddress, device.Manufacturer, device.Model, device.LogicalType)
-	log.Debugf("Registered new device:\n%s", func() string { return spew.Sdump(device) })
+	log.Debugf("Registered new device:\n%s", logger.Lazy(func() string { return spew.Sdump(device) }))
 }
 
 func (s *Steward) createEndpoint(simpleDescription *znp.ZdoSimpleDescRsp) *model.Endpoint {
@@ -193,39 +274,76 @@ func (s *Steward) createClusters(clusterIds []uint16) []*model.Cluster {
 }
 
 func (s *Steward) processIncomingMessage(incomingMessage *znp.AfIncomingMessage) {
+	log := log.With(logger.FieldNwk, incomingMessage.SrcAddr, logger.FieldCluster, incomingMessage.ClusterID,
+		logger.FieldTsn, incomingMessage.TransSeqNumber)
 	zclIncomingMessage, err := s.zcl.ToZclIncomingMessage(incomingMessage)
 	if err == nil {
-		log.Debugf("Foundation Frame Payload\n%s\n", func() string { return spew.Sdump(zclIncomingMessage) })
+		log.Debugf("Foundation Frame Payload\n%s\n", logger.Lazy(func() string { return spew.Sdump(zclIncomingMessage) }))
 		if device, ok := db.Database().Tables().Devices.GetByNetworkAddress(incomingMessage.SrcAddr); ok {
+			s.lastSeen.touch(device.IEEEAddress)
+			metrics.IncomingMessage(incomingMessage.ClusterID, device.IEEEAddress, incomingMessage.LinkQuality)
 			deviceIncomingMessage := &model.DeviceIncomingMessage{
 				Device:          device,
+				GroupId:         incomingMessage.GroupID,
+				SrcEndpoint:     incomingMessage.SrcEndpoint,
+				DstEndpoint:     incomingMessage.DstEndpoint,
+				WasBroadcast:    incomingMessage.WasBroadcast > 0,
+				LinkQuality:     incomingMessage.LinkQuality,
+				RSSI:            model.RSSI(incomingMessage.LinkQuality),
+				SecurityUse:     incomingMessage.SecurityUse > 0,
+				Timestamp:       incomingMessage.Timestamp,
 				IncomingMessage: zclIncomingMessage,
 			}
+			s.updateLinkQuality(device, incomingMessage.LinkQuality)
 			select {
 			case s.channels.onDeviceIncomingMessage <- deviceIncomingMessage:
 			default:
+				metrics.ChannelDrop("onDeviceIncomingMessage")
 				log.Errorf("onDeviceIncomingMessage channel has no capacity. Maybe channel has no subscribers")
 			}
+			switch cluster.ClusterId(incomingMessage.ClusterID) {
+			case clusters.IASZone:
+				s.processIASZoneMessage(deviceIncomingMessage)
+			case clusters.DoorLock:
+				s.processDoorLockMessage(deviceIncomingMessage)
+			case clusters.Metering, clusters.ElectricalMeasurement:
+				s.processEnergyMessage(deviceIncomingMessage)
+			case clusters.Tuya:
+				s.processTuyaMessage(deviceIncomingMessage)
+			}
+			s.processSensorMessage(deviceIncomingMessage)
+			s.processXiaomiMessage(deviceIncomingMessage, incomingMessage.Data)
+			s.processDefinitionMessage(deviceIncomingMessage)
+			s.host.Process(deviceIncomingMessage)
 		} else {
 			log.Errorf("Received message from unknown device [%s]", incomingMessage.SrcAddr)
 		}
 	} else {
-		log.Errorf("Unsupported incoming message:\n%s\n", func() string { return spew.Sdump(incomingMessage) })
+		log.Errorf("Unsupported incoming message:\n%s\n", logger.Lazy(func() string { return spew.Sdump(incomingMessage) }))
 	}
 }
 
 func (s *Steward) unregisterDevice(deviceLeave *znp.ZdoLeaveInd) {
 	ieeeAddress := deviceLeave.ExtAddr
 	if device, ok := db.Database().Tables().Devices.Get(ieeeAddress); ok {
-		log.Infof("Unregistering device: [%s]", ieeeAddress)
-		db.Database().Tables().Devices.Remove(ieeeAddress)
-		select {
-		case s.channels.onDeviceUnregistered <- device:
-		default:
-			log.Errorf("onDeviceUnregistered channel has no capacity. Maybe channel has no subscribers")
-		}
+		s.removeDevice(device)
+	}
+}
 
-		log.Infof("Unregistered device [%s]. Manufacturer: [%s], Model: [%s], Logical type: [%s]",
-			ieeeAddress, device.Manufacturer, device.Model, device.LogicalType)
+func (s *Steward) removeDevice(device *model.Device) {
+	ieeeAddress := device.IEEEAddress
+	log.Infof("Unregistering device: [%s]", ieeeAddress)
+	db.Database().Tables().Devices.Remove(ieeeAddress)
+	s.states.remove(ieeeAddress)
+	s.lastSeen.remove(ieeeAddress)
+	metrics.ForgetDevice(ieeeAddress)
+	select {
+	case s.channels.onDeviceUnregistered <- device:
+	default:
+		metrics.ChannelDrop("onDeviceUnregistered")
+		log.Errorf("onDeviceUnregistered channel has no capacity. Maybe channel has no subscribers")
 	}
+
+	log.Infof("Unregistered device [%s]. Manufacturer: [%s], Model: [%s], Logical type: [%s]",
+		ieeeAddress, device.Manufacturer, device.Model, device.LogicalType)
 }
@@ -0,0 +1,64 @@
+package steward
+
+import (
+	"github.com/dyrkin/zigbee-steward/definitions"
+	"github.com/dyrkin/zigbee-steward/metrics"
+	"github.com/dyrkin/zigbee-steward/model"
+)
+
+func newDefinitions(directory string) *definitions.Registry {
+	registry := definitions.NewRegistry()
+	if directory == "" {
+		return registry
+	}
+	if err := registry.LoadDirectory(directory); err != nil {
+		log.Errorf("Unable to load device definitions from [%s]: %s", directory, err)
+	}
+	return registry
+}
+
+func (s *Steward) Definitions() *definitions.Registry {
+	return s.definitions
+}
+
+func (s *Steward) definitionContext() *definitions.Context {
+	return &definitions.Context{
+		Functions:              s.functions,
+		CoordinatorIEEEAddress: s.configuration.IEEEAddress,
+		CoordinatorEndpoint:    hostEndpoint,
+	}
+}
+
+func (s *Steward) configureDevice(device *model.Device) {
+	definition, ok := s.definitions.Find(device)
+	if !ok {
+		log.Debugf("No definition found for device [%s]. Manufacturer: [%s], Model: [%s]",
+			device.IEEEAddress, device.Manufacturer, device.Model)
+		return
+	}
+	log.Infof("Configuring device [%s] as [%s %s]", device.IEEEAddress, definition.Vendor, definition.Description)
+	for _, step := range definition.Configure {
+		if err := step.Run(s.definitionContext(), device); err != nil {
+			log.Errorf("Unable to configure device [%s], step: [%s]. Reason: %s", device.IEEEAddress, step.Name, err)
+		}
+	}
+}
+
+func (s *Steward) processDefinitionMessage(message *model.DeviceIncomingMessage) {
+	state := s.definition(message.Device).Convert(message)
+	if len(state) == 0 {
+		return
+	}
+	s.states.update(message.Device.IEEEAddress, state)
+	stateChange := &model.DeviceStateChange{
+		Device:   message.Device,
+		Endpoint: message.IncomingMessage.SrcEndpoint,
+		State:    state,
+	}
+	select {
+	case s.channels.onDeviceStateChange <- stateChange:
+	default:
+		metrics.ChannelDrop("onDeviceStateChange")
+		log.Errorf("onDeviceStateChange channel has no capacity. Maybe channel has no subscribers")
+	}
+}
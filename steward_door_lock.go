@@ -0,0 +1,51 @@
+package steward
+
+import (
+	"github.com/dyrkin/zigbee-steward/clusters"
+	"github.com/dyrkin/zigbee-steward/metrics"
+	"github.com/dyrkin/zigbee-steward/model"
+)
+
+func (s *Steward) processDoorLockMessage(message *model.DeviceIncomingMessage) {
+	device := message.Device
+	endpoint := message.IncomingMessage.SrcEndpoint
+	switch command := message.IncomingMessage.Data.Command.(type) {
+	case *clusters.OperationEventNotificationCommand:
+		operationEvent := &model.DeviceLockOperationEvent{
+			Device:    device,
+			Endpoint:  endpoint,
+			Source:    model.LockEventSource(command.OperationEventSource),
+			Code:      model.LockOperationEventCode(command.OperationEventCode),
+			UserId:    command.UserID,
+			PIN:       command.PIN,
+			LocalTime: clusters.ToTime(command.LocalTime),
+			Data:      command.Data,
+		}
+		log.Infof("Received lock operation event: [%s], ep: [%d], code: [%s]", device.IEEEAddress, endpoint, operationEvent.Code)
+		select {
+		case s.channels.onDeviceLockOperationEvent <- operationEvent:
+		default:
+			metrics.ChannelDrop("onDeviceLockOperationEvent")
+			log.Errorf("onDeviceLockOperationEvent channel has no capacity. Maybe channel has no subscribers")
+		}
+	case *clusters.ProgrammingEventNotificationCommand:
+		programmingEvent := &model.DeviceLockProgrammingEvent{
+			Device:     device,
+			Endpoint:   endpoint,
+			Source:     model.LockEventSource(command.ProgramEventSource),
+			Code:       model.LockProgrammingEventCode(command.ProgramEventCode),
+			UserId:     command.UserID,
+			PIN:        command.PIN,
+			UserType:   model.LockUserType(command.UserType),
+			UserStatus: model.LockUserStatus(command.UserStatus),
+			LocalTime:  clusters.ToTime(command.LocalTime),
+			Data:       command.Data,
+		}
+		select {
+		case s.channels.onDeviceLockProgrammingEvent <- programmingEvent:
+		default:
+			metrics.ChannelDrop("onDeviceLockProgrammingEvent")
+			log.Errorf("onDeviceLockProgrammingEvent channel has no capacity. Maybe channel has no subscribers")
+		}
+	}
+}
@@ -0,0 +1,344 @@
+package steward
+
+import (
+	"fmt"
+	"github.com/dyrkin/zcl-go/cluster"
+	"github.com/dyrkin/zigbee-steward/clusters"
+	"github.com/dyrkin/zigbee-steward/db"
+	"github.com/dyrkin/zigbee-steward/metrics"
+	"github.com/dyrkin/zigbee-steward/model"
+	"sync"
+	"time"
+)
+
+const (
+	currentSummationDeliveredAttributeId uint16 = 0x0000
+	instantaneousDemandAttributeId       uint16 = 0x0400
+	rmsVoltageAttributeId                uint16 = 0x0505
+	rmsCurrentAttributeId                uint16 = 0x0508
+	activePowerAttributeId               uint16 = 0x050b
+)
+
+type reporting struct {
+	attributeId      uint16
+	dataType         cluster.ZclDataType
+	minimumInterval  uint16
+	maximumInterval  uint16
+	reportableChange interface{}
+}
+
+var meteringReporting = []*reporting{
+	{currentSummationDeliveredAttributeId, cluster.ZclDataTypeUint48, 60, 3600, uint64(1)},
+	{instantaneousDemandAttributeId, cluster.ZclDataTypeInt24, 5, 300, int64(1)},
+}
+
+var electricalMeasurementReporting = []*reporting{
+	{rmsVoltageAttributeId, cluster.ZclDataTypeUint16, 10, 600, uint64(1)},
+	{rmsCurrentAttributeId, cluster.ZclDataTypeUint16, 5, 300, uint64(1)},
+	{activePowerAttributeId, cluster.ZclDataTypeInt16, 5, 300, int64(1)},
+}
+
+type energyScaling struct {
+	metering   *model.MeteringScaling
+	electrical *model.ElectricalMeasurementScaling
+}
+
+func (e *energyScaling) has(clusterId cluster.ClusterId) bool {
+	switch clusterId {
+	case clusters.Metering:
+		return e.metering != nil
+	case clusters.ElectricalMeasurement:
+		return e.electrical != nil
+	}
+	return false
+}
+
+func (e *energyScaling) apply(endpoint *model.Endpoint) {
+	if e.metering != nil {
+		endpoint.MeteringScaling = e.metering
+	}
+	if e.electrical != nil {
+		endpoint.ElectricalMeasurementScaling = e.electrical
+	}
+}
+
+func (s *Steward) interviewEnergyScaling(device *model.Device) {
+	for _, endpoint := range device.Endpoints {
+		for _, clusterId := range []cluster.ClusterId{clusters.Metering, clusters.ElectricalMeasurement} {
+			if !endpoint.HasInCluster(uint16(clusterId)) {
+				continue
+			}
+			scaling, err := s.readEnergyScaling(device, endpoint.Id, clusterId)
+			if err != nil {
+				log.Errorf("Unable to read scaling of cluster [%d]: [%s], ep: [%d]. Reason: %s", clusterId, device.IEEEAddress, endpoint.Id, err)
+				continue
+			}
+			//device is not in the DB yet, so nothing else can see the endpoint
+			scaling.apply(endpoint)
+		}
+	}
+}
+
+func (s *Steward) readEnergyScaling(device *model.Device, endpointId uint8, clusterId cluster.ClusterId) (*energyScaling, error) {
+	scaling := &energyScaling{}
+	switch clusterId {
+	case clusters.Metering:
+		values, err := s.readUintAttributes(device, endpointId, clusters.Metering, []uint16{0x0300, 0x0301, 0x0302, 0x0303, 0x0304})
+		if err != nil {
+			return nil, err
+		}
+		scaling.metering = &model.MeteringScaling{
+			UnitOfMeasure:       uint8(values[0x0300]),
+			Multiplier:          uint32(values[0x0301]),
+			Divisor:             uint32(values[0x0302]),
+			SummationFormatting: uint8(values[0x0303]),
+			DemandFormatting:    uint8(values[0x0304]),
+		}
+	case clusters.ElectricalMeasurement:
+		values, err := s.readUintAttributes(device, endpointId, clusters.ElectricalMeasurement, []uint16{0x0600, 0x0601, 0x0602, 0x0603, 0x0604, 0x0605})
+		if err != nil {
+			return nil, err
+		}
+		scaling.electrical = &model.ElectricalMeasurementScaling{
+			VoltageMultiplier: uint16(values[0x0600]),
+			VoltageDivisor:    uint16(values[0x0601]),
+			CurrentMultiplier: uint16(values[0x0602]),
+			CurrentDivisor:    uint16(values[0x0603]),
+			PowerMultiplier:   uint16(values[0x0604]),
+			PowerDivisor:      uint16(values[0x0605]),
+		}
+	}
+	return scaling, nil
+}
+
+// storedEnergyScaling returns the scaling of the endpoint as stored in the DB. Scalings are replaced, never mutated,
+// so the returned pointers stay consistent after the lock is released
+func storedEnergyScaling(ieeeAddress string, endpointId uint8) (*energyScaling, bool) {
+	var scaling *energyScaling
+	db.Database().Tables().Devices.View(ieeeAddress, func(device *model.Device) {
+		if endpoint, ok := device.Endpoint(endpointId); ok {
+			scaling = &energyScaling{metering: endpoint.MeteringScaling, electrical: endpoint.ElectricalMeasurementScaling}
+		}
+	})
+	return scaling, scaling != nil
+}
+
+func storeEnergyScaling(ieeeAddress string, endpointId uint8, scaling *energyScaling) bool {
+	stored := false
+	db.Database().Tables().Devices.Update(ieeeAddress, func(device *model.Device) {
+		if endpoint, ok := device.Endpoint(endpointId); ok {
+			scaling.apply(endpoint)
+			stored = true
+		}
+	})
+	return stored
+}
+
+// devices registered before scaling was interviewed, or whose interview read failed, get scaling on their first report.
+// Reports arriving while the read is in flight are queued and processed once it completes
+func (s *Steward) scaleOnFirstReport(message *model.DeviceIncomingMessage, endpointId uint8, clusterId cluster.ClusterId) {
+	device := message.Device
+	key := fmt.Sprintf("%s/%d/%d", device.IEEEAddress, endpointId, clusterId)
+	if !s.scalingReads.start(key, message) {
+		return
+	}
+	go func() {
+		scaling, err := s.readEnergyScaling(device, endpointId, clusterId)
+		if err == nil && !storeEnergyScaling(device.IEEEAddress, endpointId, scaling) {
+			err = fmt.Errorf("device is not registered")
+		}
+		messages := s.scalingReads.done(key, err)
+		if err != nil {
+			log.Errorf("Unable to read scaling of cluster [%d]: [%s], ep: [%d]. Dropped [%d] reports. Reason: %s",
+				clusterId, device.IEEEAddress, endpointId, len(messages), err)
+			return
+		}
+		for _, message := range messages {
+			s.processEnergyMessage(message)
+		}
+	}()
+}
+
+// scalingRetryInterval limits how often a failed scaling read is retried on incoming reports
+const scalingRetryInterval = 5 * time.Minute
+
+type scalingReads struct {
+	mutex    sync.Mutex
+	pending  map[string][]*model.DeviceIncomingMessage
+	failures map[string]time.Time
+}
+
+func newScalingReads() *scalingReads {
+	return &scalingReads{
+		pending:  map[string][]*model.DeviceIncomingMessage{},
+		failures: map[string]time.Time{},
+	}
+}
+
+// start returns true if the caller should read the scaling. Otherwise the message is queued behind the read in flight,
+// or dropped if the last read failed less than scalingRetryInterval ago
+func (r *scalingReads) start(key string, message *model.DeviceIncomingMessage) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if messages, ok := r.pending[key]; ok {
+		r.pending[key] = append(messages, message)
+		return false
+	}
+	if failed, ok := r.failures[key]; ok && time.Since(failed) < scalingRetryInterval {
+		return false
+	}
+	r.pending[key] = []*model.DeviceIncomingMessage{message}
+	return true
+}
+
+// done completes the read and returns the messages received while it was in flight
+func (r *scalingReads) done(key string, err error) []*model.DeviceIncomingMessage {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	messages := r.pending[key]
+	delete(r.pending, key)
+	if err != nil {
+		r.failures[key] = time.Now()
+	} else {
+		delete(r.failures, key)
+	}
+	return messages
+}
+
+func (s *Steward) readUintAttributes(device *model.Device, endpointId uint8, clusterId cluster.ClusterId, attributeIds []uint16) (map[uint16]uint64, error) {
+	response, err := s.Functions().Cluster().Global().Endpoint(endpointId).ReadAttributes(device.NetworkAddress, clusterId, attributeIds)
+	if err != nil {
+		return nil, err
+	}
+	values := map[uint16]uint64{}
+	for _, status := range response.ReadAttributeStatuses {
+		if status.Status != cluster.ZclStatusSuccess {
+			continue
+		}
+		if value, ok := status.Attribute.Value.(uint64); ok {
+			values[status.AttributeID] = value
+		}
+	}
+	return values, nil
+}
+
+func (s *Steward) ConfigureEnergyReporting(device *model.Device) error {
+	for _, endpoint := range device.Endpoints {
+		if endpoint.HasInCluster(uint16(clusters.Metering)) {
+			if err := s.configureReporting(device, endpoint, clusters.Metering, meteringReporting); err != nil {
+				return err
+			}
+		}
+		if endpoint.HasInCluster(uint16(clusters.ElectricalMeasurement)) {
+			if err := s.configureReporting(device, endpoint, clusters.ElectricalMeasurement, electricalMeasurementReporting); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Steward) configureReporting(device *model.Device, endpoint *model.Endpoint, clusterId cluster.ClusterId, reportings []*reporting) error {
+	_, err := s.Functions().Generic().Bind(device.NetworkAddress, device.IEEEAddress, endpoint.Id, uint16(clusterId),
+		s.configuration.IEEEAddress, hostEndpoint)
+	if err != nil {
+		return fmt.Errorf("unable to bind cluster [%d] of [%s]: %s", clusterId, device.IEEEAddress, err)
+	}
+	var records []*cluster.AttributeReportingConfigurationRecord
+	for _, r := range reportings {
+		records = append(records, &cluster.AttributeReportingConfigurationRecord{
+			Direction:                cluster.ReportDirectionAttributeReported,
+			AttributeID:              r.attributeId,
+			AttributeDataType:        r.dataType,
+			MinimumReportingInterval: r.minimumInterval,
+			MaximumReportingInterval: r.maximumInterval,
+			ReportableChange:         &cluster.Attribute{DataType: r.dataType, Value: r.reportableChange},
+		})
+	}
+	response, err := s.Functions().Cluster().Global().Endpoint(endpoint.Id).ConfigureReporting(device.NetworkAddress, clusterId, records)
+	if err != nil {
+		return fmt.Errorf("unable to configure reporting of cluster [%d] of [%s]: %s", clusterId, device.IEEEAddress, err)
+	}
+	for _, status := range response.AttributeStatusRecords {
+		if status.Status != cluster.ZclStatusSuccess {
+			return fmt.Errorf("unable to configure reporting of attribute [%d] of cluster [%d] of [%s]. Status: [%d]",
+				status.AttributeID, clusterId, device.IEEEAddress, status.Status)
+		}
+	}
+	return nil
+}
+
+func (s *Steward) processEnergyMessage(message *model.DeviceIncomingMessage) {
+	command, ok := message.IncomingMessage.Data.Command.(*cluster.ReportAttributesCommand)
+	if !ok {
+		return
+	}
+	device := message.Device
+	endpointId := message.IncomingMessage.SrcEndpoint
+	scaling, ok := storedEnergyScaling(device.IEEEAddress, endpointId)
+	if !ok {
+		return
+	}
+	clusterId := cluster.ClusterId(message.IncomingMessage.ClusterID)
+	if !scaling.has(clusterId) {
+		s.scaleOnFirstReport(message, endpointId, clusterId)
+		return
+	}
+	measurement := &model.DeviceEnergyMeasurement{Device: device, Endpoint: endpointId}
+	for _, report := range command.AttributeReports {
+		applyEnergyReport(measurement, scaling, clusterId, report)
+	}
+	if measurement.Power == nil && measurement.Energy == nil && measurement.Voltage == nil && measurement.Current == nil {
+		return
+	}
+	select {
+	case s.channels.onDeviceEnergyMeasurement <- measurement:
+	default:
+		metrics.ChannelDrop("onDeviceEnergyMeasurement")
+		log.Errorf("onDeviceEnergyMeasurement channel has no capacity. Maybe channel has no subscribers")
+	}
+}
+
+func applyEnergyReport(measurement *model.DeviceEnergyMeasurement, energyScaling *energyScaling, clusterId cluster.ClusterId, report *cluster.AttributeReport) {
+	switch clusterId {
+	case clusters.Metering:
+		scaling := energyScaling.metering
+		if scaling == nil || scaling.UnitOfMeasure != clusters.MeteringUnitKilowatts {
+			return
+		}
+		switch report.AttributeID {
+		case currentSummationDeliveredAttributeId:
+			if summation, ok := report.Attribute.Value.(uint64); ok {
+				energy := scaling.Energy(summation)
+				measurement.Energy = &energy
+			}
+		case instantaneousDemandAttributeId:
+			if demand, ok := report.Attribute.Value.(int64); ok {
+				power := scaling.Power(demand)
+				measurement.Power = &power
+			}
+		}
+	case clusters.ElectricalMeasurement:
+		scaling := energyScaling.electrical
+		if scaling == nil {
+			return
+		}
+		switch report.AttributeID {
+		case rmsVoltageAttributeId:
+			if rmsVoltage, ok := report.Attribute.Value.(uint64); ok {
+				voltage := scaling.Voltage(rmsVoltage)
+				measurement.Voltage = &voltage
+			}
+		case rmsCurrentAttributeId:
+			if rmsCurrent, ok := report.Attribute.Value.(uint64); ok {
+				current := scaling.Current(rmsCurrent)
+				measurement.Current = &current
+			}
+		case activePowerAttributeId:
+			if activePower, ok := report.Attribute.Value.(int64); ok {
+				power := scaling.Power(activePower)
+				measurement.Power = &power
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package steward
+
+import (
+	"github.com/dyrkin/zcl-go/cluster"
+	"github.com/dyrkin/zigbee-steward/clusters"
+	"github.com/dyrkin/zigbee-steward/metrics"
+	"github.com/dyrkin/zigbee-steward/model"
+)
+
+const (
+	zoneEnrollResponseSuccess uint8 = 0x00
+	defaultZoneId             uint8 = 0x17
+)
+
+const (
+	zoneStatusAttributeId    uint16 = 0x0002
+	iasCieAddressAttributeId uint16 = 0x0010
+)
+
+func (s *Steward) enrollZones(device *model.Device) {
+	for _, endpoint := range device.Endpoints {
+		if !endpoint.HasInCluster(uint16(clusters.IASZone)) {
+			continue
+		}
+		log.Debugf("Enrolling IAS zone: [%s], ep: [%d]", device.IEEEAddress, endpoint.Id)
+		writeAttributeRecords := []*cluster.WriteAttributeRecord{
+			{
+				AttributeID: iasCieAddressAttributeId,
+				Attribute: &cluster.Attribute{
+					DataType: cluster.ZclDataTypeIeeeAddr,
+					Value:    s.configuration.IEEEAddress,
+				},
+			},
+		}
+		response, err := s.Functions().Cluster().Global().Endpoint(endpoint.Id).WriteAttributes(device.NetworkAddress, clusters.IASZone, writeAttributeRecords)
+		if err != nil {
+			log.Errorf("Unable to write IAS CIE address: [%s], ep: [%d]. Reason: %s", device.IEEEAddress, endpoint.Id, err)
+			continue
+		}
+		written := true
+		for _, status := range response.WriteAttributeStatuses {
+			if status.Status != cluster.ZclStatusSuccess {
+				log.Errorf("Unable to write IAS CIE address: [%s], ep: [%d]. Status: [%d]", device.IEEEAddress, endpoint.Id, status.Status)
+				written = false
+			}
+		}
+		if written {
+			s.enrollZone(device, endpoint.Id)
+		}
+	}
+}
+
+func (s *Steward) enrollZone(device *model.Device, endpoint uint8) {
+	err := s.Functions().Cluster().Local().IASZone().ZoneEnrollResponse(device.NetworkAddress, endpoint, zoneEnrollResponseSuccess, defaultZoneId)
+	if err != nil {
+		log.Errorf("Unable to enroll IAS zone: [%s], ep: [%d]. Reason: %s", device.IEEEAddress, endpoint, err)
+		return
+	}
+	log.Infof("Enrolled IAS zone: [%s], ep: [%d], zone: [%d]", device.IEEEAddress, endpoint, defaultZoneId)
+}
+
+func (s *Steward) processIASZoneMessage(message *model.DeviceIncomingMessage) {
+	device := message.Device
+	endpoint := message.IncomingMessage.SrcEndpoint
+	switch command := message.IncomingMessage.Data.Command.(type) {
+	case *clusters.ZoneEnrollRequestCommand:
+		log.Infof("Received zone enroll request: [%s], ep: [%d], zone type: [%s]",
+			device.IEEEAddress, endpoint, model.ZoneType(command.ZoneType))
+		go s.enrollZone(device, endpoint)
+	case *clusters.ZoneStatusChangeNotificationCommand:
+		s.notifyZoneStatusChange(&model.DeviceZoneStatusChange{
+			Device:         device,
+			Endpoint:       endpoint,
+			ZoneId:         command.ZoneID,
+			ZoneStatus:     model.NewZoneStatus(command.ZoneStatus),
+			ExtendedStatus: command.ExtendedStatus,
+			Delay:          command.Delay,
+		})
+	case *cluster.ReportAttributesCommand:
+		for _, report := range command.AttributeReports {
+			if report.AttributeID != zoneStatusAttributeId {
+				continue
+			}
+			if zoneStatus, ok := report.Attribute.Value.(uint64); ok {
+				s.notifyZoneStatusChange(&model.DeviceZoneStatusChange{
+					Device:     device,
+					Endpoint:   endpoint,
+					ZoneStatus: model.NewZoneStatus(uint16(zoneStatus)),
+				})
+			}
+		}
+	}
+}
+
+func (s *Steward) notifyZoneStatusChange(zoneStatusChange *model.DeviceZoneStatusChange) {
+	select {
+	case s.channels.onDeviceZoneStatusChange <- zoneStatusChange:
+	default:
+		metrics.ChannelDrop("onDeviceZoneStatusChange")
+		log.Errorf("onDeviceZoneStatusChange channel has no capacity. Maybe channel has no subscribers")
+	}
+}
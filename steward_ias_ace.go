@@ -0,0 +1,83 @@
+package steward
+
+import (
+	"github.com/dyrkin/zcl-go/cluster"
+	"github.com/dyrkin/zigbee-steward/clusters"
+	"github.com/dyrkin/zigbee-steward/host"
+	"github.com/dyrkin/zigbee-steward/model"
+)
+
+type IASACEHandler interface {
+	Arm(device *model.Device, armMode model.ArmMode, armDisarmCode string, zoneId uint8) model.ArmNotification
+	Bypass(device *model.Device, zoneIds []uint8, armDisarmCode string) []model.BypassResult
+	Emergency(device *model.Device)
+	Fire(device *model.Device)
+	Panic(device *model.Device)
+	GetPanelStatus(device *model.Device) *model.PanelStatus
+}
+
+func (s *Steward) SetIASACEHandler(handler IASACEHandler) {
+	s.iasAceHandler = handler
+}
+
+func (s *Steward) iasAceCluster() *host.Cluster {
+	iasAceCluster := host.NewCluster(clusters.IASACE)
+	for commandId := uint8(0x00); commandId <= 0x09; commandId++ {
+		iasAceCluster.HandleCommand(commandId, s.processIASACEMessage)
+	}
+	return iasAceCluster
+}
+
+func (s *Steward) processIASACEMessage(message *model.DeviceIncomingMessage) cluster.ZclStatus {
+	handler := s.iasAceHandler
+	if handler == nil {
+		log.Debugf("IAS ACE handler is not set. Ignoring command [%s] from [%s]",
+			message.IncomingMessage.Data.CommandName, message.Device.IEEEAddress)
+		return cluster.ZclStatusUnsupClusterCommand
+	}
+	device := message.Device
+	nwkAddress := device.NetworkAddress
+	endpoint := message.IncomingMessage.SrcEndpoint
+	transactionId := message.IncomingMessage.Data.TransactionSequenceNumber
+	ace := s.Functions().Cluster().Local().IASACE()
+	respond := func(response func() error) {
+		go func() {
+			if err := response(); err != nil {
+				log.Errorf("Unable to respond to IAS ACE command [%s] from [%s]: %s",
+					message.IncomingMessage.Data.CommandName, device.IEEEAddress, err)
+			}
+		}()
+	}
+
+	switch command := message.IncomingMessage.Data.Command.(type) {
+	case *clusters.ArmCommand:
+		log.Infof("Received arm request: [%s], mode: [%s]", device.IEEEAddress, model.ArmMode(command.ArmMode))
+		respond(func() error {
+			armNotification := handler.Arm(device, model.ArmMode(command.ArmMode), command.ArmDisarmCode, command.ZoneID)
+			return ace.ArmResponse(nwkAddress, endpoint, transactionId, armNotification)
+		})
+		return cluster.ZclStatusCmdHasRsp
+	case *clusters.BypassCommand:
+		respond(func() error {
+			bypassResults := handler.Bypass(device, command.ZoneIDs, command.ArmDisarmCode)
+			return ace.BypassResponse(nwkAddress, endpoint, transactionId, bypassResults)
+		})
+		return cluster.ZclStatusCmdHasRsp
+	case *clusters.EmergencyCommand:
+		go handler.Emergency(device)
+		return cluster.ZclStatusSuccess
+	case *clusters.FireCommand:
+		go handler.Fire(device)
+		return cluster.ZclStatusSuccess
+	case *clusters.PanicCommand:
+		go handler.Panic(device)
+		return cluster.ZclStatusSuccess
+	case *clusters.GetPanelStatusCommand:
+		respond(func() error {
+			panelStatus := handler.GetPanelStatus(device)
+			return ace.GetPanelStatusResponse(nwkAddress, endpoint, transactionId, panelStatus)
+		})
+		return cluster.ZclStatusCmdHasRsp
+	}
+	return cluster.ZclStatusUnsupClusterCommand
+}
@@ -0,0 +1,25 @@
+package steward
+
+import (
+	"github.com/dyrkin/zigbee-steward/metrics"
+	"github.com/dyrkin/zigbee-steward/model"
+)
+
+func (s *Steward) notifyInterviewProgress(device *model.Device, stage model.InterviewStage, endpoint uint8, err error) {
+	//the device is still being filled in by the interview, so subscribers receive a snapshot
+	snapshot := *device
+	progress := &model.DeviceInterviewProgress{
+		Device:   &snapshot,
+		Stage:    stage,
+		Endpoint: endpoint,
+	}
+	if err != nil {
+		progress.Error = err.Error()
+	}
+	select {
+	case s.channels.onDeviceInterviewProgress <- progress:
+	default:
+		metrics.ChannelDrop("onDeviceInterviewProgress")
+		log.Errorf("onDeviceInterviewProgress channel has no capacity. Maybe channel has no subscribers")
+	}
+}
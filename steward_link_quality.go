@@ -0,0 +1,47 @@
+package steward
+
+import (
+	"github.com/dyrkin/zigbee-steward/db"
+	"github.com/dyrkin/zigbee-steward/logger"
+	"github.com/dyrkin/zigbee-steward/metrics"
+	"github.com/dyrkin/zigbee-steward/model"
+)
+
+const (
+	//weight of the newest sample in the running average
+	linkQualitySmoothing = 0.1
+	//the average must rise this much above the threshold before a degraded link counts as recovered
+	linkQualityHysteresis = 10
+)
+
+func (s *Steward) updateLinkQuality(device *model.Device, linkQuality uint8) {
+	updated := model.LinkQuality{Last: linkQuality, Average: float64(linkQuality)}
+	if previous := device.LinkQuality; previous != nil {
+		updated.Average = previous.Average + linkQualitySmoothing*(float64(linkQuality)-previous.Average)
+		updated.Degraded = previous.Degraded
+	}
+	threshold := s.configuration.LinkQualityThreshold
+	changed := false
+	switch {
+	case threshold == 0:
+	case !updated.Degraded && updated.Average < float64(threshold):
+		updated.Degraded, changed = true, true
+		log.With(logger.FieldIEEE, device.IEEEAddress).Warningf("Link of device [%s] degraded. Average link quality: [%.1f]", device.IEEEAddress, updated.Average)
+	case updated.Degraded && updated.Average >= float64(threshold)+linkQualityHysteresis:
+		updated.Degraded, changed = false, true
+		log.With(logger.FieldIEEE, device.IEEEAddress).Infof("Link of device [%s] recovered. Average link quality: [%.1f]", device.IEEEAddress, updated.Average)
+	}
+	device.LinkQuality = &updated
+	if !changed {
+		return
+	}
+	//the average is persisted on alerts only, so incoming messages don't rewrite the database
+	db.Database().Tables().Devices.Add(device)
+	alert := &model.DeviceLinkQualityAlert{Device: device, LinkQuality: updated, Threshold: threshold}
+	select {
+	case s.channels.onDeviceLinkQualityAlert <- alert:
+	default:
+		metrics.ChannelDrop("onDeviceLinkQualityAlert")
+		log.Errorf("onDeviceLinkQualityAlert channel has no capacity. Maybe channel has no subscribers")
+	}
+}
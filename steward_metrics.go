@@ -0,0 +1,86 @@
+package steward
+
+import (
+	"github.com/dyrkin/zigbee-steward/db"
+	"github.com/dyrkin/zigbee-steward/metrics"
+	"github.com/dyrkin/zigbee-steward/model"
+	"github.com/prometheus/client_golang/prometheus"
+	"sync"
+	"time"
+)
+
+const (
+	mainPoweredOnlineTimeout    = time.Hour
+	batteryPoweredOnlineTimeout = 25 * time.Hour
+)
+
+var devicesDescription = prometheus.NewDesc("steward_devices", "Registered devices by availability.", []string{"availability"}, nil)
+
+type lastSeenCache struct {
+	mutex    sync.RWMutex
+	lastSeen map[string]time.Time
+}
+
+func newLastSeenCache() *lastSeenCache {
+	return &lastSeenCache{lastSeen: map[string]time.Time{}}
+}
+
+func (c *lastSeenCache) touch(ieeeAddress string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.lastSeen[ieeeAddress] = time.Now()
+}
+
+func (c *lastSeenCache) get(ieeeAddress string) (time.Time, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	lastSeen, ok := c.lastSeen[ieeeAddress]
+	return lastSeen, ok
+}
+
+func (c *lastSeenCache) remove(ieeeAddress string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.lastSeen, ieeeAddress)
+}
+
+// OnlineTimeout returns how long the device may stay silent before it's considered offline
+func OnlineTimeout(device *model.Device) time.Duration {
+	if device.MainPowered {
+		return mainPoweredOnlineTimeout
+	}
+	return batteryPoweredOnlineTimeout
+}
+
+// LastSeen returns the time of the last message received from the device since start
+func (s *Steward) LastSeen(ieeeAddress string) (time.Time, bool) {
+	return s.lastSeen.get(ieeeAddress)
+}
+
+// counts devices as online when they were heard from recently
+type deviceCollector struct {
+	lastSeen *lastSeenCache
+}
+
+func (c *deviceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- devicesDescription
+}
+
+func (c *deviceCollector) Collect(ch chan<- prometheus.Metric) {
+	online, offline := 0, 0
+	for _, device := range db.Database().Tables().Devices.All() {
+		if lastSeen, ok := c.lastSeen.get(device.IEEEAddress); ok && time.Since(lastSeen) < OnlineTimeout(device) {
+			online++
+		} else {
+			offline++
+		}
+	}
+	ch <- prometheus.MustNewConstMetric(devicesDescription, prometheus.GaugeValue, float64(online), "online")
+	ch <- prometheus.MustNewConstMetric(devicesDescription, prometheus.GaugeValue, float64(offline), "offline")
+}
+
+func (s *Steward) registerMetrics() {
+	if err := metrics.Register(&deviceCollector{lastSeen: s.lastSeen}); err != nil {
+		log.Errorf("Unable to register device metrics: %s", err)
+	}
+}
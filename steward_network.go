@@ -0,0 +1,93 @@
+package steward
+
+import (
+	"fmt"
+	"github.com/dyrkin/zigbee-steward/coordinator"
+	"github.com/dyrkin/zigbee-steward/db"
+	"github.com/dyrkin/zigbee-steward/model"
+	"github.com/dyrkin/znp-go"
+	"sort"
+)
+
+func (s *Steward) PermitJoin(timeout uint8) error {
+	log.Infof("Permitting join for [%d] seconds", timeout)
+	return s.coordinator.PermitJoin(timeout)
+}
+
+func (s *Steward) RemoveDevice(ieeeAddress string, force bool) error {
+	device, ok := db.Database().Tables().Devices.Get(ieeeAddress)
+	if !ok {
+		return model.InvalidRequest("device [%s] is not registered", ieeeAddress)
+	}
+	response, err := s.coordinator.Leave(device.NetworkAddress, ieeeAddress)
+	if err == nil && response.Status != znp.StatusSuccess {
+		err = fmt.Errorf("invalid status: [%s]", response.Status)
+	}
+	if err != nil {
+		if !force {
+			return fmt.Errorf("unable to remove device [%s]: %s", ieeeAddress, err)
+		}
+		log.Errorf("Device [%s] didn't leave the network. Removing it anyway: %s", ieeeAddress, err)
+	}
+	if db.Database().Tables().Devices.Exists(ieeeAddress) {
+		s.removeDevice(device)
+	}
+	return nil
+}
+
+func (s *Steward) Topology() *model.Topology {
+	topology := &model.Topology{}
+	routers := map[string]string{s.configuration.IEEEAddress: s.coordinator.Network().Address}
+	for _, device := range db.Database().Tables().Devices.All() {
+		if device.LogicalType == znp.LogicalTypeRouter {
+			routers[device.IEEEAddress] = device.NetworkAddress
+		}
+	}
+	var ieeeAddresses []string
+	for ieeeAddress := range routers {
+		ieeeAddresses = append(ieeeAddresses, ieeeAddress)
+	}
+	sort.Strings(ieeeAddresses)
+	for _, ieeeAddress := range ieeeAddresses {
+		links, err := s.neighbors(ieeeAddress, routers[ieeeAddress])
+		if err != nil {
+			log.Errorf("Unable to read neighbor table of [%s]: %s", ieeeAddress, err)
+			topology.Unreachable = append(topology.Unreachable, ieeeAddress)
+			continue
+		}
+		topology.Links = append(topology.Links, links...)
+	}
+	return topology
+}
+
+func (s *Steward) BackupCoordinator() (*coordinator.Backup, error) {
+	return s.coordinator.Backup()
+}
+
+func (s *Steward) neighbors(ieeeAddress string, nwkAddress string) ([]*model.Link, error) {
+	var links []*model.Link
+	var startIndex uint8
+	for {
+		response, err := s.coordinator.Lqi(nwkAddress, startIndex)
+		if err != nil {
+			return nil, err
+		}
+		if response.Status != znp.StatusSuccess {
+			return nil, fmt.Errorf("invalid status: [%s]", response.Status)
+		}
+		for _, neighbor := range response.NeighborLqiList {
+			links = append(links, &model.Link{
+				Source:         ieeeAddress,
+				Target:         neighbor.ExtendedAddress,
+				NetworkAddress: neighbor.NetworkAddress,
+				Relationship:   model.Relationship(neighbor.Relationship),
+				Depth:          neighbor.Depth,
+				LinkQuality:    neighbor.LQI,
+			})
+		}
+		startIndex += uint8(len(response.NeighborLqiList))
+		if len(response.NeighborLqiList) == 0 || startIndex >= response.NeighborTableEntries {
+			return links, nil
+		}
+	}
+}
@@ -0,0 +1,288 @@
+package steward
+
+import (
+	"github.com/dyrkin/zcl-go/cluster"
+	"github.com/dyrkin/zigbee-steward/clusters"
+	"github.com/dyrkin/zigbee-steward/configuration"
+	"github.com/dyrkin/zigbee-steward/host"
+	"github.com/dyrkin/zigbee-steward/metrics"
+	"github.com/dyrkin/zigbee-steward/model"
+	"github.com/dyrkin/zigbee-steward/ota"
+	"sync"
+	"time"
+)
+
+const (
+	otaMaximumBlockDelay      = time.Second
+	otaHardwareVersionPresent = 0x01
+)
+
+type otaSession struct {
+	image     *ota.Image
+	nextBlock time.Time
+	percent   uint8
+}
+
+type otaServer struct {
+	repository         *ota.Repository
+	minimumBlockPeriod time.Duration
+	mutex              sync.Mutex
+	sessions           map[string]*otaSession
+}
+
+func newOTAServer(configuration *configuration.OTA) *otaServer {
+	server := &otaServer{sessions: map[string]*otaSession{}}
+	if configuration == nil || configuration.ImagesDirectory == "" {
+		return server
+	}
+	server.minimumBlockPeriod = configuration.MinimumBlockPeriod
+	server.repository = ota.NewRepository(configuration.ImagesDirectory)
+	if err := server.repository.Reload(); err != nil {
+		log.Errorf("Unable to index OTA images in [%s]: %s", configuration.ImagesDirectory, err)
+	}
+	return server
+}
+
+func (o *otaServer) findUpgrade(command *clusters.QueryNextImageRequestCommand) (*ota.Image, bool) {
+	if o.repository == nil {
+		return nil, false
+	}
+	return o.repository.FindUpgrade(command.ManufacturerCode, command.ImageType, command.CurrentFileVersion,
+		command.HardwareVersion, command.FieldControl&otaHardwareVersionPresent > 0)
+}
+
+func (o *otaServer) session(ieeeAddress string, manufacturerCode uint16, imageType uint16, fileVersion uint32) (*otaSession, bool, bool) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if session, ok := o.sessions[ieeeAddress]; ok && session.image.Header.FileVersion == fileVersion &&
+		session.image.Header.ManufacturerCode == manufacturerCode && session.image.Header.ImageType == imageType {
+		return session, false, true
+	}
+	if o.repository == nil {
+		return nil, false, false
+	}
+	image, ok := o.repository.FindVersion(manufacturerCode, imageType, fileVersion)
+	if !ok {
+		return nil, false, false
+	}
+	session := &otaSession{image: image}
+	o.sessions[ieeeAddress] = session
+	return session, true, true
+}
+
+func (o *otaServer) endSession(ieeeAddress string) (*otaSession, bool) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	session, ok := o.sessions[ieeeAddress]
+	delete(o.sessions, ieeeAddress)
+	return session, ok
+}
+
+func (o *otaServer) reserveBlock(session *otaSession) time.Duration {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	now := time.Now()
+	if session.nextBlock.Before(now) {
+		session.nextBlock = now
+	}
+	delay := session.nextBlock.Sub(now)
+	if delay <= otaMaximumBlockDelay {
+		session.nextBlock = session.nextBlock.Add(o.minimumBlockPeriod)
+	}
+	return delay
+}
+
+func (o *otaServer) updatePercent(session *otaSession, percent uint8) bool {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if session.percent == percent {
+		return false
+	}
+	session.percent = percent
+	return true
+}
+
+func (s *Steward) OTARepository() *ota.Repository {
+	return s.ota.repository
+}
+
+func (s *Steward) otaCluster() *host.Cluster {
+	otaCluster := host.NewCluster(cluster.OTA)
+	for _, commandId := range []uint8{0x01, 0x03, 0x04, 0x06} {
+		otaCluster.HandleCommand(commandId, s.processOTAMessage)
+	}
+	return otaCluster
+}
+
+func (s *Steward) processOTAMessage(message *model.DeviceIncomingMessage) cluster.ZclStatus {
+	device := message.Device
+	nwkAddress := device.NetworkAddress
+	endpoint := message.IncomingMessage.SrcEndpoint
+	transactionId := message.IncomingMessage.Data.TransactionSequenceNumber
+	otaCluster := s.Functions().Cluster().Local().OTA()
+	respond := func(response func() error) {
+		go func() {
+			if err := response(); err != nil {
+				log.Errorf("Unable to respond to OTA command [%s] from [%s]: %s",
+					message.IncomingMessage.Data.CommandName, device.IEEEAddress, err)
+			}
+		}()
+	}
+
+	switch command := message.IncomingMessage.Data.Command.(type) {
+	case *clusters.QueryNextImageRequestCommand:
+		image, ok := s.ota.findUpgrade(command)
+		if !ok {
+			log.Debugf("No OTA image available: [%s], manufacturer: [0x%04x], image type: [0x%04x], version: [0x%08x]",
+				device.IEEEAddress, command.ManufacturerCode, command.ImageType, command.CurrentFileVersion)
+			respond(func() error {
+				return otaCluster.QueryNextImageResponse(nwkAddress, endpoint, transactionId, nil)
+			})
+			return cluster.ZclStatusCmdHasRsp
+		}
+		log.Infof("Offering OTA image: [%s], current version: [0x%08x], new version: [0x%08x]",
+			device.IEEEAddress, command.CurrentFileVersion, image.Header.FileVersion)
+		respond(func() error {
+			return otaCluster.QueryNextImageResponse(nwkAddress, endpoint, transactionId, image.Header)
+		})
+		return cluster.ZclStatusCmdHasRsp
+	case *clusters.ImageBlockRequestCommand:
+		if command.MaximumDataSize == 0 {
+			log.Warningf("Rejecting OTA image block request with zero data size: [%s]", device.IEEEAddress)
+			return cluster.ZclStatusMalformedCommand
+		}
+		session, ok := s.otaBlockSession(message, command.ManufacturerCode, command.ImageType, command.FileVersion)
+		if !ok {
+			respond(func() error {
+				return otaCluster.ImageBlockAbortResponse(nwkAddress, endpoint, transactionId)
+			})
+			return cluster.ZclStatusCmdHasRsp
+		}
+		respond(func() error {
+			return s.sendImageBlock(message, session, command.FileOffset, command.MaximumDataSize)
+		})
+		return cluster.ZclStatusCmdHasRsp
+	case *clusters.ImagePageRequestCommand:
+		if command.MaximumDataSize == 0 || command.PageSize == 0 {
+			log.Warningf("Rejecting OTA image page request with zero data or page size: [%s]", device.IEEEAddress)
+			return cluster.ZclStatusMalformedCommand
+		}
+		session, ok := s.otaBlockSession(message, command.ManufacturerCode, command.ImageType, command.FileVersion)
+		if !ok {
+			respond(func() error {
+				return otaCluster.ImageBlockAbortResponse(nwkAddress, endpoint, transactionId)
+			})
+			return cluster.ZclStatusCmdHasRsp
+		}
+		respond(func() error {
+			spacing := time.Duration(command.ResponseSpacing) * time.Millisecond
+			//page is capped at the image length, computed in 64 bits so offset + page size can't wrap
+			end := uint64(command.FileOffset) + uint64(command.PageSize)
+			if imageSize := uint64(session.image.Header.TotalImageSize); end > imageSize {
+				end = imageSize
+			}
+			for offset := uint64(command.FileOffset); offset < end; offset += uint64(command.MaximumDataSize) {
+				size := command.MaximumDataSize
+				if remaining := end - offset; remaining < uint64(size) {
+					size = uint8(remaining)
+				}
+				if err := s.sendImageBlock(message, session, uint32(offset), size); err != nil {
+					return err
+				}
+				time.Sleep(spacing)
+			}
+			return nil
+		})
+		return cluster.ZclStatusCmdHasRsp
+	case *clusters.UpgradeEndRequestCommand:
+		session, ok := s.ota.endSession(device.IEEEAddress)
+		if !ok {
+			log.Warningf("Received OTA upgrade end without active session: [%s]", device.IEEEAddress)
+			return cluster.ZclStatusSuccess
+		}
+		progress := s.otaProgress(message, session, session.image.Header.TotalImageSize)
+		if command.Status != clusters.OTAStatusSuccess {
+			log.Errorf("OTA upgrade failed: [%s], version: [0x%08x], status: [0x%02x]",
+				device.IEEEAddress, command.FileVersion, command.Status)
+			progress.Status = model.OTAUpgradeStatusFailed
+			s.notifyOTAProgress(progress)
+			return cluster.ZclStatusSuccess
+		}
+		log.Infof("OTA upgrade completed: [%s], version: [0x%08x]", device.IEEEAddress, command.FileVersion)
+		progress.Status = model.OTAUpgradeStatusCompleted
+		s.notifyOTAProgress(progress)
+		respond(func() error {
+			return otaCluster.UpgradeEndResponse(nwkAddress, endpoint, transactionId, session.image.Header, 0)
+		})
+		return cluster.ZclStatusCmdHasRsp
+	}
+	return cluster.ZclStatusUnsupClusterCommand
+}
+
+func (s *Steward) otaBlockSession(message *model.DeviceIncomingMessage, manufacturerCode uint16, imageType uint16, fileVersion uint32) (*otaSession, bool) {
+	device := message.Device
+	session, started, ok := s.ota.session(device.IEEEAddress, manufacturerCode, imageType, fileVersion)
+	if !ok {
+		log.Errorf("Requested unknown OTA image: [%s], manufacturer: [0x%04x], image type: [0x%04x], version: [0x%08x]",
+			device.IEEEAddress, manufacturerCode, imageType, fileVersion)
+		return nil, false
+	}
+	if started {
+		log.Infof("OTA upgrade started: [%s], image: [%s]", device.IEEEAddress, session.image.Path)
+		progress := s.otaProgress(message, session, 0)
+		progress.Status = model.OTAUpgradeStatusStarted
+		s.notifyOTAProgress(progress)
+	}
+	return session, true
+}
+
+func (s *Steward) sendImageBlock(message *model.DeviceIncomingMessage, session *otaSession, fileOffset uint32, maximumDataSize uint8) error {
+	device := message.Device
+	endpoint := message.IncomingMessage.SrcEndpoint
+	transactionId := message.IncomingMessage.Data.TransactionSequenceNumber
+	otaCluster := s.Functions().Cluster().Local().OTA()
+
+	delay := s.ota.reserveBlock(session)
+	if delay > otaMaximumBlockDelay {
+		return otaCluster.ImageBlockWaitResponse(device.NetworkAddress, endpoint, transactionId, delay, s.ota.minimumBlockPeriod)
+	}
+	time.Sleep(delay)
+
+	block, err := session.image.ReadBlock(fileOffset, maximumDataSize)
+	if err != nil {
+		log.Errorf("Unable to read OTA image block: %s", err)
+		return otaCluster.ImageBlockAbortResponse(device.NetworkAddress, endpoint, transactionId)
+	}
+	err = otaCluster.ImageBlockResponse(device.NetworkAddress, endpoint, transactionId, session.image.Header, fileOffset, block)
+	if err != nil {
+		return err
+	}
+	progress := s.otaProgress(message, session, fileOffset+uint32(len(block)))
+	if s.ota.updatePercent(session, progress.Percent()) {
+		s.notifyOTAProgress(progress)
+	}
+	return nil
+}
+
+func (s *Steward) otaProgress(message *model.DeviceIncomingMessage, session *otaSession, offset uint32) *model.DeviceOTAProgress {
+	header := session.image.Header
+	return &model.DeviceOTAProgress{
+		Device:           message.Device,
+		Endpoint:         message.IncomingMessage.SrcEndpoint,
+		ManufacturerCode: header.ManufacturerCode,
+		ImageType:        header.ImageType,
+		FileVersion:      header.FileVersion,
+		Offset:           offset,
+		ImageSize:        header.TotalImageSize,
+		Status:           model.OTAUpgradeStatusDownloading,
+	}
+}
+
+func (s *Steward) notifyOTAProgress(progress *model.DeviceOTAProgress) {
+	select {
+	case s.channels.onDeviceOTAProgress <- progress:
+	default:
+		metrics.ChannelDrop("onDeviceOTAProgress")
+		log.Errorf("onDeviceOTAProgress channel has no capacity. Maybe channel has no subscribers")
+	}
+}
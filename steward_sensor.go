@@ -0,0 +1,30 @@
+package steward
+
+import (
+	"github.com/dyrkin/zcl-go/cluster"
+	"github.com/dyrkin/zigbee-steward/converters"
+	"github.com/dyrkin/zigbee-steward/metrics"
+	"github.com/dyrkin/zigbee-steward/model"
+)
+
+func (s *Steward) processSensorMessage(message *model.DeviceIncomingMessage) {
+	command, ok := message.IncomingMessage.Data.Command.(*cluster.ReportAttributesCommand)
+	if !ok {
+		return
+	}
+	reading, ok := converters.SensorReading(message.Device, message.IncomingMessage.SrcEndpoint,
+		cluster.ClusterId(message.IncomingMessage.ClusterID), command.AttributeReports)
+	if !ok {
+		return
+	}
+	s.notifySensorReading(reading)
+}
+
+func (s *Steward) notifySensorReading(reading *model.DeviceSensorReading) {
+	select {
+	case s.channels.onDeviceSensorReading <- reading:
+	default:
+		metrics.ChannelDrop("onDeviceSensorReading")
+		log.Errorf("onDeviceSensorReading channel has no capacity. Maybe channel has no subscribers")
+	}
+}
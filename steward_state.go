@@ -0,0 +1,234 @@
+package steward
+
+import (
+	"fmt"
+	"github.com/dyrkin/zcl-go/cluster"
+	"github.com/dyrkin/zigbee-steward/db"
+	"github.com/dyrkin/zigbee-steward/definitions"
+	"github.com/dyrkin/zigbee-steward/model"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var stateOptions = map[string]bool{
+	"transition": true,
+	"pin_code":   true,
+	"endpoint":   true,
+}
+
+var stateKeyOrder = []string{"state", "brightness", "color_temp", "color"}
+
+type stateCache struct {
+	mutex  sync.RWMutex
+	states map[string]model.State
+}
+
+func newStateCache() *stateCache {
+	return &stateCache{states: map[string]model.State{}}
+}
+
+func (c *stateCache) update(ieeeAddress string, state model.State) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	cached, ok := c.states[ieeeAddress]
+	if !ok {
+		cached = model.State{}
+		c.states[ieeeAddress] = cached
+	}
+	for key, value := range state {
+		if !strings.HasPrefix(key, "action") {
+			cached[key] = value
+		}
+	}
+}
+
+func (c *stateCache) get(ieeeAddress string) model.State {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	state := model.State{}
+	for key, value := range c.states[ieeeAddress] {
+		state[key] = value
+	}
+	return state
+}
+
+func (c *stateCache) remove(ieeeAddress string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.states, ieeeAddress)
+}
+
+func (s *Steward) GetState(ieeeAddress string) (model.State, bool) {
+	if _, ok := db.Database().Tables().Devices.Get(ieeeAddress); !ok {
+		return nil, false
+	}
+	return s.states.get(ieeeAddress), true
+}
+
+func (s *Steward) SetState(ieeeAddress string, state model.State) error {
+	device, ok := db.Database().Tables().Devices.Get(ieeeAddress)
+	if !ok {
+		return model.InvalidRequest("device [%s] is not registered", ieeeAddress)
+	}
+	definition := s.definition(device)
+	options := model.State{}
+	for key := range stateOptions {
+		if value, ok := state[key]; ok {
+			options[key] = value
+		}
+	}
+	applied := model.State{}
+	for _, key := range stateKeys(state) {
+		value := state[key]
+		if key == "state" && isOn(value) && state["brightness"] != nil {
+			applied[key] = "ON"
+			continue
+		}
+		if key == "brightness" && isOff(state["state"]) {
+			continue
+		}
+		converter, ok := definition.ToZigbeeConverter(key)
+		if !ok {
+			converter, ok = definitions.Generic.ToZigbeeConverter(key)
+		}
+		if !ok {
+			return model.InvalidRequest("unsupported state [%s] of device [%s]", key, ieeeAddress)
+		}
+		endpoint, err := stateEndpoint(device, converter.Cluster, key, options)
+		if err != nil {
+			return err
+		}
+		if err := converter.Convert(s.definitionContext(), device, endpoint, key, value, options); err != nil {
+			return fmt.Errorf("unable to set [%s] of device [%s]: %w", key, ieeeAddress, err)
+		}
+		applied[key] = value
+	}
+	//the cached state has one value per key, so it can't track the other gangs of a multi-gang device
+	if _, ok := options["endpoint"]; !ok {
+		s.states.update(ieeeAddress, optimisticState(applied))
+	}
+	return nil
+}
+
+func (s *Steward) definition(device *model.Device) *definitions.Definition {
+	if definition, ok := s.definitions.Find(device); ok {
+		return definition
+	}
+	return definitions.Generic
+}
+
+func stateKeys(state model.State) []string {
+	var keys []string
+	for _, key := range stateKeyOrder {
+		if _, ok := state[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+	var rest []string
+	for key := range state {
+		if !stateOptions[key] && !contains(stateKeyOrder, key) {
+			rest = append(rest, key)
+		}
+	}
+	sort.Strings(rest)
+	return append(keys, rest...)
+}
+
+// stateEndpoint returns the endpoint given by the "endpoint" option, e.g. the second gang of a switch,
+// or the first endpoint hosting the cluster
+func stateEndpoint(device *model.Device, clusterId cluster.ClusterId, key string, options model.State) (uint8, error) {
+	value, ok := options["endpoint"]
+	if !ok {
+		if endpoints := device.InClusterEndpoints(uint16(clusterId)); len(endpoints) > 0 {
+			return endpoints[0], nil
+		}
+		return 0, model.InvalidRequest("device [%s] has no endpoint with cluster [%d] to set [%s]", device.IEEEAddress, clusterId, key)
+	}
+	endpointId, ok := endpointOption(value)
+	if !ok {
+		return 0, model.InvalidRequest("invalid endpoint [%v]", value)
+	}
+	if endpoint, ok := device.Endpoint(endpointId); !ok || !endpoint.HasInCluster(uint16(clusterId)) {
+		return 0, model.InvalidRequest("endpoint [%d] of device [%s] has no cluster [%d] to set [%s]", endpointId, device.IEEEAddress, clusterId, key)
+	}
+	return endpointId, nil
+}
+
+func endpointOption(value interface{}) (uint8, bool) {
+	var number float64
+	switch v := value.(type) {
+	case float64:
+		number = v
+	case int:
+		number = float64(v)
+	default:
+		return 0, false
+	}
+	if number < 1 || number > 0xf0 || number != math.Trunc(number) {
+		return 0, false
+	}
+	return uint8(number), true
+}
+
+func optimisticState(applied model.State) model.State {
+	state := model.State{}
+	for key, value := range applied {
+		switch key {
+		case "state":
+			switch v := value.(type) {
+			case bool:
+				state[key] = onOffState(v)
+			case string:
+				if strings.EqualFold(v, "ON") || strings.EqualFold(v, "OFF") {
+					state[key] = strings.ToUpper(v)
+				}
+			}
+		case "lock":
+			if v, ok := value.(string); ok {
+				state[key] = strings.ToUpper(v) + "ED"
+			}
+		case "cover":
+		default:
+			state[key] = value
+		}
+	}
+	return state
+}
+
+func isOn(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		return strings.EqualFold(v, "ON")
+	}
+	return false
+}
+
+func isOff(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return !v
+	case string:
+		return strings.EqualFold(v, "OFF")
+	}
+	return false
+}
+
+func onOffState(on bool) string {
+	if on {
+		return "ON"
+	}
+	return "OFF"
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package steward
+
+import (
+	"github.com/dyrkin/zcl-go/cluster"
+	"github.com/dyrkin/zigbee-steward/clusters"
+	"github.com/dyrkin/zigbee-steward/host"
+	"time"
+)
+
+type daylightSaving struct {
+	start time.Time
+	end   time.Time
+	shift int
+}
+
+func (s *Steward) timeCluster() *host.Cluster {
+	timeCluster := host.NewCluster(clusters.Time)
+	for attributeId, attribute := range timeAttributes(time.Now()) {
+		attributeId := attributeId
+		timeCluster.AddAttribute(&host.Attribute{
+			Id:       attributeId,
+			DataType: attribute.DataType,
+			Access:   cluster.Read,
+			Getter: func() interface{} {
+				return timeAttributes(time.Now())[attributeId].Value
+			},
+		})
+	}
+	return timeCluster
+}
+
+func timeAttributes(now time.Time) map[uint16]*cluster.Attribute {
+	utc := clusters.FromTime(now)
+	_, offset := now.Zone()
+	standardOffset := offset
+	dstStart, dstEnd, dstShift := clusters.TimeInvalid, clusters.TimeInvalid, 0
+	if dst, ok := nextDaylightSaving(now); ok {
+		if now.IsDST() {
+			standardOffset -= dst.shift
+		}
+		dstStart, dstEnd, dstShift = clusters.FromTime(dst.start), clusters.FromTime(dst.end), dst.shift
+	}
+	return map[uint16]*cluster.Attribute{
+		0x0000: {DataType: cluster.ZclDataTypeUtc, Value: utc},
+		0x0001: {DataType: cluster.ZclDataTypeBitmap8, Value: uint64(clusters.TimeStatusMaster | clusters.TimeStatusMasterZoneDst)},
+		0x0002: {DataType: cluster.ZclDataTypeInt32, Value: int64(standardOffset)},
+		0x0003: {DataType: cluster.ZclDataTypeUint32, Value: uint64(dstStart)},
+		0x0004: {DataType: cluster.ZclDataTypeUint32, Value: uint64(dstEnd)},
+		0x0005: {DataType: cluster.ZclDataTypeInt32, Value: int64(dstShift)},
+		0x0006: {DataType: cluster.ZclDataTypeUint32, Value: uint64(int64(utc) + int64(standardOffset))},
+		0x0007: {DataType: cluster.ZclDataTypeUint32, Value: uint64(int64(utc) + int64(offset))},
+	}
+}
+
+func nextDaylightSaving(now time.Time) (*daylightSaving, bool) {
+	start, end := now.ZoneBounds()
+	if now.IsDST() {
+		_, offset := now.Zone()
+		_, standardOffset := end.Zone()
+		return &daylightSaving{start: start, end: end, shift: offset - standardOffset}, !end.IsZero()
+	}
+	if end.IsZero() {
+		return nil, false
+	}
+	_, standardOffset := now.Zone()
+	if !end.IsDST() {
+		return nil, false
+	}
+	_, offset := end.Zone()
+	_, dstEnd := end.ZoneBounds()
+	return &daylightSaving{start: end, end: dstEnd, shift: offset - standardOffset}, !dstEnd.IsZero()
+}
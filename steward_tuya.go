@@ -0,0 +1,34 @@
+package steward
+
+import (
+	"github.com/dyrkin/zigbee-steward/clusters"
+	"github.com/dyrkin/zigbee-steward/metrics"
+	"github.com/dyrkin/zigbee-steward/model"
+	"github.com/dyrkin/zigbee-steward/tuya"
+)
+
+func (s *Steward) processTuyaMessage(message *model.DeviceIncomingMessage) {
+	command, ok := message.IncomingMessage.Data.Command.(*clusters.TuyaDataCommand)
+	if !ok {
+		return
+	}
+	report := &model.DeviceTuyaReport{
+		Device:   message.Device,
+		Endpoint: message.IncomingMessage.SrcEndpoint,
+		Sequence: command.Sequence,
+	}
+	for _, raw := range command.Datapoints {
+		datapoint, err := tuya.Decode(raw)
+		if err != nil {
+			log.Errorf("Unable to decode Tuya datapoint of [%s]: %s", message.Device.IEEEAddress, err)
+			continue
+		}
+		report.Datapoints = append(report.Datapoints, datapoint)
+	}
+	select {
+	case s.channels.onDeviceTuyaReport <- report:
+	default:
+		metrics.ChannelDrop("onDeviceTuyaReport")
+		log.Errorf("onDeviceTuyaReport channel has no capacity. Maybe channel has no subscribers")
+	}
+}
@@ -0,0 +1,47 @@
+package steward
+
+import (
+	"github.com/dyrkin/zcl-go/cluster"
+	"github.com/dyrkin/zigbee-steward/converters"
+	"github.com/dyrkin/zigbee-steward/metrics"
+	"github.com/dyrkin/zigbee-steward/model"
+)
+
+func (s *Steward) processXiaomiMessage(message *model.DeviceIncomingMessage, data []uint8) {
+	device := message.Device
+	if !converters.IsXiaomi(device) {
+		return
+	}
+	endpoint := message.IncomingMessage.SrcEndpoint
+	clusterId := cluster.ClusterId(message.IncomingMessage.ClusterID)
+	switch clusterId {
+	case cluster.Basic:
+		values, err := converters.XiaomiBasicReport(data)
+		if err != nil {
+			log.Debugf("Unable to decode Xiaomi attributes of [%s]: %s", device.IEEEAddress, err)
+			return
+		}
+		if reading, ok := converters.XiaomiReading(device, endpoint, values); ok {
+			s.notifySensorReading(reading)
+		}
+	case cluster.MultistateInput, cluster.OnOff:
+		command, ok := message.IncomingMessage.Data.Command.(*cluster.ReportAttributesCommand)
+		if !ok {
+			return
+		}
+		for _, report := range command.AttributeReports {
+			if click, ok := converters.XiaomiClick(device, clusterId, report); ok {
+				s.notifyClick(&model.DeviceClick{Device: device, Endpoint: endpoint, Click: click})
+			}
+		}
+	}
+}
+
+func (s *Steward) notifyClick(click *model.DeviceClick) {
+	select {
+	case s.channels.onDeviceClick <- click:
+	default:
+		metrics.ChannelDrop("onDeviceClick")
+		log.Errorf("onDeviceClick channel has no capacity. Maybe channel has no subscribers")
+	}
+}
@@ -0,0 +1,66 @@
+package tuya
+
+import "github.com/dyrkin/zigbee-steward/model"
+
+var builtinDevices = []*Device{
+	{
+		Vendor:        "Saswell",
+		Description:   "Thermostatic radiator valve",
+		Manufacturers: []string{"_TZE200_c88teujp", "_TYST11_c88teujp"},
+		Models:        []string{"TS0601", "88teujp"},
+		Mappings: []*Mapping{
+			{Datapoint: 8, Name: "window_detection", Type: model.TuyaDatapointTypeBool, Writable: true},
+			{Datapoint: 10, Name: "frost_detection", Type: model.TuyaDatapointTypeBool, Writable: true},
+			{Datapoint: 27, Name: "local_temperature_calibration", Type: model.TuyaDatapointTypeValue, Writable: true},
+			{Datapoint: 40, Name: "child_lock", Type: model.TuyaDatapointTypeBool, Writable: true},
+			{Datapoint: 101, Name: "state", Type: model.TuyaDatapointTypeBool, Writable: true},
+			{Datapoint: 102, Name: "local_temperature", Type: model.TuyaDatapointTypeValue, Scale: 10},
+			{Datapoint: 103, Name: "heating_setpoint", Type: model.TuyaDatapointTypeValue, Scale: 10, Writable: true},
+			{Datapoint: 104, Name: "valve_position", Type: model.TuyaDatapointTypeValue},
+			{Datapoint: 105, Name: "battery_low", Type: model.TuyaDatapointTypeBool},
+			{Datapoint: 106, Name: "away_mode", Type: model.TuyaDatapointTypeBool, Writable: true},
+			{Datapoint: 108, Name: "schedule_mode", Type: model.TuyaDatapointTypeBool, Writable: true},
+		},
+	},
+	{
+		Vendor:        "Tuya",
+		Description:   "Curtain motor",
+		Manufacturers: []string{"_TZE200_xuzcvlku", "_TZE200_zah67ekd", "_TZE200_rddyvrci", "_TZE200_5zbp6j0u"},
+		Models:        []string{"TS0601"},
+		Mappings: []*Mapping{
+			{
+				Datapoint: 1,
+				Name:      "control",
+				Type:      model.TuyaDatapointTypeEnum,
+				Values:    map[uint64]string{0: "OPEN", 1: "STOP", 2: "CLOSE"},
+				Writable:  true,
+			},
+			{Datapoint: 2, Name: "position", Type: model.TuyaDatapointTypeValue, Writable: true},
+			{Datapoint: 3, Name: "current_position", Type: model.TuyaDatapointTypeValue},
+			{
+				Datapoint: 5,
+				Name:      "motor_direction",
+				Type:      model.TuyaDatapointTypeEnum,
+				Values:    map[uint64]string{0: "forward", 1: "backward"},
+				Writable:  true,
+			},
+			{
+				Datapoint: 7,
+				Name:      "work_state",
+				Type:      model.TuyaDatapointTypeEnum,
+				Values:    map[uint64]string{0: "opening", 1: "closing"},
+			},
+		},
+	},
+	{
+		Vendor:        "Tuya",
+		Description:   "Temperature and humidity sensor",
+		Manufacturers: []string{"_TZE200_bjawzodf", "_TZE200_zl1kmjqx"},
+		Models:        []string{"TS0601"},
+		Mappings: []*Mapping{
+			{Datapoint: 1, Name: "temperature", Type: model.TuyaDatapointTypeValue, Scale: 10},
+			{Datapoint: 2, Name: "humidity", Type: model.TuyaDatapointTypeValue, Scale: 10},
+			{Datapoint: 4, Name: "battery", Type: model.TuyaDatapointTypeValue},
+		},
+	},
+}
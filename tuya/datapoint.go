@@ -0,0 +1,120 @@
+package tuya
+
+import (
+	"encoding/binary"
+	"fmt"
+	"github.com/dyrkin/zigbee-steward/clusters"
+	"github.com/dyrkin/zigbee-steward/model"
+)
+
+func Decode(raw *clusters.TuyaDatapoint) (*model.TuyaDatapoint, error) {
+	datapoint := &model.TuyaDatapoint{Id: raw.Id, Type: model.TuyaDatapointType(raw.Type)}
+	data := raw.Data
+	switch datapoint.Type {
+	case model.TuyaDatapointTypeRaw:
+		datapoint.Value = append([]uint8{}, data...)
+	case model.TuyaDatapointTypeBool:
+		if len(data) != 1 {
+			return nil, invalidLength(raw)
+		}
+		datapoint.Value = data[0] > 0
+	case model.TuyaDatapointTypeValue:
+		if len(data) != 4 {
+			return nil, invalidLength(raw)
+		}
+		datapoint.Value = int64(int32(binary.BigEndian.Uint32(data)))
+	case model.TuyaDatapointTypeString:
+		datapoint.Value = string(data)
+	case model.TuyaDatapointTypeEnum:
+		if len(data) != 1 {
+			return nil, invalidLength(raw)
+		}
+		datapoint.Value = uint64(data[0])
+	case model.TuyaDatapointTypeBitmap:
+		switch len(data) {
+		case 1:
+			datapoint.Value = uint64(data[0])
+		case 2:
+			datapoint.Value = uint64(binary.BigEndian.Uint16(data))
+		case 4:
+			datapoint.Value = uint64(binary.BigEndian.Uint32(data))
+		default:
+			return nil, invalidLength(raw)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported type [%d] of datapoint [%d]", raw.Type, raw.Id)
+	}
+	return datapoint, nil
+}
+
+func Encode(datapoint *model.TuyaDatapoint) (*clusters.TuyaDatapoint, error) {
+	raw := &clusters.TuyaDatapoint{Id: datapoint.Id, Type: uint8(datapoint.Type)}
+	switch datapoint.Type {
+	case model.TuyaDatapointTypeRaw:
+		if data, ok := datapoint.Value.([]uint8); ok {
+			raw.Data = data
+			return raw, nil
+		}
+	case model.TuyaDatapointTypeBool:
+		if value, ok := datapoint.Value.(bool); ok {
+			raw.Data = []uint8{0}
+			if value {
+				raw.Data[0] = 1
+			}
+			return raw, nil
+		}
+	case model.TuyaDatapointTypeValue:
+		if value, ok := integer(datapoint.Value); ok {
+			raw.Data = make([]uint8, 4)
+			binary.BigEndian.PutUint32(raw.Data, uint32(int32(value)))
+			return raw, nil
+		}
+	case model.TuyaDatapointTypeString:
+		if value, ok := datapoint.Value.(string); ok {
+			raw.Data = []uint8(value)
+			return raw, nil
+		}
+	case model.TuyaDatapointTypeEnum:
+		if value, ok := integer(datapoint.Value); ok && value >= 0 && value <= 0xff {
+			raw.Data = []uint8{uint8(value)}
+			return raw, nil
+		}
+	case model.TuyaDatapointTypeBitmap:
+		if value, ok := integer(datapoint.Value); ok && value >= 0 {
+			switch {
+			case value <= 0xff:
+				raw.Data = []uint8{uint8(value)}
+			case value <= 0xffff:
+				raw.Data = make([]uint8, 2)
+				binary.BigEndian.PutUint16(raw.Data, uint16(value))
+			default:
+				raw.Data = make([]uint8, 4)
+				binary.BigEndian.PutUint32(raw.Data, uint32(value))
+			}
+			return raw, nil
+		}
+	default:
+		return nil, fmt.Errorf("unsupported type [%d] of datapoint [%d]", datapoint.Type, datapoint.Id)
+	}
+	return nil, fmt.Errorf("invalid value of %s datapoint [%d]: [%v]", datapoint.Type, datapoint.Id, datapoint.Value)
+}
+
+func integer(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint8:
+		return int64(v), true
+	case uint64:
+		return int64(v), true
+	case float64:
+		return int64(v), true
+	}
+	return 0, false
+}
+
+func invalidLength(raw *clusters.TuyaDatapoint) error {
+	return fmt.Errorf("invalid length [%d] of %s datapoint [%d]", len(raw.Data), model.TuyaDatapointType(raw.Type), raw.Id)
+}
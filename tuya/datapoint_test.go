@@ -0,0 +1,140 @@
+package tuya
+
+import (
+	"github.com/dyrkin/zigbee-steward/clusters"
+	"github.com/dyrkin/zigbee-steward/model"
+	"reflect"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     *clusters.TuyaDatapoint
+		want    interface{}
+		wantErr bool
+	}{
+		{"raw", &clusters.TuyaDatapoint{Id: 1, Type: 0, Data: []uint8{0xde, 0xad}}, []uint8{0xde, 0xad}, false},
+		{"bool true", &clusters.TuyaDatapoint{Id: 1, Type: 1, Data: []uint8{1}}, true, false},
+		{"bool false", &clusters.TuyaDatapoint{Id: 1, Type: 1, Data: []uint8{0}}, false, false},
+		{"bool invalid length", &clusters.TuyaDatapoint{Id: 1, Type: 1, Data: []uint8{0, 1}}, nil, true},
+		{"value", &clusters.TuyaDatapoint{Id: 2, Type: 2, Data: []uint8{0x00, 0x00, 0x01, 0x2c}}, int64(300), false},
+		{"negative value", &clusters.TuyaDatapoint{Id: 2, Type: 2, Data: []uint8{0xff, 0xff, 0xff, 0xf6}}, int64(-10), false},
+		{"value invalid length", &clusters.TuyaDatapoint{Id: 2, Type: 2, Data: []uint8{0x01, 0x2c}}, nil, true},
+		{"string", &clusters.TuyaDatapoint{Id: 3, Type: 3, Data: []uint8("auto")}, "auto", false},
+		{"enum", &clusters.TuyaDatapoint{Id: 4, Type: 4, Data: []uint8{2}}, uint64(2), false},
+		{"enum invalid length", &clusters.TuyaDatapoint{Id: 4, Type: 4, Data: []uint8{}}, nil, true},
+		{"bitmap 8", &clusters.TuyaDatapoint{Id: 5, Type: 5, Data: []uint8{0x81}}, uint64(0x81), false},
+		{"bitmap 16", &clusters.TuyaDatapoint{Id: 5, Type: 5, Data: []uint8{0x01, 0x02}}, uint64(0x0102), false},
+		{"bitmap 32", &clusters.TuyaDatapoint{Id: 5, Type: 5, Data: []uint8{0x01, 0x02, 0x03, 0x04}}, uint64(0x01020304), false},
+		{"bitmap invalid length", &clusters.TuyaDatapoint{Id: 5, Type: 5, Data: []uint8{1, 2, 3}}, nil, true},
+		{"unsupported type", &clusters.TuyaDatapoint{Id: 6, Type: 9, Data: []uint8{1}}, nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Decode(test.raw)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Id != test.raw.Id || uint8(got.Type) != test.raw.Type {
+				t.Errorf("got id [%d] type [%d], want id [%d] type [%d]", got.Id, got.Type, test.raw.Id, test.raw.Type)
+			}
+			if !reflect.DeepEqual(got.Value, test.want) {
+				t.Errorf("got %#v, want %#v", got.Value, test.want)
+			}
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name      string
+		datapoint *model.TuyaDatapoint
+		want      []uint8
+		wantErr   bool
+	}{
+		{"raw", &model.TuyaDatapoint{Type: model.TuyaDatapointTypeRaw, Value: []uint8{1, 2}}, []uint8{1, 2}, false},
+		{"bool", &model.TuyaDatapoint{Type: model.TuyaDatapointTypeBool, Value: true}, []uint8{1}, false},
+		{"bool invalid", &model.TuyaDatapoint{Type: model.TuyaDatapointTypeBool, Value: "on"}, nil, true},
+		{"value", &model.TuyaDatapoint{Type: model.TuyaDatapointTypeValue, Value: 300}, []uint8{0x00, 0x00, 0x01, 0x2c}, false},
+		{"negative value", &model.TuyaDatapoint{Type: model.TuyaDatapointTypeValue, Value: int64(-10)}, []uint8{0xff, 0xff, 0xff, 0xf6}, false},
+		{"float value", &model.TuyaDatapoint{Type: model.TuyaDatapointTypeValue, Value: float64(21)}, []uint8{0x00, 0x00, 0x00, 0x15}, false},
+		{"string", &model.TuyaDatapoint{Type: model.TuyaDatapointTypeString, Value: "auto"}, []uint8("auto"), false},
+		{"enum", &model.TuyaDatapoint{Type: model.TuyaDatapointTypeEnum, Value: uint64(2)}, []uint8{2}, false},
+		{"enum out of range", &model.TuyaDatapoint{Type: model.TuyaDatapointTypeEnum, Value: 256}, nil, true},
+		{"bitmap 8", &model.TuyaDatapoint{Type: model.TuyaDatapointTypeBitmap, Value: 0x81}, []uint8{0x81}, false},
+		{"bitmap 16", &model.TuyaDatapoint{Type: model.TuyaDatapointTypeBitmap, Value: 0x0102}, []uint8{0x01, 0x02}, false},
+		{"bitmap 32", &model.TuyaDatapoint{Type: model.TuyaDatapointTypeBitmap, Value: 0x010203}, []uint8{0x00, 0x01, 0x02, 0x03}, false},
+		{"negative bitmap", &model.TuyaDatapoint{Type: model.TuyaDatapointTypeBitmap, Value: -1}, nil, true},
+		{"unsupported type", &model.TuyaDatapoint{Type: model.TuyaDatapointType(9), Value: 1}, nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Encode(test.datapoint)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got.Data, test.want) {
+				t.Errorf("got %v, want %v", got.Data, test.want)
+			}
+		})
+	}
+}
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	datapoints := []*model.TuyaDatapoint{
+		{Id: 1, Type: model.TuyaDatapointTypeBool, Value: false},
+		{Id: 2, Type: model.TuyaDatapointTypeValue, Value: int64(-2147483648)},
+		{Id: 3, Type: model.TuyaDatapointTypeString, Value: "manual"},
+		{Id: 4, Type: model.TuyaDatapointTypeEnum, Value: uint64(255)},
+		{Id: 5, Type: model.TuyaDatapointTypeBitmap, Value: uint64(0xffff)},
+	}
+	for _, datapoint := range datapoints {
+		raw, err := Encode(datapoint)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded, err := Decode(raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(decoded, datapoint) {
+			t.Errorf("got %+v, want %+v", decoded, datapoint)
+		}
+	}
+}
+
+func TestMappingToDatapoint(t *testing.T) {
+	mapping := &Mapping{Datapoint: 2, Name: "current_heating_setpoint", Type: model.TuyaDatapointTypeValue, Scale: 10, Writable: true}
+	datapoint, err := mapping.ToDatapoint(21.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if datapoint.Value != int64(215) {
+		t.Errorf("got %#v, want 215", datapoint.Value)
+	}
+	mode := &Mapping{Datapoint: 4, Name: "preset", Type: model.TuyaDatapointTypeEnum, Values: map[uint64]string{0: "auto", 1: "manual"}, Writable: true}
+	datapoint, err = mode.ToDatapoint("Manual")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if datapoint.Value != uint64(1) {
+		t.Errorf("got %#v, want 1", datapoint.Value)
+	}
+	readOnly := &Mapping{Datapoint: 3, Name: "local_temperature", Type: model.TuyaDatapointTypeValue}
+	if _, err := readOnly.ToDatapoint(20); err == nil {
+		t.Error("expected error writing read only datapoint")
+	}
+}
@@ -0,0 +1,149 @@
+package tuya
+
+import (
+	"github.com/dyrkin/zigbee-steward/model"
+	"math"
+	"strings"
+	"sync"
+)
+
+type Mapping struct {
+	Datapoint uint8
+	Name      string
+	Type      model.TuyaDatapointType
+	Scale     float64
+	Values    map[uint64]string
+	Writable  bool
+}
+
+type Device struct {
+	Vendor        string
+	Description   string
+	Manufacturers []string
+	Models        []string
+	Mappings      []*Mapping
+}
+
+var (
+	mutex   sync.RWMutex
+	devices = builtinDevices
+)
+
+func Register(definitions ...*Device) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	devices = append(devices, definitions...)
+}
+
+func Devices() []*Device {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return append([]*Device{}, devices...)
+}
+
+func Find(device *model.Device) (*Device, bool) {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	for i := len(devices) - 1; i >= 0; i-- {
+		if devices[i].Matches(device) {
+			return devices[i], true
+		}
+	}
+	return nil, false
+}
+
+func (d *Device) Matches(device *model.Device) bool {
+	return contains(d.Manufacturers, trim(device.Manufacturer)) && contains(d.Models, trim(device.Model))
+}
+
+func (d *Device) Mapping(datapoint uint8) (*Mapping, bool) {
+	for _, m := range d.Mappings {
+		if m.Datapoint == datapoint {
+			return m, true
+		}
+	}
+	return nil, false
+}
+
+func (d *Device) MappingByName(name string) (*Mapping, bool) {
+	for _, m := range d.Mappings {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return nil, false
+}
+
+func (d *Device) State(datapoints []*model.TuyaDatapoint) model.State {
+	state := model.State{}
+	for _, datapoint := range datapoints {
+		if m, ok := d.Mapping(datapoint.Id); ok {
+			if value, ok := m.FromDatapoint(datapoint); ok {
+				state[m.Name] = value
+			}
+		}
+	}
+	return state
+}
+
+func (m *Mapping) FromDatapoint(datapoint *model.TuyaDatapoint) (interface{}, bool) {
+	if datapoint.Type != m.Type {
+		return nil, false
+	}
+	switch value := datapoint.Value.(type) {
+	case int64:
+		if m.Scale != 0 {
+			return float64(value) / m.Scale, true
+		}
+		return value, true
+	case uint64:
+		if name, ok := m.Values[value]; ok {
+			return name, true
+		}
+		return value, true
+	}
+	return datapoint.Value, true
+}
+
+func (m *Mapping) ToDatapoint(value interface{}) (*model.TuyaDatapoint, error) {
+	if !m.Writable {
+		return nil, model.InvalidRequest("datapoint [%s] is read only", m.Name)
+	}
+	datapoint := &model.TuyaDatapoint{Id: m.Datapoint, Type: m.Type, Value: value}
+	switch v := value.(type) {
+	case string:
+		if m.Type == model.TuyaDatapointTypeBool {
+			datapoint.Value = strings.EqualFold(v, "ON") || strings.EqualFold(v, "TRUE")
+		}
+		for raw, name := range m.Values {
+			if strings.EqualFold(name, v) {
+				datapoint.Value = raw
+			}
+		}
+	case float64:
+		if m.Scale != 0 {
+			datapoint.Value = int64(math.Round(v * m.Scale))
+		}
+	case int:
+		if m.Scale != 0 {
+			datapoint.Value = int64(math.Round(float64(v) * m.Scale))
+		}
+	}
+	if _, err := Encode(datapoint); err != nil {
+		return nil, model.InvalidRequest("%s", err)
+	}
+	return datapoint, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func trim(value string) string {
+	return strings.TrimSpace(strings.TrimRight(value, "\x00"))
+}